@@ -29,7 +29,9 @@ const _channelBufferLength = 1000
 // of bus traffic to registered handlers and writing frames onto the bus.
 //
 // BusManager uses SocketCAN on the Linux platform. Note that
-// it does not manage the lifetime of the network socket connection.
+// it does not dial the initial network socket connection, but it
+// takes ownership of it: Close closes the connection, and Redial
+// closes it and replaces it with a connection it dials itself.
 //
 // Example:
 //
@@ -197,6 +199,34 @@ func (b *BusManager) Close() error {
 	return nil
 }
 
+// Redial replaces the network connection with a new one, for example after the CAN interface went down.
+// The previous connection is closed, including the one passed to NewBusManager.
+//
+// Registered handlers are kept, but the BusManager is stopped and must be started again.
+func (b *BusManager) Redial(ctx context.Context, network, address string) error {
+	if b.isRunning {
+		b.Stop()
+	}
+
+	b.l.Info("redialing socketcan connection", zap.String("address", address))
+
+	conn, err := socketcan.DialContext(ctx, network, address)
+	if err != nil {
+		return errors.Wrap(err, "dial context")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.receiver.Close()
+	b.transmitter.Close()
+
+	b.receiver = socketcan.NewReceiver(conn)
+	b.transmitter = socketcan.NewTransmitter(conn)
+
+	return nil
+}
+
 // Send transmits frames onto the connection.
 func (b *BusManager) Send(ctx context.Context, msg generated.Message) error {
 	frame, err := msg.MarshalFrame()
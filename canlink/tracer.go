@@ -130,6 +130,15 @@ func (t *Tracer) GetFileName() string {
 	return fmt.Sprintf("%s.%s", t.fileName, t.converter.GetFileExtension())
 }
 
+// GetFilePath returns the path of the current trace file, it is empty until a trace dir has been set
+func (t *Tracer) GetFilePath() string {
+	if t.traceFile == nil {
+		return ""
+	}
+
+	return t.traceFile.Name()
+}
+
 // SetTraceDir changes the directory where trace files are logged to and creates a new trace file
 func (t *Tracer) SetTraceDir(traceDir string) error {
 	t.traceDir = traceDir
@@ -19,8 +19,13 @@ type CliDispatcher struct {
 	start            chan orchestrator.StartSignal
 	results          chan orchestrator.ResultsSignal
 	status           chan orchestrator.StatusSignal
+	measurements     chan orchestrator.MeasurementSignal
 	cancelTest       chan orchestrator.CancelTestSignal
+	moveTest         chan orchestrator.MoveTestSignal
 	recoverFromFatal chan orchestrator.RecoverFromFatalSignal
+	pause            chan orchestrator.PauseSignal
+	resume           chan orchestrator.ResumeSignal
+	step             chan orchestrator.StepSignal
 	cli              cliIface
 }
 
@@ -31,8 +36,13 @@ func NewCliDispatcher(sequences []flow.Sequence, l *zap.Logger) *CliDispatcher {
 		start:            make(chan orchestrator.StartSignal, 5),
 		results:          make(chan orchestrator.ResultsSignal),
 		status:           make(chan orchestrator.StatusSignal),
+		measurements:     make(chan orchestrator.MeasurementSignal),
 		cancelTest:       make(chan orchestrator.CancelTestSignal),
+		moveTest:         make(chan orchestrator.MoveTestSignal),
 		recoverFromFatal: make(chan orchestrator.RecoverFromFatalSignal),
+		pause:            make(chan orchestrator.PauseSignal),
+		resume:           make(chan orchestrator.ResumeSignal),
+		step:             make(chan orchestrator.StepSignal),
 		cli:              newCliModel(sequences, l),
 	}
 }
@@ -74,11 +84,31 @@ func (c *CliDispatcher) CancelTest() <-chan orchestrator.CancelTestSignal {
 	return c.cancelTest
 }
 
+// MoveTest will move a queued test to a new position in the test queue.
+func (c *CliDispatcher) MoveTest() <-chan orchestrator.MoveTestSignal {
+	return c.moveTest
+}
+
 // RecoverFromFatal will tell the orchestrator to leave the fatal error state and go back to idle.
 func (c *CliDispatcher) RecoverFromFatal() <-chan orchestrator.RecoverFromFatalSignal {
 	return c.recoverFromFatal
 }
 
+// Pause will halt the running test before its next state.
+func (c *CliDispatcher) Pause() <-chan orchestrator.PauseSignal {
+	return c.pause
+}
+
+// Resume will continue a paused test.
+func (c *CliDispatcher) Resume() <-chan orchestrator.ResumeSignal {
+	return c.resume
+}
+
+// Step will run the next state of a paused test and pause again.
+func (c *CliDispatcher) Step() <-chan orchestrator.StepSignal {
+	return c.step
+}
+
 // Status signal is sent on updates from the orchestrator.
 func (c *CliDispatcher) Status() chan<- orchestrator.StatusSignal {
 	return c.status
@@ -89,6 +119,11 @@ func (c *CliDispatcher) Results() chan<- orchestrator.ResultsSignal {
 	return c.results
 }
 
+// Measurements signal is sent for every live sample published by the running test.
+func (c *CliDispatcher) Measurements() chan<- orchestrator.MeasurementSignal {
+	return c.measurements
+}
+
 // Quit signal will shut down the app.
 func (c *CliDispatcher) Quit() chan orchestrator.ShutdownSignal {
 	return c.cli.Quit()
@@ -114,6 +149,22 @@ func (c *CliDispatcher) monitorCli(ctx context.Context, cli cliIface) {
 			c.l.Info("cancel test signal received")
 
 			c.cancelTest <- cancelSignal
+		case moveSignal := <-cli.MoveTest():
+			c.l.Info("move test signal received")
+
+			c.moveTest <- moveSignal
+		case pauseSignal := <-cli.Pause():
+			c.l.Info("pause signal received")
+
+			c.pause <- pauseSignal
+		case resumeSignal := <-cli.Resume():
+			c.l.Info("resume signal received")
+
+			c.resume <- resumeSignal
+		case stepSignal := <-cli.Step():
+			c.l.Info("step signal received")
+
+			c.step <- stepSignal
 		case fatalSignal := <-cli.RecoverFromFatal():
 			c.l.Info("fatal recovery signal received")
 
@@ -138,6 +189,8 @@ func (c *CliDispatcher) monitorOrchestrator(ctx context.Context) {
 			c.l.Info("results signal received")
 
 			c.cli.Results() <- results
+		case measurement := <-c.measurements:
+			c.cli.Measurements() <- measurement
 		case <-ctx.Done():
 			c.l.Info("context done signal received")
 
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/uuid"
@@ -22,6 +25,11 @@ const (
 	_sigKill           = "ctrl+c"
 	_quitKey           = "q"
 	_escapeKey         = "esc"
+	_priorityStartKey  = "p"
+	_moveToFrontKey    = "t"
+	_pauseKey          = " "
+	_stepKey           = "n"
+	_breakpointKey     = "b"
 	_sequenceListTitle = "HIL"
 	_showLastResults   = 5
 )
@@ -40,8 +48,13 @@ type cliModel struct {
 	startChan   chan orchestrator.StartSignal
 	resultsChan chan orchestrator.ResultsSignal
 	statusChan  chan orchestrator.StatusSignal
+	measureChan chan orchestrator.MeasurementSignal
 	fatalChan   chan orchestrator.RecoverFromFatalSignal
 	cancelChan  chan orchestrator.CancelTestSignal
+	moveChan    chan orchestrator.MoveTestSignal
+	pauseChan   chan orchestrator.PauseSignal
+	resumeChan  chan orchestrator.ResumeSignal
+	stepChan    chan orchestrator.StepSignal
 	quit        chan orchestrator.ShutdownSignal
 
 	currentScreen         screenState
@@ -51,7 +64,15 @@ type cliModel struct {
 	testToRun             orchestrator.TestId
 	testItem              sequenceItem
 	orchestratorWorking   bool
+	measurements          *measurementPanel
 	fatalErr              error
+	// breakpoints are the state names to pause before, keyed by sequence name. They apply to tests started later.
+	breakpoints map[string]map[string]struct{}
+	// pendingStart is the test waiting for the metadata keys in pendingKeys to be entered in metadataInput.
+	pendingStart  orchestrator.StartSignal
+	pendingItem   sequenceItem
+	pendingKeys   []string
+	metadataInput textinput.Model
 }
 
 func newCliModel(sequences []flow.Sequence, l *zap.Logger) *cliModel {
@@ -64,6 +85,12 @@ func newCliModel(sequences []flow.Sequence, l *zap.Logger) *cliModel {
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("206"))
 	sequenceList := list.New(sequenceItems, list.NewDefaultDelegate(), 0, 0)
 	sequenceList.Title = _sequenceListTitle
+	sequenceList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys(_enterKey), key.WithHelp(_enterKey, "run")),
+			key.NewBinding(key.WithKeys(_priorityStartKey), key.WithHelp(_priorityStartKey, "run high priority")),
+		}
+	}
 
 	cli := cliModel{
 		l:                     l.Named(_loggerName),
@@ -71,13 +98,21 @@ func newCliModel(sequences []flow.Sequence, l *zap.Logger) *cliModel {
 		startChan:             make(chan orchestrator.StartSignal),
 		resultsChan:           make(chan orchestrator.ResultsSignal),
 		statusChan:            make(chan orchestrator.StatusSignal),
+		measureChan:           make(chan orchestrator.MeasurementSignal),
 		cancelChan:            make(chan orchestrator.CancelTestSignal),
+		moveChan:              make(chan orchestrator.MoveTestSignal),
+		pauseChan:             make(chan orchestrator.PauseSignal),
+		resumeChan:            make(chan orchestrator.ResumeSignal),
+		stepChan:              make(chan orchestrator.StepSignal),
 		fatalChan:             make(chan orchestrator.RecoverFromFatalSignal),
 		currentScreen:         Idle,
 		spinner:               sp,
 		results:               make([]result, _showLastResults),
+		measurements:          newMeasurementPanel(),
 		currentRunningResults: make([]result, _showLastResults),
 		quit:                  make(chan orchestrator.ShutdownSignal),
+		breakpoints:           make(map[string]map[string]struct{}),
+		metadataInput:         textinput.New(),
 	}
 
 	return &cli
@@ -101,6 +136,26 @@ func (c *cliModel) CancelTest() chan orchestrator.CancelTestSignal {
 	return c.cancelChan
 }
 
+// MoveTest will signal the dispatcher to move the queued test the Cli is trying to run
+func (c *cliModel) MoveTest() chan orchestrator.MoveTestSignal {
+	return c.moveChan
+}
+
+// Pause will signal the dispatcher to halt the running test before its next state.
+func (c *cliModel) Pause() chan orchestrator.PauseSignal {
+	return c.pauseChan
+}
+
+// Resume will signal the dispatcher to continue a paused test.
+func (c *cliModel) Resume() chan orchestrator.ResumeSignal {
+	return c.resumeChan
+}
+
+// Step will signal the dispatcher to run the next state of a paused test.
+func (c *cliModel) Step() chan orchestrator.StepSignal {
+	return c.stepChan
+}
+
 // RecoverFromFatal is sent to signal the dispatcher that the Fatal error has been fixed.
 func (c *cliModel) RecoverFromFatal() chan orchestrator.RecoverFromFatalSignal {
 	return c.fatalChan
@@ -116,6 +171,11 @@ func (c *cliModel) Results() chan orchestrator.ResultsSignal {
 	return c.resultsChan
 }
 
+// Measurements are the live samples published by the running test.
+func (c *cliModel) Measurements() chan orchestrator.MeasurementSignal {
+	return c.measureChan
+}
+
 // Quit signals the app to shut down.
 func (c *cliModel) Quit() chan orchestrator.ShutdownSignal {
 	return c.quit
@@ -130,8 +190,8 @@ func (c *cliModel) Init() tea.Cmd {
 func (c *cliModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	// Make sure these keys always quit
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+	// Make sure these keys always quit, unless metadata is being typed in.
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && c.currentScreen != Metadata {
 		k := keyMsg.String()
 		if k == _quitKey || k == _escapeKey {
 			c.quitting = true
@@ -159,6 +219,10 @@ func (c *cliModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case Results:
 		cmd = c.updateResults(msg)
 
+		return c, cmd
+	case Metadata:
+		cmd = c.updateMetadata(msg)
+
 		return c, cmd
 	default:
 		cmd = c.updateIdle(msg)
@@ -187,6 +251,8 @@ func (c *cliModel) View() string {
 		s = c.fatalView()
 	case Results:
 		s = c.resultsView()
+	case Metadata:
+		s = c.metadataView()
 	default:
 		c.idleView()
 	}
@@ -201,23 +267,44 @@ func (c *cliModel) updateIdle(msg tea.Msg) tea.Cmd {
 		h, v := docStyle.GetFrameSize()
 		c.sequenceList.SetSize(msgType.Width-h, msgType.Height-v)
 	case tea.KeyMsg:
-		switch msgType.String() {
-		case _enterKey:
+		switch key := msgType.String(); key {
+		case _enterKey, _priorityStartKey:
+			if c.sequenceList.FilterState() == list.Filtering {
+				break
+			}
+
+			priority := orchestrator.PriorityNormal
+			if key == _priorityStartKey {
+				priority = orchestrator.PriorityHigh
+			}
+
 			seqItem, ok := c.sequenceList.SelectedItem().(sequenceItem)
-			if ok {
-				c.testToRun = uuid.New()
-				c.startChan <- orchestrator.StartSignal{
-					TestId:   c.testToRun,
-					Seq:      flow.Sequence(seqItem),
-					Metadata: seqItem.getMetaData(),
-				}
-				c.testItem = seqItem
+			if !ok {
+				break
 			}
 
-			c.currentScreen = Running
-			c.results = make([]result, _showLastResults)
+			startSig := orchestrator.StartSignal{
+				TestId:      uuid.New(),
+				Seq:         flow.Sequence(seqItem),
+				Metadata:    seqItem.getMetaData(),
+				Priority:    priority,
+				Breakpoints: c.sequenceBreakpoints(seqItem.Name),
+			}
 
-			return c.spinner.Tick
+			// Ask for the required metadata before starting the test.
+			if missing := startSig.Seq.MissingMetadata(startSig.Metadata); len(missing) > 0 {
+				c.pendingStart = startSig
+				c.pendingItem = seqItem
+				c.pendingKeys = missing
+				c.currentScreen = Metadata
+
+				c.metadataInput.Reset()
+				c.metadataInput.Placeholder = missing[0]
+
+				return c.metadataInput.Focus()
+			}
+
+			return c.startTest(startSig, seqItem)
 		case _sigKill:
 			c.quitting = true
 			c.quit <- struct{}{}
@@ -237,6 +324,56 @@ func (c *cliModel) updateIdle(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
+// startTest sends the start signal and shows the running test.
+func (c *cliModel) startTest(startSig orchestrator.StartSignal, seqItem sequenceItem) tea.Cmd {
+	c.testToRun = startSig.TestId
+	c.startChan <- startSig
+	c.testItem = seqItem
+
+	c.currentScreen = Running
+	c.results = make([]result, _showLastResults)
+
+	return c.spinner.Tick
+}
+
+// updateMetadata collects the required metadata of the pending test one key at a time, then starts it.
+func (c *cliModel) updateMetadata(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case _escapeKey:
+			c.metadataInput.Blur()
+			c.currentScreen = Idle
+
+			return nil
+		case _enterKey:
+			value := strings.TrimSpace(c.metadataInput.Value())
+			if value == "" {
+				return nil
+			}
+
+			c.pendingStart.Metadata[c.pendingKeys[0]] = value
+			c.pendingKeys = c.pendingKeys[1:]
+			c.metadataInput.Reset()
+
+			if len(c.pendingKeys) == 0 {
+				c.metadataInput.Blur()
+
+				return c.startTest(c.pendingStart, c.pendingItem)
+			}
+
+			c.metadataInput.Placeholder = c.pendingKeys[0]
+
+			return nil
+		}
+	}
+
+	c.metadataInput, cmd = c.metadataInput.Update(msg)
+
+	return cmd
+}
+
 func (c *cliModel) updateRunning(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 
@@ -250,12 +387,80 @@ func (c *cliModel) updateRunning(msg tea.Msg) tea.Cmd {
 		case _sigKill:
 			testId := c.testToRun
 			c.cancelChan <- orchestrator.CancelTestSignal{TestId: testId}
+		case _moveToFrontKey:
+			c.moveChan <- orchestrator.MoveTestSignal{TestId: c.testToRun, Position: 0}
+		case _pauseKey:
+			if c.statusSignal.OrchestratorState == orchestrator.Paused {
+				c.resumeChan <- orchestrator.ResumeSignal{}
+			} else {
+				c.pauseChan <- orchestrator.PauseSignal{}
+			}
+		case _stepKey:
+			c.stepChan <- orchestrator.StepSignal{}
+		case _breakpointKey:
+			c.toggleBreakpoint()
 		}
 	}
 
 	return nil
 }
 
+// toggleBreakpoint adds or removes a breakpoint on the current state of the running sequence.
+func (c *cliModel) toggleBreakpoint() {
+	progress := c.statusSignal.Progress
+	if progress.CurrentState == nil {
+		return
+	}
+
+	seqName, stateName := progress.Sequence.Name, progress.CurrentState.Name()
+
+	states, ok := c.breakpoints[seqName]
+	if !ok {
+		states = make(map[string]struct{})
+		c.breakpoints[seqName] = states
+	}
+
+	if _, ok = states[stateName]; ok {
+		delete(states, stateName)
+	} else {
+		states[stateName] = struct{}{}
+	}
+}
+
+// sequenceBreakpoints returns the breakpoints set on the sequence in the order of its states.
+func (c *cliModel) sequenceBreakpoints(seqName string) []string {
+	states := c.breakpoints[seqName]
+	if len(states) == 0 {
+		return nil
+	}
+
+	seq, ok := c.sequenceByName(seqName)
+	if !ok {
+		return nil
+	}
+
+	ret := make([]string, 0, len(states))
+
+	for _, state := range seq.States {
+		if _, ok := states[state.Name()]; ok {
+			ret = append(ret, state.Name())
+		}
+	}
+
+	return ret
+}
+
+func (c *cliModel) sequenceByName(name string) (flow.Sequence, bool) {
+	for _, item := range c.sequenceList.Items() {
+		seqItem, ok := item.(sequenceItem)
+		if ok && seqItem.Name == name {
+			return flow.Sequence(seqItem), true
+		}
+	}
+
+	return flow.Sequence{}, false
+}
+
 func (c *cliModel) updateFatal(msg tea.Msg) tea.Cmd {
 	switch msgType := msg.(type) {
 	case spinner.TickMsg:
@@ -263,8 +468,10 @@ func (c *cliModel) updateFatal(msg tea.Msg) tea.Cmd {
 	case tea.KeyMsg:
 		switch msgType.String() {
 		case _enterKey:
-			c.currentScreen = Idle
-			c.fatalChan <- orchestrator.RecoverFromFatalSignal{}
+			// The screen changes once the orchestrator has recovered, it stays here if the recovery procedure fails.
+			if c.statusSignal.OrchestratorState == orchestrator.FatalError {
+				c.fatalChan <- orchestrator.RecoverFromFatalSignal{}
+			}
 
 			return c.spinner.Tick
 		case _sigKill:
@@ -315,6 +522,10 @@ func (c *cliModel) runningView() string {
 		s += " Work relevant to you...\n\n"
 	}
 
+	if progress := c.statusSignal.Progress; progress.Iterations > 1 {
+		s += fmt.Sprintf("Iteration %d/%d (%s)\n\n", progress.Iteration+1, progress.Iterations, progress.Params)
+	}
+
 	for _, res := range c.results {
 		if res.duration == 0 {
 			s += "........................\n"
@@ -329,12 +540,28 @@ func (c *cliModel) runningView() string {
 
 	state := c.statusSignal.Progress.CurrentState
 	if state != nil && c.orchestratorWorking {
-		s += fmt.Sprintf("%s currently running...\n", state.Name())
+		if c.statusSignal.OrchestratorState == orchestrator.Paused {
+			s += fmt.Sprintf("⏸️  Paused before %s\n", state.Name())
+		} else {
+			s += fmt.Sprintf("%s currently running...\n", c.statusSignal.Progress.CurrentPath())
+			s += childProgressView(c.statusSignal.Progress.Children, 1)
+		}
+	}
+
+	if c.currentRunningTestId == c.testToRun {
+		s += c.measurements.view(c.testToRun)
+	}
+
+	if breakpoints := c.sequenceBreakpoints(c.testItem.Name); len(breakpoints) > 0 {
+		s += helpStyle(fmt.Sprintf("\nBreakpoints (next run): %s\n", strings.Join(breakpoints, ", ")))
 	}
 
 	s += helpStyle(fmt.Sprintf("\nCurrent test running: %s\n", c.testItem.Name))
 	s += helpStyle(fmt.Sprintf("\nTest_ID: %s\n", c.testToRun.String()))
 	s += helpStyle(fmt.Sprintf("\nCtrl+c to cancel the test\n"))
+	s += helpStyle(fmt.Sprintf("\n\"t\" to move the test to the front of the queue\n"))
+	s += helpStyle(fmt.Sprintf("\n\"space\" to pause or resume, \"n\" to step to the next state\n"))
+	s += helpStyle(fmt.Sprintf("\n\"b\" to toggle a breakpoint on the current state\n"))
 
 	if c.quitting {
 		s += "\n"
@@ -366,12 +593,14 @@ func (c *cliModel) currentRunningTestView() string {
 
 	state := c.statusSignal.Progress.CurrentState
 	if state != nil && c.orchestratorWorking {
-		s += fmt.Sprintf("%s currently running...\n", state.Name())
+		s += fmt.Sprintf("%s currently running...\n", c.statusSignal.Progress.CurrentPath())
+		s += childProgressView(c.statusSignal.Progress.Children, 1)
 	}
 
 	s += helpStyle(fmt.Sprintf("\nCurrent test running: %s\n", c.currentRunningTestId.String()))
 	s += helpStyle(fmt.Sprintf("\nTest_ID: %s\n", c.currentRunningTestId.String()))
-	s += helpStyle(fmt.Sprintf("\nQueue length: %d\n", c.statusSignal.QueueLength))
+	s += helpStyle(fmt.Sprintf("\nQueue length: %d\n", len(c.statusSignal.Queue)))
+	s += queueView(c.statusSignal.Queue)
 
 	if c.quitting {
 		s += "\n"
@@ -380,11 +609,82 @@ func (c *cliModel) currentRunningTestView() string {
 	return docStyle.Render(s)
 }
 
+// queueView renders the queued tests in the order they will be considered to run.
+func queueView(queue []orchestrator.QueuedTest) string {
+	var builder strings.Builder
+
+	for i, queued := range queue {
+		builder.WriteString(fmt.Sprintf("  %d. %s (%s priority)", i+1, queued.SequenceName, queued.Priority))
+
+		if !queued.ScheduledAt.IsZero() {
+			builder.WriteString(fmt.Sprintf(", scheduled for %s", queued.ScheduledAt.Format(time.DateTime)))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	return helpStyle(builder.String())
+}
+
+// childProgressView renders the progress of the children of a composite state, indented by depth.
+func childProgressView(children []flow.ChildProgress, depth int) string {
+	var builder strings.Builder
+
+	for _, child := range children {
+		builder.WriteString(strings.Repeat("  ", depth))
+
+		switch {
+		case child.Running:
+			builder.WriteString(fmt.Sprintf("%s running...\n", child.Name))
+		case child.Passed:
+			builder.WriteString(fmt.Sprintf("%s %s, finished in %s\n", passed("Passed"), child.Name, child.Duration))
+		case child.Duration == 0:
+			builder.WriteString(fmt.Sprintf("%s waiting...\n", child.Name))
+		default:
+			builder.WriteString(fmt.Sprintf("%s %s, finished in %s\n", failed("Failed"), child.Name, child.Duration))
+		}
+
+		builder.WriteString(childProgressView(child.Children, depth+1))
+	}
+
+	return builder.String()
+}
+
+func (c *cliModel) metadataView() string {
+	s := fmt.Sprintf("%s requires metadata before it can start.\n\n", c.pendingItem.Name)
+	s += fmt.Sprintf("%s: %s\n", c.pendingKeys[0], c.metadataInput.View())
+	s += helpStyle("\nEnter to continue, esc to go back\n")
+
+	return s
+}
+
 func (c *cliModel) fatalView() string {
 	s := "\n"
 	s += helpStyle(fmt.Sprintf("\n%s\nHit \"enter\" to send the fatal recovery signal (CONTACT IVAN LANGE IF YOU DO NOT KNOW HOW TO FIX PROBLEM)\n",
 		title("💀💀💀 FATAL ERROR 💀💀💀")))
 	s += errorStyle(fmt.Sprintf("\nERROR: %s", c.fatalErr.Error()))
+
+	procedure, ok := flow.Recovery(c.fatalErr)
+	if ok {
+		if len(procedure.Checklist) > 0 {
+			s += "\n\nBefore recovering:\n"
+
+			for i, item := range procedure.Checklist {
+				s += fmt.Sprintf("  %d. %s\n", i+1, item)
+			}
+		}
+
+		if len(procedure.Actions) > 0 {
+			s += fmt.Sprintf("\nRecovering will run: %s\n", strings.Join(procedure.ActionNames(), ", "))
+		}
+	}
+
+	if c.statusSignal.OrchestratorState == orchestrator.Recovering {
+		s += fmt.Sprintf("\n%s Running recovery procedure...\n", c.spinner.View())
+	} else if c.statusSignal.RecoveryError != nil {
+		s += errorStyle(fmt.Sprintf("\nRECOVERY FAILED: %s", c.statusSignal.RecoveryError.Error()))
+	}
+
 	return s
 }
 
@@ -394,7 +694,13 @@ func (c *cliModel) resultsView() string {
 	results := c.resultsSignal
 
 	builder.WriteString(fmt.Sprintf("Test ID: %s\n", results.TestId.String()))
-	if results.IsPassing {
+	if results.BenchError != nil {
+		builder.WriteString(failed(fmt.Sprintf("BENCH ERROR\n\n")))
+		builder.WriteString(fmt.Sprintf("The test did not run: %s\n", results.BenchError.Error()))
+		builder.WriteString(helpStyle("\nPress enter to go back to Main Menu\n"))
+
+		return builder.String()
+	} else if results.IsPassing {
 		builder.WriteString(passed(fmt.Sprintf("PASSED\n\n")))
 	} else {
 		builder.WriteString(failed(fmt.Sprintf("FAILED\n\n")))
@@ -424,6 +730,13 @@ func (c *cliModel) resultsView() string {
 		builder.WriteString("No errors.\n")
 	}
 
+	if len(results.TeardownErrors) > 0 {
+		builder.WriteString("\nTeardown Errors (bench may need attention):\n")
+		for _, err := range results.TeardownErrors {
+			builder.WriteString(fmt.Sprintf("\t⚠️  %s\n", err))
+		}
+	}
+
 	builder.WriteString(helpStyle("\nPress enter to go back to Main Menu\n"))
 
 	return builder.String()
@@ -459,14 +772,17 @@ func (c *cliModel) monitorDispatcher(ctx context.Context) {
 				zap.Durations("state durations", progress.StateDuration),
 				zap.String("testid", status.TestId.String()))
 
-			if c.currentScreen == FatalError && status.OrchestratorState != orchestrator.FatalError {
+			isFatal := status.OrchestratorState == orchestrator.FatalError ||
+				status.OrchestratorState == orchestrator.Recovering
+
+			if c.currentScreen == FatalError && !isFatal {
 				c.currentScreen = Idle
 			}
 
 			if status.OrchestratorState == orchestrator.Idle {
 				c.orchestratorWorking = false
 				continue
-			} else if status.OrchestratorState == orchestrator.FatalError {
+			} else if isFatal {
 				c.orchestratorWorking = false
 				c.currentScreen = FatalError
 				continue
@@ -514,6 +830,8 @@ func (c *cliModel) monitorDispatcher(ctx context.Context) {
 			}
 
 			c.results = make([]result, _showLastResults)
+		case measurement := <-c.measureChan:
+			c.measurements.add(measurement)
 		case <-ctx.Done():
 			c.l.Info("context done signal received")
 
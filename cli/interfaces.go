@@ -15,12 +15,22 @@ type cliIface interface {
 	Start() chan orchestrator.StartSignal
 	// CancelTest will signal the Dispatcher to cancel execution of the current test the Cli is trying to run
 	CancelTest() chan orchestrator.CancelTestSignal
+	// MoveTest will signal the Dispatcher to move a queued test the Cli is trying to run
+	MoveTest() chan orchestrator.MoveTestSignal
+	// Pause will signal the Dispatcher to halt the running test before its next state
+	Pause() chan orchestrator.PauseSignal
+	// Resume will signal the Dispatcher to continue a paused test
+	Resume() chan orchestrator.ResumeSignal
+	// Step will signal the Dispatcher to run the next state of a paused test
+	Step() chan orchestrator.StepSignal
 	// Status signal is received when the orchestrator sends a new status
 	Status() chan orchestrator.StatusSignal
 	// RecoverFromFatal is sent to signal the orchestrator that the Fatal error has been fixed
 	RecoverFromFatal() chan orchestrator.RecoverFromFatalSignal
 	// Results signal is received when the orchestrator completes or cancels a test
 	Results() chan orchestrator.ResultsSignal
+	// Measurements signal is received for every live sample published by the running test
+	Measurements() chan orchestrator.MeasurementSignal
 	// Quit is sent when the user wants to quit the Cli
 	Quit() chan orchestrator.ShutdownSignal
 }
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/orchestrator"
+)
+
+const (
+	// _sparklineWidth is the number of samples shown for each measurement.
+	_sparklineWidth = 40
+	// _maxMeasurements is the number of measurements shown, later measurements are ignored.
+	_maxMeasurements = 8
+)
+
+var _sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// measurementPanel keeps the latest samples of each measurement published by the running test.
+type measurementPanel struct {
+	mtx    sync.Mutex
+	testId orchestrator.TestId
+	// names keeps the measurements in the order they were first published.
+	names  []string
+	series map[string][]flow.Sample
+}
+
+func newMeasurementPanel() *measurementPanel {
+	return &measurementPanel{
+		testId: uuid.Nil,
+		names:  make([]string, 0),
+		series: make(map[string][]flow.Sample),
+	}
+}
+
+// add records the sample, the samples of a previous test are cleared when a new test starts publishing.
+func (m *measurementPanel) add(measurement orchestrator.MeasurementSignal) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if measurement.TestId != m.testId {
+		m.testId = measurement.TestId
+		m.names = make([]string, 0)
+		m.series = make(map[string][]flow.Sample)
+	}
+
+	sample := measurement.Sample
+
+	samples, ok := m.series[sample.Name]
+	if !ok {
+		if len(m.names) >= _maxMeasurements {
+			return
+		}
+
+		m.names = append(m.names, sample.Name)
+	}
+
+	samples = append(samples, sample)
+	if len(samples) > _sparklineWidth {
+		samples = samples[len(samples)-_sparklineWidth:]
+	}
+
+	m.series[sample.Name] = samples
+}
+
+// view renders a sparkline and the latest value of each measurement of the test.
+func (m *measurementPanel) view(testId orchestrator.TestId) string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if testId != m.testId || len(m.names) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, name := range m.names {
+		width = max(width, len(name))
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("\nMeasurements:\n")
+
+	for _, name := range m.names {
+		samples := m.series[name]
+
+		builder.WriteString(fmt.Sprintf("  %-*s %-*s %s\n",
+			width, name, _sparklineWidth, sparkline(samples), formatSample(samples[len(samples)-1])))
+	}
+
+	return builder.String()
+}
+
+// sparkline scales the samples between their minimum and maximum, a flat series is drawn at the bottom.
+func sparkline(samples []flow.Sample) string {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, sample := range samples {
+		low = math.Min(low, sample.Value)
+		high = math.Max(high, sample.Value)
+	}
+
+	var builder strings.Builder
+
+	for _, sample := range samples {
+		level := 0
+		if high > low {
+			level = int((sample.Value - low) / (high - low) * float64(len(_sparkBlocks)-1))
+		}
+
+		builder.WriteRune(_sparkBlocks[level])
+	}
+
+	return builder.String()
+}
+
+func formatSample(sample flow.Sample) string {
+	if sample.IsBool {
+		if sample.Value != 0 {
+			return passed("HIGH")
+		}
+
+		return failed("LOW")
+	}
+
+	if sample.Unit == "" {
+		return fmt.Sprintf("%.3g", sample.Value)
+	}
+
+	return fmt.Sprintf("%.3g %s", sample.Value, sample.Unit)
+}
@@ -9,4 +9,6 @@ const (
 	Running
 	FatalError
 	Results
+	// Metadata prompts for the required metadata of a sequence before it is started.
+	Metadata
 )
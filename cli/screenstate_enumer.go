@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _screenStateName = "UnknownIdleRunningFatalErrorResults"
+const _screenStateName = "UnknownIdleRunningFatalErrorResultsMetadata"
 
-var _screenStateIndex = [...]uint8{0, 7, 11, 18, 28, 35}
+var _screenStateIndex = [...]uint8{0, 7, 11, 18, 28, 35, 43}
 
-const _screenStateLowerName = "unknownidlerunningfatalerrorresults"
+const _screenStateLowerName = "unknownidlerunningfatalerrorresultsmetadata"
 
 func (i screenState) String() string {
 	if i < 0 || i >= screenState(len(_screenStateIndex)-1) {
@@ -29,9 +29,10 @@ func _screenStateNoOp() {
 	_ = x[Running-(2)]
 	_ = x[FatalError-(3)]
 	_ = x[Results-(4)]
+	_ = x[Metadata-(5)]
 }
 
-var _screenStateValues = []screenState{Unknown, Idle, Running, FatalError, Results}
+var _screenStateValues = []screenState{Unknown, Idle, Running, FatalError, Results, Metadata}
 
 var _screenStateNameToValueMap = map[string]screenState{
 	_screenStateName[0:7]:        Unknown,
@@ -44,6 +45,8 @@ var _screenStateNameToValueMap = map[string]screenState{
 	_screenStateLowerName[18:28]: FatalError,
 	_screenStateName[28:35]:      Results,
 	_screenStateLowerName[28:35]: Results,
+	_screenStateName[35:43]:      Metadata,
+	_screenStateLowerName[35:43]: Metadata,
 }
 
 var _screenStateNames = []string{
@@ -52,6 +55,7 @@ var _screenStateNames = []string{
 	_screenStateName[11:18],
 	_screenStateName[18:28],
 	_screenStateName[28:35],
+	_screenStateName[35:43],
 }
 
 // screenStateString retrieves an enum value from the enum constants string name.
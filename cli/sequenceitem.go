@@ -21,7 +21,7 @@ func (i sequenceItem) FilterValue() string {
 	return i.Name
 }
 
-// Metadata is not yet used.
+// getMetaData returns the metadata every test started from the cli has. Required metadata is entered by the user.
 func (i sequenceItem) getMetaData() map[string]string {
 	metaData := make(map[string]string)
 	return metaData
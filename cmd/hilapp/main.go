@@ -14,6 +14,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/macformula/hil/canlink"
 	"github.com/macformula/hil/cli"
+	"github.com/macformula/hil/control"
+	"github.com/macformula/hil/coordinator"
 	"github.com/macformula/hil/flow"
 	"github.com/macformula/hil/iocontrol"
 	"github.com/macformula/hil/iocontrol/sil"
@@ -22,10 +24,13 @@ import (
 	"github.com/macformula/hil/macformula/ecu/frontcontroller"
 	"github.com/macformula/hil/macformula/ecu/lvcontroller"
 	"github.com/macformula/hil/macformula/pinout"
+	"github.com/macformula/hil/macformula/preflight"
 	"github.com/macformula/hil/macformula/state"
 	"github.com/macformula/hil/orchestrator"
 	"github.com/macformula/hil/results"
+	"github.com/macformula/hil/scheduler"
 	"github.com/macformula/hil/utils"
+	"github.com/macformula/hil/web"
 	"github.com/pkg/errors"
 )
 
@@ -105,7 +110,14 @@ func main() {
 
 	// Create result processor.
 	resultProcessor := results.NewResultAccumulator(logger, cfg.TagsFilePath,
-		results.NewHtmlReportGenerator())
+		results.NewHtmlReportGenerator(
+			results.WithTrends(results.NewHistory(cfg.ResultsDir), results.NewTrendAnalyzer())),
+		results.NewJunitReportGenerator(),
+		results.NewJsonExportGenerator(),
+		results.NewCsvExportGenerator())
+
+	// Each test gets its own bundle of reports and artifacts in the results dir.
+	resultProcessor.SetBundlesDir(cfg.ResultsDir)
 
 	// Create sequencer.
 	sequencer := flow.NewSequencer(resultProcessor, logger)
@@ -203,6 +215,7 @@ func main() {
 			PtBusManager:          ptBusManager,
 			VehCanTracer:          vehCanTracer,
 			PtCanTracer:           ptCanTracer,
+			IoController:          ioController,
 			PinoutController:      pinoutController,
 			PinModel:              silController.Pins,
 			TestBench:             testBench,
@@ -214,6 +227,7 @@ func main() {
 	} else {
 		app = macformula.App{
 			Config:                cfg,
+			IoController:          ioController,
 			PinoutController:      pinoutController,
 			PinModel:              silController.Pins,
 			TestBench:             testBench,
@@ -224,14 +238,83 @@ func main() {
 		}
 	}
 
-	// Create sequences.
+	// Create sequences. Sequence files take the place of the built-in sequences when configured.
 	sequences := state.GetSequences(&app, logger)
 
+	if cfg.SequencesDir != "" {
+		sequences, err = state.LoadSequences(cfg.SequencesDir, &app, logger)
+		if err != nil {
+			panic(errors.Wrap(err, "load sequences"))
+		}
+	}
+
+	// Check every sequence against the tag database and pinout before any test can be queued.
+	problems, err := preflight.Check(cfg, sequences, logger)
+	if err != nil {
+		panic(errors.Wrap(err, "pre-flight check"))
+	}
+
+	for _, problem := range problems {
+		logger.Error("pre-flight problem", zap.String("problem", problem.String()))
+	}
+
+	err = preflight.Err(problems)
+	if err != nil {
+		panic(err)
+	}
+
 	// Create command line dispatcher.
 	cliDispatcher := cli.NewCliDispatcher(sequences, logger)
+	dispatchers := []orchestrator.DispatcherIface{cliDispatcher}
+
+	// Create http dispatcher so tests can be started and watched remotely alongside the cli.
+	var httpDispatcher *web.HttpDispatcher
+
+	if cfg.HttpDispatcherAddr != "" {
+		httpDispatcher = web.NewHttpDispatcher(cfg.HttpDispatcherAddr, sequences, logger)
+		dispatchers = append(dispatchers, httpDispatcher)
+	}
+
+	// Create grpc dispatcher so CI can drive the bench through hilctl.
+	if cfg.GrpcDispatcherAddr != "" {
+		dispatchers = append(dispatchers, control.NewGrpcDispatcher(cfg.GrpcDispatcherAddr, sequences, logger))
+	}
+
+	// Create scheduler dispatcher so sequences run on their cron schedules without a person or CI.
+	if len(cfg.Schedules) > 0 {
+		entries, err := scheduleEntries(cfg)
+		if err != nil {
+			panic(errors.Wrap(err, "schedule entries"))
+		}
+
+		schedulerDispatcher := scheduler.NewSchedulerDispatcher(entries, sequences, logger)
+		dispatchers = append(dispatchers, schedulerDispatcher)
+
+		if httpDispatcher != nil {
+			httpDispatcher.SetScheduleLister(schedulerDispatcher)
+		}
+	}
 
 	// Create orchestrator.
-	orch := orchestrator.NewOrchestrator(sequencer, logger, cliDispatcher)
+	orch := orchestrator.NewOrchestrator(sequencer, logger, dispatchers...)
+
+	// Check the bench before every test so a broken bench is not reported as a failed test.
+	checks, err := healthChecks(cfg, &app)
+	if err != nil {
+		panic(errors.Wrap(err, "health checks"))
+	}
+
+	orch.AddHealthChecks(checks...)
+
+	// Persist the test queue and run history so they survive a restart.
+	if cfg.RunHistoryPath != "" {
+		orch.SetStore(orchestrator.NewJsonStore(cfg.RunHistoryPath, logger,
+			orchestrator.WithMaxRuns(cfg.RunHistoryMaxRuns)), sequences)
+
+		if httpDispatcher != nil {
+			httpDispatcher.SetRunLister(orch)
+		}
+	}
 
 	// Shutdown gracefully.
 	defer shutdownHandler(orch, logger)
@@ -244,6 +327,23 @@ func main() {
 		return
 	}
 
+	// Register with the multi-bench coordinator, it starts tests through the grpc dispatcher.
+	if cfg.Coordinator.Addr != "" && cfg.GrpcDispatcherAddr != "" {
+		agent := coordinator.NewAgent(cfg.Coordinator.Addr, cfg.Coordinator.BenchName, cfg.Coordinator.ControlAddr,
+			cfg.Revision, sequences, logger)
+
+		err = agent.Open(ctx)
+		if err != nil {
+			logger.Error("failed to open coordinator agent",
+				zap.Error(errors.Wrap(err, "agent open")))
+			return
+		}
+
+		defer agent.Close()
+	} else if cfg.Coordinator.Addr != "" {
+		logger.Warn("not registering with the coordinator, grpcDispatcherAddr is empty")
+	}
+
 	err = orch.Run(ctx)
 	if err != nil {
 		logger.Error("orchestrator run error",
@@ -254,6 +354,77 @@ func main() {
 	log.Info("hil app shutting down")
 }
 
+// scheduleEntries converts the schedules in the config file. Priority defaults to normal.
+func scheduleEntries(cfg *config.Config) ([]scheduler.Entry, error) {
+	entries := make([]scheduler.Entry, 0, len(cfg.Schedules))
+
+	for _, schedule := range cfg.Schedules {
+		priority := orchestrator.PriorityNormal
+
+		if schedule.Priority != "" {
+			var err error
+
+			priority, err = orchestrator.PriorityString(schedule.Priority)
+			if err != nil {
+				return nil, errors.Errorf("invalid priority (%s) for scheduled sequence (%s) valid options (%v)",
+					schedule.Priority, schedule.Sequence, orchestrator.PriorityStrings())
+			}
+		}
+
+		entries = append(entries, scheduler.Entry{
+			Cron:         schedule.Cron,
+			SequenceName: schedule.Sequence,
+			Metadata:     schedule.Metadata,
+			Priority:     priority,
+		})
+	}
+
+	return entries, nil
+}
+
+// healthChecks creates the health checks enabled in the config file.
+func healthChecks(cfg *config.Config, app *macformula.App) ([]orchestrator.HealthCheckIface, error) {
+	checks := make([]orchestrator.HealthCheckIface, 0)
+	healthCfg := cfg.HealthChecks
+
+	if healthCfg.ConnectivityPin != "" {
+		pin, err := pinout.PhysicalIoString(healthCfg.ConnectivityPin)
+		if err != nil {
+			return nil, errors.Wrap(err, "connectivity pin")
+		}
+
+		checks = append(checks, macformula.NewConnectivityCheck(app, pin))
+	}
+
+	if healthCfg.LoopbackOutput != "" || healthCfg.LoopbackInput != "" {
+		output, err := pinout.PhysicalIoString(healthCfg.LoopbackOutput)
+		if err != nil {
+			return nil, errors.Wrap(err, "loopback output")
+		}
+
+		input, err := pinout.PhysicalIoString(healthCfg.LoopbackInput)
+		if err != nil {
+			return nil, errors.Wrap(err, "loopback input")
+		}
+
+		checks = append(checks, macformula.NewLoopbackCheck(app.PinoutController, output, input))
+	}
+
+	if healthCfg.CanTrafficTimeoutMs > 0 && app.WithVcan {
+		timeout := time.Duration(healthCfg.CanTrafficTimeoutMs) * time.Millisecond
+
+		checks = append(checks,
+			macformula.NewCanTrafficCheck(cfg.CanInterfaces.Veh, timeout),
+			macformula.NewCanTrafficCheck(cfg.CanInterfaces.Pt, timeout))
+	}
+
+	if healthCfg.MinFreeDiskMb > 0 {
+		checks = append(checks, macformula.NewDiskSpaceCheck(cfg.ResultsDir, healthCfg.MinFreeDiskMb))
+	}
+
+	return checks, nil
+}
+
 func shutdownHandler(orchestrator *orchestrator.Orchestrator, logger *zap.Logger) {
 	panicMsg := recover()
 
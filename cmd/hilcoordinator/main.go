@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/coordinator"
+	"github.com/pkg/errors"
+)
+
+const (
+	_logFileName = "hilcoordinator.log"
+)
+
+var (
+	addr = flag.String("addr", ":9000", "Address the coordinator listens on")
+)
+
+func main() {
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg := zap.NewDevelopmentConfig()
+	cfg.OutputPaths = []string{_logFileName, "stdout"}
+	logger, err := cfg.Build()
+	if err != nil {
+		panic(errors.Wrap(err, "zap config build"))
+	}
+	defer logger.Sync()
+
+	coord := coordinator.NewCoordinator(*addr, logger)
+
+	err = coord.Open(ctx)
+	if err != nil {
+		logger.Error("failed to open coordinator", zap.Error(errors.Wrap(err, "coordinator open")))
+
+		return
+	}
+
+	<-ctx.Done()
+
+	err = coord.Close()
+	if err != nil {
+		logger.Error("failed to close coordinator", zap.Error(err))
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/control"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+)
+
+const (
+	_defaultAddr = "localhost:8001"
+	// Exit codes of hilctl run, so pipelines can tell a failed test from a broken bench.
+	_exitPassed = 0
+	_exitFailed = 1
+	_exitError  = 2
+
+	_measurementTimeFormat = "15:04:05.000"
+)
+
+const _usage = `Usage: hilctl [flags] <command> [args]
+
+Commands:
+  list                  List the available sequences
+  run <sequence>        Run a sequence and wait for its result, exits 0 on pass, 1 on fail and 2 on error
+  cancel <test id>      Cancel a queued or running test
+  pause                 Pause the running test before its next state
+  resume                Resume a paused test
+  step                  Run the next state of a paused test, then pause again
+  recover               Recover the bench from a fatal error and wait for its recovery procedure
+  shutdown              Shut down hilapp
+  benches               List the benches registered with a coordinator
+  measurements          Print the live measurements of the running test until interrupted
+
+Flags:
+`
+
+// metadataFlag collects repeated -meta key=value flags.
+type metadataFlag map[string]string
+
+func (m metadataFlag) String() string {
+	return fmt.Sprint(map[string]string(m))
+}
+
+func (m metadataFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok {
+		return errors.Errorf("metadata must be key=value (%s)", value)
+	}
+
+	m[key] = val
+
+	return nil
+}
+
+// breakpointsFlag collects repeated -break state flags.
+type breakpointsFlag []string
+
+func (b *breakpointsFlag) String() string {
+	return strings.Join(*b, ",")
+}
+
+func (b *breakpointsFlag) Set(value string) error {
+	*b = append(*b, value)
+
+	return nil
+}
+
+var (
+	addr        = flag.String("addr", _defaultAddr, "Address of the hilapp grpc dispatcher")
+	priorityStr = flag.String("priority", orchestrator.PriorityNormal.String(), "Priority of the test (Low, Normal, High)")
+	verbose     = flag.Bool("v", false, "Print status updates while waiting for results")
+	revision    = flag.String("revision", "", "Only run the test on benches with this revision (coordinator only)")
+	metadata    = metadataFlag{}
+	breakpoints = breakpointsFlag{}
+)
+
+func main() {
+	flag.Var(metadata, "meta", "Test metadata as key=value, can be repeated")
+	flag.Var(&breakpoints, "break", "Pause the test before the state with this name, can be repeated")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), _usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(_exitError)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	logger := zap.NewNop()
+
+	client := control.NewClient(*addr, logger)
+
+	err := client.Open(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to %s: %v\n", *addr, err)
+		os.Exit(_exitError)
+	}
+
+	exitCode, err := runCommand(ctx, client, flag.Arg(0), flag.Args()[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", flag.Arg(0), err)
+	}
+
+	// os.Exit skips deferred calls.
+	_ = client.Close()
+	stop()
+
+	os.Exit(exitCode)
+}
+
+func runCommand(ctx context.Context, client *control.Client, cmd string, args []string) (int, error) {
+	switch cmd {
+	case "list":
+		sequences, err := client.ListSequences(ctx)
+		if err != nil {
+			return _exitError, err
+		}
+
+		for _, seq := range sequences {
+			fmt.Printf("%s\t%s\n", seq.Name, seq.Desc)
+		}
+	case "run":
+		if len(args) != 1 {
+			return _exitError, errors.New("expected a sequence name")
+		}
+
+		return runTest(ctx, client, args[0])
+	case "cancel":
+		if len(args) != 1 {
+			return _exitError, errors.New("expected a test id")
+		}
+
+		testId, err := uuid.Parse(args[0])
+		if err != nil {
+			return _exitError, errors.Wrap(err, "parse test id")
+		}
+
+		err = client.CancelTest(ctx, testId)
+		if err != nil {
+			return _exitError, err
+		}
+	case "pause":
+		err := client.Pause(ctx)
+		if err != nil {
+			return _exitError, err
+		}
+	case "resume":
+		err := client.Resume(ctx)
+		if err != nil {
+			return _exitError, err
+		}
+	case "step":
+		err := client.Step(ctx)
+		if err != nil {
+			return _exitError, err
+		}
+	case "recover":
+		var printed bool
+
+		err := client.RunRecovery(ctx, func(status *pb.Status) {
+			if printed {
+				return
+			}
+
+			printed = true
+
+			fmt.Printf("fatal error: %s\n", status.FatalError)
+
+			for i, item := range status.RecoveryChecklist {
+				fmt.Printf("  %d. %s\n", i+1, item)
+			}
+
+			if len(status.RecoveryActions) > 0 {
+				fmt.Printf("running %s\n", strings.Join(status.RecoveryActions, ", "))
+			}
+		})
+		if err != nil {
+			return _exitError, err
+		}
+
+		fmt.Println("recovered")
+	case "shutdown":
+		err := client.Shutdown(ctx)
+		if err != nil {
+			return _exitError, err
+		}
+	case "benches":
+		benches, err := client.ListBenches(ctx)
+		if err != nil {
+			return _exitError, err
+		}
+
+		for _, b := range benches {
+			online := "offline"
+			if b.Online {
+				online = "online"
+			}
+
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n",
+				b.Bench.Name, b.Bench.Revision, online, b.Status.GetOrchestratorState(), b.AssignedTestId)
+		}
+	case "measurements":
+		err := client.WatchMeasurements(ctx, func(measurement *pb.Measurement) bool {
+			fmt.Printf("%s\t%s\t%s\t%s\n", measurement.Time.AsTime().Format(_measurementTimeFormat),
+				measurement.StateName, measurement.Name, formatMeasurement(measurement))
+
+			return true
+		})
+		if err != nil && ctx.Err() == nil {
+			return _exitError, err
+		}
+	default:
+		return _exitError, errors.Errorf("unknown command, run hilctl -h for usage")
+	}
+
+	return _exitPassed, nil
+}
+
+func runTest(ctx context.Context, client *control.Client, sequenceName string) (int, error) {
+	priority, err := orchestrator.PriorityString(*priorityStr)
+	if err != nil {
+		return _exitError, errors.Errorf("invalid priority (%s) valid options (%v)", *priorityStr, orchestrator.PriorityStrings())
+	}
+
+	var lastState string
+
+	onStatus := func(status *pb.Status) {
+		if status.Progress == nil {
+			return
+		}
+
+		var state string
+
+		switch {
+		// Always tell the user the test is waiting for them, even without -v.
+		case status.Progress.Paused:
+			state = "paused before " + status.Progress.CurrentState
+		case *verbose:
+			state = "running " + status.Progress.CurrentPath
+		default:
+			return
+		}
+
+		if state == lastState {
+			return
+		}
+
+		lastState = state
+		fmt.Println(state)
+	}
+
+	results, err := client.RunTest(ctx, sequenceName, onStatus,
+		control.WithMetadata(metadata),
+		control.WithPriority(priority),
+		control.WithRevision(*revision),
+		control.WithBreakpoints(breakpoints...))
+	if err != nil {
+		return _exitError, err
+	}
+
+	fmt.Printf("Test ID: %s\n", results.TestId)
+
+	for _, tag := range results.FailedTags {
+		fmt.Printf("failed tag %s: %s\n", tag.TagId, tag.Description)
+	}
+
+	for _, testErr := range results.TestErrors {
+		fmt.Printf("error: %s\n", testErr)
+	}
+
+	for _, teardownErr := range results.TeardownErrors {
+		fmt.Printf("teardown error: %s\n", teardownErr)
+	}
+
+	for _, path := range results.ReportPaths {
+		fmt.Printf("report: %s\n", path)
+	}
+
+	if results.BenchError != "" {
+		fmt.Printf("BENCH ERROR: %s\n", results.BenchError)
+		return _exitError, nil
+	}
+
+	if !results.IsPassing {
+		fmt.Println("FAILED")
+		return _exitFailed, nil
+	}
+
+	fmt.Println("PASSED")
+
+	return _exitPassed, nil
+}
+
+func formatMeasurement(measurement *pb.Measurement) string {
+	if measurement.IsBool {
+		return fmt.Sprintf("%t", measurement.Value != 0)
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%g %s", measurement.Value, measurement.Unit))
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula"
+	"github.com/macformula/hil/macformula/config"
+	"github.com/macformula/hil/macformula/preflight"
+	"github.com/macformula/hil/macformula/state"
+)
+
+var (
+	configPath = flag.String("config", "", "Path to config file")
+	revision   = flag.String("revision", "", "Pinout revision to validate against, defaults to the config revision")
+)
+
+// hilpreflight checks every sequence against the tag database and pinout without touching the test bench.
+// It exits with a non-zero status if any problem is found.
+func main() {
+	flag.Parse()
+
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "Missing required flag: --config")
+		os.Exit(2)
+	}
+
+	cfg, err := config.NewConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "new config (%s): %v\n", *configPath, err)
+		os.Exit(2)
+	}
+
+	if *revision != "" {
+		cfg.Revision = *revision
+	}
+
+	logger := zap.NewNop()
+
+	// States are only built to read their declarations, they are never run.
+	app := &macformula.App{Config: cfg}
+
+	var sequences []flow.Sequence
+
+	if cfg.SequencesDir != "" {
+		sequences, err = state.LoadSequences(cfg.SequencesDir, app, logger)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "load sequences: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		sequences = state.GetSequences(app, logger)
+	}
+
+	problems, err := preflight.Check(cfg, sequences, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pre-flight check: %v\n", err)
+		os.Exit(2)
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("%d problem(s) found in %d sequence(s) for revision %s\n", len(problems), len(sequences), cfg.Revision)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%d sequence(s) ok for revision %s\n", len(sequences), cfg.Revision)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+
+	"github.com/macformula/hil/results"
+)
+
+var (
+	dir        = flag.String("dir", "", "Directory holding the JSON exports of previous runs, usually the results dir")
+	sequence   = flag.String("sequence", "", "Sequence to analyze, its latest run is compared against the runs before it")
+	testID     = flag.String("test-id", "", "Run to analyze instead of the latest run of the sequence")
+	window     = flag.Int("window", 20, "Number of previous runs used for the statistics")
+	threshold  = flag.Float64("threshold", 3, "Standard deviations from the mean before a value is flagged as drift")
+	minSamples = flag.Int("min-samples", 5, "Previous values a tag needs before drift is flagged")
+	flagged    = flag.Bool("flagged", false, "Only print the tags that drifted or newly failed")
+)
+
+// hiltrend compares the tags of a run against the previous runs of its sequence. It reads the exports written by
+// the JSON export generator and exits with a non-zero status if any tag drifted or newly failed.
+func main() {
+	flag.Parse()
+
+	if *dir == "" {
+		fmt.Fprintln(os.Stderr, "Missing required flag: --dir")
+		os.Exit(2)
+	}
+
+	if *sequence == "" && *testID == "" {
+		fmt.Fprintln(os.Stderr, "Missing required flag: --sequence or --test-id")
+		os.Exit(2)
+	}
+
+	history, err := results.NewHistory(*dir).Load(*sequence)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load history (%s): %v\n", *dir, err)
+		os.Exit(2)
+	}
+
+	current, err := currentRun(history)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Without --sequence every sequence was loaded, only the runs of the selected one are comparable.
+	history = sequenceRuns(history, current.SequenceName)
+
+	analyzer := results.NewTrendAnalyzer(
+		results.WithTrendWindow(*window),
+		results.WithDriftThreshold(*threshold),
+		results.WithMinSamples(*minSamples))
+
+	trends := analyzer.Analyze(history, current)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TAG\tSTATE\tPARAMS\tRUNS\tMEAN\tSTDDEV\tP50\tP95\tCURRENT\tZ\tCHANGE\tFLAGS")
+
+	count := 0
+
+	for _, trend := range trends {
+		if trend.Flagged() {
+			count++
+		}
+
+		if *flagged && !trend.Flagged() {
+			continue
+		}
+
+		if !trend.Numeric {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t\t\t\t\t\t\t\t%s\n",
+				trend.TagID, trend.StatePath, trend.Params, trend.Runs, flags(trend))
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%.4g\t%.4g\t%.4g\t%.4g\t%.4g %s\t%.2f\t%+.1f%%\t%s\n",
+			trend.TagID, trend.StatePath, trend.Params, trend.Runs, trend.Mean, trend.StdDev, trend.P50, trend.P95,
+			trend.Current, trend.Unit, trend.ZScore, trend.PercentChange, flags(trend))
+	}
+
+	w.Flush()
+
+	fmt.Printf("%d of %d tag(s) flagged in run %s of %s\n", count, len(trends), current.TestID, current.SequenceName)
+
+	if count > 0 {
+		os.Exit(1)
+	}
+}
+
+// currentRun returns the run selected by --test-id, or the latest run in history.
+func currentRun(history []results.Export) (results.Export, error) {
+	if *testID == "" {
+		if len(history) == 0 {
+			return results.Export{}, fmt.Errorf("no runs of sequence (%s) in (%s)", *sequence, *dir)
+		}
+
+		return history[len(history)-1], nil
+	}
+
+	id, err := uuid.Parse(*testID)
+	if err != nil {
+		return results.Export{}, fmt.Errorf("parse test id (%s): %v", *testID, err)
+	}
+
+	for _, export := range history {
+		if export.TestID == id {
+			return export, nil
+		}
+	}
+
+	return results.Export{}, fmt.Errorf("no run with test id (%s) in (%s)", *testID, *dir)
+}
+
+func sequenceRuns(history []results.Export, sequenceName string) []results.Export {
+	ret := make([]results.Export, 0, len(history))
+
+	for _, export := range history {
+		if export.SequenceName == sequenceName {
+			ret = append(ret, export)
+		}
+	}
+
+	return ret
+}
+
+func flags(trend results.TagTrend) string {
+	var ret []string
+
+	if trend.NewFailure {
+		ret = append(ret, "NEW FAILURE")
+	}
+
+	if trend.Drift {
+		ret = append(ret, "DRIFT")
+	}
+
+	return strings.Join(ret, ",")
+}
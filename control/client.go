@@ -0,0 +1,365 @@
+package control
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	_clientLoggerName = "control_client"
+)
+
+// Client is a client of the Control service served by a GrpcDispatcher.
+type Client struct {
+	l      *zap.Logger
+	addr   string
+	conn   *grpc.ClientConn
+	client pb.ControlClient
+}
+
+// StartOption configures a test started through a Client.
+type StartOption = func(*pb.StartTestRequest)
+
+// WithMetadata attaches metadata to the test.
+func WithMetadata(metadata map[string]string) StartOption {
+	return func(r *pb.StartTestRequest) {
+		r.Metadata = metadata
+	}
+}
+
+// WithPriority queues the test with the given priority.
+func WithPriority(priority orchestrator.Priority) StartOption {
+	return func(r *pb.StartTestRequest) {
+		r.Priority = _priorities[priority]
+	}
+}
+
+// WithScheduledAt holds the test in the queue until the given time.
+func WithScheduledAt(scheduledAt time.Time) StartOption {
+	return func(r *pb.StartTestRequest) {
+		r.ScheduledAt = timestamppb.New(scheduledAt)
+	}
+}
+
+// WithTestId starts the test with the given id instead of a new one.
+func WithTestId(testId orchestrator.TestId) StartOption {
+	return func(r *pb.StartTestRequest) {
+		r.TestId = testId.String()
+	}
+}
+
+// WithRevision only runs the test on a bench with the given pinout revision. It is only used by a Coordinator.
+func WithRevision(revision string) StartOption {
+	return func(r *pb.StartTestRequest) {
+		r.Revision = revision
+	}
+}
+
+// WithBreakpoints pauses the test before the states with the given names.
+func WithBreakpoints(stateNames ...string) StartOption {
+	return func(r *pb.StartTestRequest) {
+		r.Breakpoints = stateNames
+	}
+}
+
+// NewClient creates a client for the GrpcDispatcher at address.
+func NewClient(address string, l *zap.Logger) *Client {
+	return &Client{
+		l:    l.Named(_clientLoggerName),
+		addr: address,
+	}
+}
+
+// Open connects to the GrpcDispatcher.
+func (c *Client) Open(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, c.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return errors.Wrap(err, "dial context")
+	}
+
+	c.conn = conn
+	c.client = pb.NewControlClient(conn)
+
+	return nil
+}
+
+// Close closes the connection to the GrpcDispatcher.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	if err != nil {
+		return errors.Wrap(err, "close conn")
+	}
+
+	return nil
+}
+
+// ListSequences returns the sequences that can be started.
+func (c *Client) ListSequences(ctx context.Context) ([]*pb.Sequence, error) {
+	reply, err := c.client.ListSequences(ctx, &pb.ListSequencesRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list sequences")
+	}
+
+	return reply.Sequences, nil
+}
+
+// StartTest queues the sequence and returns the TestId of the queued test.
+func (c *Client) StartTest(ctx context.Context, sequenceName string, opts ...StartOption) (orchestrator.TestId, error) {
+	request := &pb.StartTestRequest{SequenceName: sequenceName}
+
+	for _, o := range opts {
+		o(request)
+	}
+
+	reply, err := c.client.StartTest(ctx, request)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "start test")
+	}
+
+	testId, err := uuid.Parse(reply.TestId)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "parse test id")
+	}
+
+	return testId, nil
+}
+
+// CancelTest cancels a queued or running test.
+func (c *Client) CancelTest(ctx context.Context, testId orchestrator.TestId) error {
+	_, err := c.client.CancelTest(ctx, &pb.CancelTestRequest{TestId: testId.String()})
+	if err != nil {
+		return errors.Wrap(err, "cancel test")
+	}
+
+	return nil
+}
+
+// RecoverFromFatal tells the orchestrator the fatal error has been fixed.
+func (c *Client) RecoverFromFatal(ctx context.Context) error {
+	_, err := c.client.RecoverFromFatal(ctx, &pb.RecoverFromFatalRequest{})
+	if err != nil {
+		return errors.Wrap(err, "recover from fatal")
+	}
+
+	return nil
+}
+
+// Pause halts the running test before its next state.
+func (c *Client) Pause(ctx context.Context) error {
+	_, err := c.client.Pause(ctx, &pb.PauseRequest{})
+	if err != nil {
+		return errors.Wrap(err, "pause")
+	}
+
+	return nil
+}
+
+// Resume continues a paused test.
+func (c *Client) Resume(ctx context.Context) error {
+	_, err := c.client.Resume(ctx, &pb.ResumeRequest{})
+	if err != nil {
+		return errors.Wrap(err, "resume")
+	}
+
+	return nil
+}
+
+// Step runs the next state of a paused test, then pauses it again.
+func (c *Client) Step(ctx context.Context) error {
+	_, err := c.client.Step(ctx, &pb.StepRequest{})
+	if err != nil {
+		return errors.Wrap(err, "step")
+	}
+
+	return nil
+}
+
+// Shutdown shuts down the hil app.
+func (c *Client) Shutdown(ctx context.Context) error {
+	_, err := c.client.Shutdown(ctx, &pb.ShutdownRequest{})
+	if err != nil {
+		return errors.Wrap(err, "shutdown")
+	}
+
+	return nil
+}
+
+// ListBenches returns the benches registered with a Coordinator. It fails if the client is connected to a bench.
+func (c *Client) ListBenches(ctx context.Context) ([]*pb.BenchStatus, error) {
+	reply, err := pb.NewCoordinatorClient(c.conn).ListBenches(ctx, &pb.ListBenchesRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list benches")
+	}
+
+	return reply.Benches, nil
+}
+
+// WatchStatus calls onUpdate with every status and results update until onUpdate returns false or ctx is done.
+// The first update is always the current status. The watch ends with an error if onUpdate falls too far behind.
+func (c *Client) WatchStatus(ctx context.Context, onUpdate func(update *pb.WatchStatusResponse) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.WatchStatus(ctx, &pb.WatchStatusRequest{})
+	if err != nil {
+		return errors.Wrap(err, "watch status")
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "receive update")
+		}
+
+		if !onUpdate(update) {
+			return nil
+		}
+	}
+}
+
+// WatchMeasurements calls onMeasurement with every live sample published by the running test until onMeasurement
+// returns false or ctx is done. Samples are dropped if onMeasurement falls behind.
+func (c *Client) WatchMeasurements(ctx context.Context, onMeasurement func(measurement *pb.Measurement) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.WatchStatus(ctx, &pb.WatchStatusRequest{Measurements: true})
+	if err != nil {
+		return errors.Wrap(err, "watch status")
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "receive update")
+		}
+
+		measurement := update.GetMeasurement()
+		if measurement == nil {
+			continue
+		}
+
+		if !onMeasurement(measurement) {
+			return nil
+		}
+	}
+}
+
+// RunRecovery recovers the bench from a fatal error and blocks until its recovery procedure is done. onStatus is
+// called with every status update while waiting, starting with the fatal error, it can be nil.
+func (c *Client) RunRecovery(ctx context.Context, onStatus func(status *pb.Status)) error {
+	var (
+		sent       bool
+		recovering bool
+		err        error
+	)
+
+	// Recover after the first update so the outcome cannot be sent before we are watching.
+	watchErr := c.WatchStatus(ctx, func(update *pb.WatchStatusResponse) bool {
+		status := update.GetStatus()
+		if status == nil {
+			return true
+		}
+
+		if onStatus != nil {
+			onStatus(status)
+		}
+
+		if !sent {
+			sent = true
+
+			err = c.RecoverFromFatal(ctx)
+
+			return err == nil
+		}
+
+		switch status.OrchestratorState {
+		case pb.OrchestratorState_ORCHESTRATOR_STATE_RECOVERING:
+			recovering = true
+		case pb.OrchestratorState_ORCHESTRATOR_STATE_FATAL_ERROR:
+			// Fatal error updates from before the recovery procedure started are ignored.
+			if recovering {
+				err = errors.Errorf("recovery failed: %s", status.RecoveryError)
+				return false
+			}
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if watchErr != nil {
+		return errors.Wrap(watchErr, "wait for recovery")
+	}
+
+	return nil
+}
+
+// RunTest starts the sequence and blocks until its results are received. onStatus is called with every status
+// update while waiting, it can be nil.
+func (c *Client) RunTest(
+	ctx context.Context,
+	sequenceName string,
+	onStatus func(status *pb.Status),
+	opts ...StartOption,
+) (*pb.Results, error) {
+	var (
+		testId  orchestrator.TestId
+		results *pb.Results
+		err     error
+	)
+
+	// Start the test after the first update so the results cannot be sent before we are watching.
+	watchErr := c.WatchStatus(ctx, func(update *pb.WatchStatusResponse) bool {
+		if testId == uuid.Nil {
+			testId, err = c.StartTest(ctx, sequenceName, opts...)
+			if err != nil {
+				return false
+			}
+
+			c.l.Info("test started", zap.String("test id", testId.String()))
+		}
+
+		switch signal := update.Signal.(type) {
+		case *pb.WatchStatusResponse_Status:
+			if onStatus != nil {
+				onStatus(signal.Status)
+			}
+		case *pb.WatchStatusResponse_Results:
+			if signal.Results.TestId == testId.String() {
+				results = signal.Results
+				return false
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if watchErr != nil {
+		return nil, errors.Wrap(watchErr, "wait for results")
+	}
+
+	return results, nil
+}
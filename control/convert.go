@@ -0,0 +1,186 @@
+package control
+
+import (
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/orchestrator"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _orchestratorStates = map[orchestrator.State]pb.OrchestratorState{
+	orchestrator.Unknown:    pb.OrchestratorState_ORCHESTRATOR_STATE_UNKNOWN,
+	orchestrator.Idle:       pb.OrchestratorState_ORCHESTRATOR_STATE_IDLE,
+	orchestrator.Running:    pb.OrchestratorState_ORCHESTRATOR_STATE_RUNNING,
+	orchestrator.FatalError: pb.OrchestratorState_ORCHESTRATOR_STATE_FATAL_ERROR,
+	orchestrator.Paused:     pb.OrchestratorState_ORCHESTRATOR_STATE_PAUSED,
+	orchestrator.Recovering: pb.OrchestratorState_ORCHESTRATOR_STATE_RECOVERING,
+}
+
+var _priorities = map[orchestrator.Priority]pb.Priority{
+	orchestrator.PriorityNormal: pb.Priority_PRIORITY_NORMAL,
+	orchestrator.PriorityLow:    pb.Priority_PRIORITY_LOW,
+	orchestrator.PriorityHigh:   pb.Priority_PRIORITY_HIGH,
+}
+
+func toPbSequence(seq flow.Sequence) *pb.Sequence {
+	return &pb.Sequence{
+		Name:             seq.Name,
+		Desc:             seq.Desc,
+		States:           stateNames(seq.States),
+		Teardown:         stateNames(seq.Teardown),
+		RequiredMetadata: seq.RequiredMetadata,
+	}
+}
+
+func toPbStatus(status orchestrator.StatusSignal) *pb.Status {
+	ret := &pb.Status{
+		OrchestratorState: _orchestratorStates[status.OrchestratorState],
+		TestId:            status.TestId.String(),
+		Progress:          toPbProgress(status.Progress),
+		Queue:             make([]*pb.QueuedTest, len(status.Queue)),
+	}
+
+	for i, queued := range status.Queue {
+		ret.Queue[i] = &pb.QueuedTest{
+			TestId:       queued.TestId.String(),
+			SequenceName: queued.SequenceName,
+			Metadata:     queued.Metadata,
+			Priority:     _priorities[queued.Priority],
+		}
+
+		if !queued.ScheduledAt.IsZero() {
+			ret.Queue[i].ScheduledAt = timestamppb.New(queued.ScheduledAt)
+		}
+	}
+
+	if status.FatalError != nil {
+		ret.FatalError = status.FatalError.Error()
+	}
+
+	if procedure, ok := flow.Recovery(status.FatalError); ok {
+		ret.RecoveryChecklist = procedure.Checklist
+		ret.RecoveryActions = procedure.ActionNames()
+	}
+
+	if status.RecoveryError != nil {
+		ret.RecoveryError = status.RecoveryError.Error()
+	}
+
+	return ret
+}
+
+func toPbProgress(progress flow.Progress) *pb.Progress {
+	ret := &pb.Progress{
+		SequenceName:  progress.Sequence.Name,
+		StateIndex:    int32(progress.StateIndex),
+		Iteration:     int32(progress.Iteration),
+		Iterations:    int32(progress.Iterations),
+		Params:        progress.Params.String(),
+		StatePassed:   progress.StatePassed,
+		StateDuration: make([]*durationpb.Duration, len(progress.StateDuration)),
+		Children:      toPbChildProgress(progress.Children),
+		Paused:        progress.Paused,
+		CurrentPath:   progress.CurrentPath(),
+	}
+
+	if progress.CurrentState != nil {
+		ret.CurrentState = progress.CurrentState.Name()
+	}
+
+	for i, duration := range progress.StateDuration {
+		ret.StateDuration[i] = durationpb.New(duration)
+	}
+
+	return ret
+}
+
+func toPbChildProgress(children []flow.ChildProgress) []*pb.ChildProgress {
+	ret := make([]*pb.ChildProgress, len(children))
+
+	for i, child := range children {
+		ret[i] = &pb.ChildProgress{
+			Name:     child.Name,
+			Running:  child.Running,
+			Passed:   child.Passed,
+			Duration: durationpb.New(child.Duration),
+			Children: toPbChildProgress(child.Children),
+		}
+	}
+
+	return ret
+}
+
+func toPbResults(results orchestrator.ResultsSignal) *pb.Results {
+	ret := &pb.Results{
+		TestId:         results.TestId.String(),
+		IsPassing:      results.IsPassing,
+		FailedTags:     make([]*pb.Tag, len(results.FailedTags)),
+		TestErrors:     errorStrings(results.TestErrors),
+		TeardownErrors: errorStrings(results.TeardownErrors),
+		ReportPaths:    results.ReportPaths,
+	}
+
+	if results.FatalError != nil {
+		ret.FatalError = results.FatalError.Error()
+	}
+
+	if results.BenchError != nil {
+		ret.BenchError = results.BenchError.Error()
+	}
+
+	for i, tag := range results.FailedTags {
+		ret.FailedTags[i] = &pb.Tag{
+			TagId:       tag.ID,
+			Description: tag.Description,
+		}
+	}
+
+	return ret
+}
+
+func toPbMeasurement(measurement orchestrator.MeasurementSignal) *pb.Measurement {
+	sample := measurement.Sample
+
+	return &pb.Measurement{
+		TestId:    measurement.TestId.String(),
+		Name:      sample.Name,
+		Value:     sample.Value,
+		Unit:      sample.Unit,
+		IsBool:    sample.IsBool,
+		StateName: sample.StateName,
+		Time:      timestamppb.New(sample.Time),
+	}
+}
+
+// PriorityFromPb converts a Priority received over the wire. Unknown priorities are PriorityNormal.
+func PriorityFromPb(priority pb.Priority) orchestrator.Priority {
+	for ret, p := range _priorities {
+		if p == priority {
+			return ret
+		}
+	}
+
+	return orchestrator.PriorityNormal
+}
+
+func stateNames(states []flow.State) []string {
+	ret := make([]string, len(states))
+	for i, state := range states {
+		ret[i] = state.Name()
+	}
+
+	return ret
+}
+
+func errorStrings(errs []error) []string {
+	ret := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		if err != nil {
+			ret = append(ret, err.Error())
+		}
+	}
+
+	return ret
+}
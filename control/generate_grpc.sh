@@ -0,0 +1 @@
+protoc -I ./proto --go_out=./generated --go_opt=paths=source_relative --go-grpc_out=./generated --go-grpc_opt=paths=source_relative ./proto/control.proto
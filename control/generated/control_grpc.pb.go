@@ -0,0 +1,570 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: control.proto
+
+package generated
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Control_ListSequences_FullMethodName    = "/HilControl.Control/ListSequences"
+	Control_StartTest_FullMethodName        = "/HilControl.Control/StartTest"
+	Control_CancelTest_FullMethodName       = "/HilControl.Control/CancelTest"
+	Control_RecoverFromFatal_FullMethodName = "/HilControl.Control/RecoverFromFatal"
+	Control_Shutdown_FullMethodName         = "/HilControl.Control/Shutdown"
+	Control_Pause_FullMethodName            = "/HilControl.Control/Pause"
+	Control_Resume_FullMethodName           = "/HilControl.Control/Resume"
+	Control_Step_FullMethodName             = "/HilControl.Control/Step"
+	Control_WatchStatus_FullMethodName      = "/HilControl.Control/WatchStatus"
+)
+
+// ControlClient is the client API for Control service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ControlClient interface {
+	ListSequences(ctx context.Context, in *ListSequencesRequest, opts ...grpc.CallOption) (*ListSequencesResponse, error)
+	StartTest(ctx context.Context, in *StartTestRequest, opts ...grpc.CallOption) (*StartTestResponse, error)
+	CancelTest(ctx context.Context, in *CancelTestRequest, opts ...grpc.CallOption) (*CancelTestResponse, error)
+	RecoverFromFatal(ctx context.Context, in *RecoverFromFatalRequest, opts ...grpc.CallOption) (*RecoverFromFatalResponse, error)
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// Pause halts the running test before its next state.
+	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+	// Step runs the next state of a paused test, then pauses it again.
+	Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*StepResponse, error)
+	// WatchStatus sends the current status right away, then every status and results update until the call is canceled.
+	// Live measurements are also sent if requested.
+	WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (Control_WatchStatusClient, error)
+}
+
+type controlClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewControlClient(cc grpc.ClientConnInterface) ControlClient {
+	return &controlClient{cc}
+}
+
+func (c *controlClient) ListSequences(ctx context.Context, in *ListSequencesRequest, opts ...grpc.CallOption) (*ListSequencesResponse, error) {
+	out := new(ListSequencesResponse)
+	err := c.cc.Invoke(ctx, Control_ListSequences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StartTest(ctx context.Context, in *StartTestRequest, opts ...grpc.CallOption) (*StartTestResponse, error) {
+	out := new(StartTestResponse)
+	err := c.cc.Invoke(ctx, Control_StartTest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) CancelTest(ctx context.Context, in *CancelTestRequest, opts ...grpc.CallOption) (*CancelTestResponse, error) {
+	out := new(CancelTestResponse)
+	err := c.cc.Invoke(ctx, Control_CancelTest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) RecoverFromFatal(ctx context.Context, in *RecoverFromFatalRequest, opts ...grpc.CallOption) (*RecoverFromFatalResponse, error) {
+	out := new(RecoverFromFatalResponse)
+	err := c.cc.Invoke(ctx, Control_RecoverFromFatal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, Control_Shutdown_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error) {
+	out := new(PauseResponse)
+	err := c.cc.Invoke(ctx, Control_Pause_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error) {
+	out := new(ResumeResponse)
+	err := c.cc.Invoke(ctx, Control_Resume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*StepResponse, error) {
+	out := new(StepResponse)
+	err := c.cc.Invoke(ctx, Control_Step_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (Control_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Control_ServiceDesc.Streams[0], Control_WatchStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_WatchStatusClient interface {
+	Recv() (*WatchStatusResponse, error)
+	grpc.ClientStream
+}
+
+type controlWatchStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlWatchStatusClient) Recv() (*WatchStatusResponse, error) {
+	m := new(WatchStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ControlServer is the server API for Control service.
+// All implementations must embed UnimplementedControlServer
+// for forward compatibility
+type ControlServer interface {
+	ListSequences(context.Context, *ListSequencesRequest) (*ListSequencesResponse, error)
+	StartTest(context.Context, *StartTestRequest) (*StartTestResponse, error)
+	CancelTest(context.Context, *CancelTestRequest) (*CancelTestResponse, error)
+	RecoverFromFatal(context.Context, *RecoverFromFatalRequest) (*RecoverFromFatalResponse, error)
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	// Pause halts the running test before its next state.
+	Pause(context.Context, *PauseRequest) (*PauseResponse, error)
+	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
+	// Step runs the next state of a paused test, then pauses it again.
+	Step(context.Context, *StepRequest) (*StepResponse, error)
+	// WatchStatus sends the current status right away, then every status and results update until the call is canceled.
+	// Live measurements are also sent if requested.
+	WatchStatus(*WatchStatusRequest, Control_WatchStatusServer) error
+	mustEmbedUnimplementedControlServer()
+}
+
+// UnimplementedControlServer must be embedded to have forward compatible implementations.
+type UnimplementedControlServer struct {
+}
+
+func (UnimplementedControlServer) ListSequences(context.Context, *ListSequencesRequest) (*ListSequencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSequences not implemented")
+}
+func (UnimplementedControlServer) StartTest(context.Context, *StartTestRequest) (*StartTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTest not implemented")
+}
+func (UnimplementedControlServer) CancelTest(context.Context, *CancelTestRequest) (*CancelTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTest not implemented")
+}
+func (UnimplementedControlServer) RecoverFromFatal(context.Context, *RecoverFromFatalRequest) (*RecoverFromFatalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverFromFatal not implemented")
+}
+func (UnimplementedControlServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedControlServer) Pause(context.Context, *PauseRequest) (*PauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedControlServer) Resume(context.Context, *ResumeRequest) (*ResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedControlServer) Step(context.Context, *StepRequest) (*StepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Step not implemented")
+}
+func (UnimplementedControlServer) WatchStatus(*WatchStatusRequest, Control_WatchStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
+func (UnimplementedControlServer) mustEmbedUnimplementedControlServer() {}
+
+// UnsafeControlServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ControlServer will
+// result in compilation errors.
+type UnsafeControlServer interface {
+	mustEmbedUnimplementedControlServer()
+}
+
+func RegisterControlServer(s grpc.ServiceRegistrar, srv ControlServer) {
+	s.RegisterService(&Control_ServiceDesc, srv)
+}
+
+func _Control_ListSequences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSequencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ListSequences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_ListSequences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ListSequences(ctx, req.(*ListSequencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StartTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StartTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_StartTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StartTest(ctx, req.(*StartTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_CancelTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).CancelTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_CancelTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).CancelTest(ctx, req.(*CancelTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_RecoverFromFatal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverFromFatalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RecoverFromFatal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_RecoverFromFatal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RecoverFromFatal(ctx, req.(*RecoverFromFatalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Pause(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Step(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Control_Step_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Step(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).WatchStatus(m, &controlWatchStatusServer{stream})
+}
+
+type Control_WatchStatusServer interface {
+	Send(*WatchStatusResponse) error
+	grpc.ServerStream
+}
+
+type controlWatchStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlWatchStatusServer) Send(m *WatchStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Control_ServiceDesc is the grpc.ServiceDesc for Control service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Control_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "HilControl.Control",
+	HandlerType: (*ControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSequences",
+			Handler:    _Control_ListSequences_Handler,
+		},
+		{
+			MethodName: "StartTest",
+			Handler:    _Control_StartTest_Handler,
+		},
+		{
+			MethodName: "CancelTest",
+			Handler:    _Control_CancelTest_Handler,
+		},
+		{
+			MethodName: "RecoverFromFatal",
+			Handler:    _Control_RecoverFromFatal_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _Control_Shutdown_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Control_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Control_Resume_Handler,
+		},
+		{
+			MethodName: "Step",
+			Handler:    _Control_Step_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _Control_WatchStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "control.proto",
+}
+
+const (
+	Coordinator_RegisterBench_FullMethodName = "/HilControl.Coordinator/RegisterBench"
+	Coordinator_ListBenches_FullMethodName   = "/HilControl.Coordinator/ListBenches"
+)
+
+// CoordinatorClient is the client API for Coordinator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CoordinatorClient interface {
+	// RegisterBench adds or replaces a bench. Bench agents call it on startup and then on every heartbeat interval.
+	RegisterBench(ctx context.Context, in *RegisterBenchRequest, opts ...grpc.CallOption) (*RegisterBenchResponse, error)
+	ListBenches(ctx context.Context, in *ListBenchesRequest, opts ...grpc.CallOption) (*ListBenchesResponse, error)
+}
+
+type coordinatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCoordinatorClient(cc grpc.ClientConnInterface) CoordinatorClient {
+	return &coordinatorClient{cc}
+}
+
+func (c *coordinatorClient) RegisterBench(ctx context.Context, in *RegisterBenchRequest, opts ...grpc.CallOption) (*RegisterBenchResponse, error) {
+	out := new(RegisterBenchResponse)
+	err := c.cc.Invoke(ctx, Coordinator_RegisterBench_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) ListBenches(ctx context.Context, in *ListBenchesRequest, opts ...grpc.CallOption) (*ListBenchesResponse, error) {
+	out := new(ListBenchesResponse)
+	err := c.cc.Invoke(ctx, Coordinator_ListBenches_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CoordinatorServer is the server API for Coordinator service.
+// All implementations must embed UnimplementedCoordinatorServer
+// for forward compatibility
+type CoordinatorServer interface {
+	// RegisterBench adds or replaces a bench. Bench agents call it on startup and then on every heartbeat interval.
+	RegisterBench(context.Context, *RegisterBenchRequest) (*RegisterBenchResponse, error)
+	ListBenches(context.Context, *ListBenchesRequest) (*ListBenchesResponse, error)
+	mustEmbedUnimplementedCoordinatorServer()
+}
+
+// UnimplementedCoordinatorServer must be embedded to have forward compatible implementations.
+type UnimplementedCoordinatorServer struct {
+}
+
+func (UnimplementedCoordinatorServer) RegisterBench(context.Context, *RegisterBenchRequest) (*RegisterBenchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterBench not implemented")
+}
+func (UnimplementedCoordinatorServer) ListBenches(context.Context, *ListBenchesRequest) (*ListBenchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBenches not implemented")
+}
+func (UnimplementedCoordinatorServer) mustEmbedUnimplementedCoordinatorServer() {}
+
+// UnsafeCoordinatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CoordinatorServer will
+// result in compilation errors.
+type UnsafeCoordinatorServer interface {
+	mustEmbedUnimplementedCoordinatorServer()
+}
+
+func RegisterCoordinatorServer(s grpc.ServiceRegistrar, srv CoordinatorServer) {
+	s.RegisterService(&Coordinator_ServiceDesc, srv)
+}
+
+func _Coordinator_RegisterBench_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterBenchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).RegisterBench(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coordinator_RegisterBench_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).RegisterBench(ctx, req.(*RegisterBenchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_ListBenches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBenchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).ListBenches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coordinator_ListBenches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).ListBenches(ctx, req.(*ListBenchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Coordinator_ServiceDesc is the grpc.ServiceDesc for Coordinator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Coordinator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "HilControl.Coordinator",
+	HandlerType: (*CoordinatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterBench",
+			Handler:    _Coordinator_RegisterBench_Handler,
+		},
+		{
+			MethodName: "ListBenches",
+			Handler:    _Coordinator_ListBenches_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "control.proto",
+}
@@ -0,0 +1,268 @@
+package control
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+const (
+	_dispatcherLoggerName = "grpc_dispatcher"
+	_dispatcherName       = "grpc_dispatcher"
+	// _watcherBuffer is the number of updates buffered per WatchStatus call before that call is ended.
+	_watcherBuffer = 32
+)
+
+// GrpcDispatcher is the gRPC implementation of the orchestrator.DispatcherIface. It serves the Control service
+// defined in control/proto/control.proto.
+type GrpcDispatcher struct {
+	l         *zap.Logger
+	addr      string
+	sequences map[string]flow.Sequence
+	seqOrder  []string
+
+	server  *grpc.Server
+	closing chan struct{}
+
+	start            chan orchestrator.StartSignal
+	results          chan orchestrator.ResultsSignal
+	status           chan orchestrator.StatusSignal
+	measurements     chan orchestrator.MeasurementSignal
+	cancelTest       chan orchestrator.CancelTestSignal
+	moveTest         chan orchestrator.MoveTestSignal
+	recoverFromFatal chan orchestrator.RecoverFromFatalSignal
+	pause            chan orchestrator.PauseSignal
+	resume           chan orchestrator.ResumeSignal
+	step             chan orchestrator.StepSignal
+	shutdown         chan orchestrator.ShutdownSignal
+
+	statusMtx  sync.Mutex
+	lastStatus orchestrator.StatusSignal
+
+	watchersMtx sync.Mutex
+	// watchers maps the updates of each WatchStatus call to whether it requested measurements.
+	watchers map[chan *pb.WatchStatusResponse]bool
+}
+
+// NewGrpcDispatcher creates a grpc dispatcher that will listen on addr, for example ":8001".
+func NewGrpcDispatcher(addr string, sequences []flow.Sequence, l *zap.Logger) *GrpcDispatcher {
+	ret := &GrpcDispatcher{
+		l:                l.Named(_dispatcherLoggerName),
+		addr:             addr,
+		sequences:        make(map[string]flow.Sequence, len(sequences)),
+		seqOrder:         make([]string, 0, len(sequences)),
+		closing:          make(chan struct{}),
+		start:            make(chan orchestrator.StartSignal),
+		results:          make(chan orchestrator.ResultsSignal),
+		status:           make(chan orchestrator.StatusSignal),
+		measurements:     make(chan orchestrator.MeasurementSignal),
+		cancelTest:       make(chan orchestrator.CancelTestSignal),
+		moveTest:         make(chan orchestrator.MoveTestSignal),
+		recoverFromFatal: make(chan orchestrator.RecoverFromFatalSignal),
+		pause:            make(chan orchestrator.PauseSignal),
+		resume:           make(chan orchestrator.ResumeSignal),
+		step:             make(chan orchestrator.StepSignal),
+		shutdown:         make(chan orchestrator.ShutdownSignal),
+		watchers:         make(map[chan *pb.WatchStatusResponse]bool),
+	}
+
+	for _, seq := range sequences {
+		ret.sequences[seq.Name] = seq
+		ret.seqOrder = append(ret.seqOrder, seq.Name)
+	}
+
+	return ret
+}
+
+// Name returns the dispatcher name.
+func (g *GrpcDispatcher) Name() string {
+	return _dispatcherName
+}
+
+// Open starts the grpc server.
+func (g *GrpcDispatcher) Open(ctx context.Context) error {
+	listener, err := net.Listen("tcp", g.addr)
+	if err != nil {
+		return errors.Wrapf(err, "listen (%s)", g.addr)
+	}
+
+	g.server = grpc.NewServer()
+	pb.RegisterControlServer(g.server, &controlServer{d: g})
+
+	go func() {
+		err := g.server.Serve(listener)
+		if err != nil {
+			g.l.Error("grpc server stopped", zap.Error(err))
+		}
+	}()
+
+	go g.monitorOrchestrator(ctx)
+
+	g.l.Info("grpc dispatcher listening", zap.String("addr", listener.Addr().String()))
+
+	return nil
+}
+
+// Close stops the grpc server and ends all WatchStatus calls.
+func (g *GrpcDispatcher) Close() error {
+	g.l.Info("closing grpc dispatcher")
+
+	close(g.closing)
+
+	if g.server != nil {
+		g.server.GracefulStop()
+	}
+
+	return nil
+}
+
+// Start signal is sent by the dispatcher to the orchestrator to start a test sequence.
+func (g *GrpcDispatcher) Start() <-chan orchestrator.StartSignal {
+	return g.start
+}
+
+// CancelTest will cancel execution of the test with the given ID.
+func (g *GrpcDispatcher) CancelTest() <-chan orchestrator.CancelTestSignal {
+	return g.cancelTest
+}
+
+// MoveTest will move a queued test to a new position in the test queue.
+func (g *GrpcDispatcher) MoveTest() <-chan orchestrator.MoveTestSignal {
+	return g.moveTest
+}
+
+// Shutdown will shut down the hil app.
+func (g *GrpcDispatcher) Shutdown() <-chan orchestrator.ShutdownSignal {
+	return g.shutdown
+}
+
+// RecoverFromFatal will tell the orchestrator to leave the fatal error state and go back to idle.
+func (g *GrpcDispatcher) RecoverFromFatal() <-chan orchestrator.RecoverFromFatalSignal {
+	return g.recoverFromFatal
+}
+
+// Pause will halt the running test before its next state.
+func (g *GrpcDispatcher) Pause() <-chan orchestrator.PauseSignal {
+	return g.pause
+}
+
+// Resume will continue a paused test.
+func (g *GrpcDispatcher) Resume() <-chan orchestrator.ResumeSignal {
+	return g.resume
+}
+
+// Step will run the next state of a paused test and pause again.
+func (g *GrpcDispatcher) Step() <-chan orchestrator.StepSignal {
+	return g.step
+}
+
+// Status signal is sent on updates from the orchestrator.
+func (g *GrpcDispatcher) Status() chan<- orchestrator.StatusSignal {
+	return g.status
+}
+
+// Results signal is sent at the end of a test execution or on test cancel.
+func (g *GrpcDispatcher) Results() chan<- orchestrator.ResultsSignal {
+	return g.results
+}
+
+// Measurements signal is sent for every live sample published by the running test.
+func (g *GrpcDispatcher) Measurements() chan<- orchestrator.MeasurementSignal {
+	return g.measurements
+}
+
+func (g *GrpcDispatcher) monitorOrchestrator(ctx context.Context) {
+	for {
+		select {
+		case s := <-g.status:
+			g.statusMtx.Lock()
+			g.lastStatus = s
+			g.statusMtx.Unlock()
+
+			g.publish(&pb.WatchStatusResponse{
+				Signal: &pb.WatchStatusResponse_Status{Status: toPbStatus(s)},
+			})
+		case results := <-g.results:
+			g.l.Info("results signal received", zap.String("test id", results.TestId.String()))
+
+			g.publish(&pb.WatchStatusResponse{
+				Signal: &pb.WatchStatusResponse_Results{Results: toPbResults(results)},
+			})
+		case measurement := <-g.measurements:
+			g.publishMeasurement(&pb.WatchStatusResponse{
+				Signal: &pb.WatchStatusResponse_Measurement{Measurement: toPbMeasurement(measurement)},
+			})
+		case <-ctx.Done():
+			g.l.Info("context done signal received")
+
+			return
+		}
+	}
+}
+
+func (g *GrpcDispatcher) currentStatus() orchestrator.StatusSignal {
+	g.statusMtx.Lock()
+	defer g.statusMtx.Unlock()
+
+	return g.lastStatus
+}
+
+func (g *GrpcDispatcher) subscribe(measurements bool) chan *pb.WatchStatusResponse {
+	g.watchersMtx.Lock()
+	defer g.watchersMtx.Unlock()
+
+	ch := make(chan *pb.WatchStatusResponse, _watcherBuffer)
+	g.watchers[ch] = measurements
+
+	return ch
+}
+
+func (g *GrpcDispatcher) unsubscribe(ch chan *pb.WatchStatusResponse) {
+	g.watchersMtx.Lock()
+	defer g.watchersMtx.Unlock()
+
+	delete(g.watchers, ch)
+}
+
+// publish hands the update to every WatchStatus call. A call whose buffer is full is ended rather than blocking the
+// orchestrator, so slow callers never silently miss results or status, they can watch again for the current status.
+func (g *GrpcDispatcher) publish(update *pb.WatchStatusResponse) {
+	g.watchersMtx.Lock()
+	defer g.watchersMtx.Unlock()
+
+	for ch := range g.watchers {
+		select {
+		case ch <- update:
+		default:
+			g.l.Warn("ending watch of slow watcher")
+
+			delete(g.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publishMeasurement hands the update to every WatchStatus call that requested measurements. Measurements are
+// dropped quietly for slow callers since the next sample replaces them.
+func (g *GrpcDispatcher) publishMeasurement(update *pb.WatchStatusResponse) {
+	g.watchersMtx.Lock()
+	defer g.watchersMtx.Unlock()
+
+	for ch, measurements := range g.watchers {
+		if !measurements {
+			continue
+		}
+
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package control
+
+import (
+	"testing"
+
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGrpcDispatcherEndsSlowWatcher(t *testing.T) {
+	g := NewGrpcDispatcher("", nil, zap.NewNop())
+
+	slow := g.subscribe(false)
+	defer g.unsubscribe(slow)
+
+	for i := 0; i < _watcherBuffer; i++ {
+		g.publish(&pb.WatchStatusResponse{
+			Signal: &pb.WatchStatusResponse_Status{Status: &pb.Status{}},
+		})
+	}
+
+	fast := g.subscribe(false)
+	defer g.unsubscribe(fast)
+
+	results := &pb.WatchStatusResponse{
+		Signal: &pb.WatchStatusResponse_Results{Results: &pb.Results{TestId: "test"}},
+	}
+	g.publish(results)
+
+	// The slow watcher gets every update it buffered, then its watch ends rather than missing the results.
+	for i := 0; i < _watcherBuffer; i++ {
+		_, ok := <-slow
+		require.True(t, ok)
+	}
+
+	_, ok := <-slow
+	assert.False(t, ok, "watch of slow watcher must end")
+	assert.NotContains(t, g.watchers, slow)
+
+	assert.Equal(t, results, <-fast)
+}
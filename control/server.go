@@ -0,0 +1,212 @@
+package control
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// controlServer implements the Control service on behalf of a GrpcDispatcher.
+type controlServer struct {
+	pb.UnimplementedControlServer
+
+	d *GrpcDispatcher
+}
+
+// ListSequences returns the sequences that can be started.
+func (c *controlServer) ListSequences(_ context.Context, _ *pb.ListSequencesRequest) (*pb.ListSequencesResponse, error) {
+	ret := &pb.ListSequencesResponse{
+		Sequences: make([]*pb.Sequence, 0, len(c.d.seqOrder)),
+	}
+
+	for _, name := range c.d.seqOrder {
+		ret.Sequences = append(ret.Sequences, toPbSequence(c.d.sequences[name]))
+	}
+
+	return ret, nil
+}
+
+// StartTest queues the sequence and returns the TestId of the queued test.
+func (c *controlServer) StartTest(ctx context.Context, req *pb.StartTestRequest) (*pb.StartTestResponse, error) {
+	seq, ok := c.d.sequences[req.GetSequenceName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown sequence (%s)", req.GetSequenceName())
+	}
+
+	state := c.d.currentStatus().OrchestratorState
+	if state == orchestrator.FatalError || state == orchestrator.Recovering {
+		return nil, status.Error(codes.FailedPrecondition, "orchestrator is in fatal error state, must recover from fatal error")
+	}
+
+	if missing := seq.MissingMetadata(req.GetMetadata()); len(missing) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "missing required metadata (%s)", strings.Join(missing, ", "))
+	}
+
+	testId := uuid.New()
+
+	if req.GetTestId() != "" {
+		var err error
+
+		testId, err = uuid.Parse(req.GetTestId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parse test id: %v", err)
+		}
+	}
+
+	startSig := orchestrator.StartSignal{
+		TestId:      testId,
+		Seq:         seq,
+		Metadata:    req.GetMetadata(),
+		Priority:    PriorityFromPb(req.GetPriority()),
+		Breakpoints: req.GetBreakpoints(),
+	}
+
+	if req.GetScheduledAt() != nil {
+		startSig.ScheduledAt = req.GetScheduledAt().AsTime()
+	}
+
+	err := send(ctx, c.d, c.d.start, startSig)
+	if err != nil {
+		return nil, err
+	}
+
+	c.d.l.Info("start signal sent",
+		zap.String("test id", startSig.TestId.String()),
+		zap.String("sequence", seq.Name))
+
+	return &pb.StartTestResponse{TestId: startSig.TestId.String()}, nil
+}
+
+// CancelTest cancels a queued or running test.
+func (c *controlServer) CancelTest(ctx context.Context, req *pb.CancelTestRequest) (*pb.CancelTestResponse, error) {
+	testId, err := uuid.Parse(req.GetTestId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse test id: %v", err)
+	}
+
+	err = send(ctx, c.d, c.d.cancelTest, orchestrator.CancelTestSignal{TestId: testId})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CancelTestResponse{}, nil
+}
+
+// RecoverFromFatal tells the orchestrator the fatal error has been fixed.
+func (c *controlServer) RecoverFromFatal(ctx context.Context, _ *pb.RecoverFromFatalRequest) (*pb.RecoverFromFatalResponse, error) {
+	if c.d.currentStatus().OrchestratorState != orchestrator.FatalError {
+		return nil, status.Error(codes.FailedPrecondition, "orchestrator is not in fatal error state")
+	}
+
+	err := send(ctx, c.d, c.d.recoverFromFatal, orchestrator.RecoverFromFatalSignal{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RecoverFromFatalResponse{}, nil
+}
+
+// Shutdown shuts down the hil app.
+func (c *controlServer) Shutdown(ctx context.Context, _ *pb.ShutdownRequest) (*pb.ShutdownResponse, error) {
+	c.d.l.Info("shutdown requested")
+
+	err := send(ctx, c.d, c.d.shutdown, orchestrator.ShutdownSignal{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ShutdownResponse{}, nil
+}
+
+// Pause halts the running test before its next state.
+func (c *controlServer) Pause(ctx context.Context, _ *pb.PauseRequest) (*pb.PauseResponse, error) {
+	if c.d.currentStatus().OrchestratorState != orchestrator.Running {
+		return nil, status.Error(codes.FailedPrecondition, "orchestrator is not running a test")
+	}
+
+	err := send(ctx, c.d, c.d.pause, orchestrator.PauseSignal{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PauseResponse{}, nil
+}
+
+// Resume continues a paused test.
+func (c *controlServer) Resume(ctx context.Context, _ *pb.ResumeRequest) (*pb.ResumeResponse, error) {
+	state := c.d.currentStatus().OrchestratorState
+	if state != orchestrator.Running && state != orchestrator.Paused {
+		return nil, status.Error(codes.FailedPrecondition, "orchestrator is not running a test")
+	}
+
+	err := send(ctx, c.d, c.d.resume, orchestrator.ResumeSignal{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ResumeResponse{}, nil
+}
+
+// Step runs the next state of a paused test, then pauses it again.
+func (c *controlServer) Step(ctx context.Context, _ *pb.StepRequest) (*pb.StepResponse, error) {
+	if c.d.currentStatus().OrchestratorState != orchestrator.Paused {
+		return nil, status.Error(codes.FailedPrecondition, "orchestrator is not paused")
+	}
+
+	err := send(ctx, c.d, c.d.step, orchestrator.StepSignal{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StepResponse{}, nil
+}
+
+// WatchStatus streams every status and results update to the caller, and the live measurements if requested.
+func (c *controlServer) WatchStatus(req *pb.WatchStatusRequest, stream pb.Control_WatchStatusServer) error {
+	updates := c.d.subscribe(req.Measurements)
+	defer c.d.unsubscribe(updates)
+
+	err := stream.Send(&pb.WatchStatusResponse{
+		Signal: &pb.WatchStatusResponse_Status{Status: toPbStatus(c.d.currentStatus())},
+	})
+	if err != nil {
+		return errors.Wrap(err, "send current status")
+	}
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind, watch again for the current status")
+			}
+
+			err = stream.Send(update)
+			if err != nil {
+				return errors.Wrap(err, "send update")
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-c.d.closing:
+			return status.Error(codes.Unavailable, "dispatcher is closing")
+		}
+	}
+}
+
+// send hands the signal to the orchestrator unless the call is canceled or the dispatcher is closing first.
+func send[T any](ctx context.Context, g *GrpcDispatcher, ch chan T, sig T) error {
+	select {
+	case ch <- sig:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	case <-g.closing:
+		return status.Error(codes.Unavailable, "dispatcher is closing")
+	}
+}
@@ -0,0 +1,129 @@
+package coordinator
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/flow"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	_agentLoggerName = "coordinator_agent"
+	// _retryInterval is how long the agent waits before registering again after a failure.
+	_retryInterval = 5 * time.Second
+)
+
+// Agent registers a bench with a Coordinator and keeps it registered. It runs alongside the bench's
+// control.GrpcDispatcher in hilapp.
+type Agent struct {
+	l               *zap.Logger
+	coordinatorAddr string
+	bench           *pb.Bench
+
+	conn    *grpc.ClientConn
+	client  pb.CoordinatorClient
+	closing chan struct{}
+}
+
+// NewAgent creates an agent for the bench with the given name. controlAddr is the address the coordinator uses to
+// reach the bench's control.GrpcDispatcher.
+func NewAgent(
+	coordinatorAddr, name, controlAddr, revision string,
+	sequences []flow.Sequence,
+	l *zap.Logger,
+) *Agent {
+	sequenceNames := make([]string, len(sequences))
+	for i, seq := range sequences {
+		sequenceNames[i] = seq.Name
+	}
+
+	return &Agent{
+		l:               l.Named(_agentLoggerName),
+		coordinatorAddr: coordinatorAddr,
+		bench: &pb.Bench{
+			Name:        name,
+			ControlAddr: controlAddr,
+			Revision:    revision,
+			Sequences:   sequenceNames,
+			// A new instance id on every start tells the coordinator the bench restarted.
+			InstanceId: uuid.New().String(),
+		},
+		closing: make(chan struct{}),
+	}
+}
+
+// Open connects to the coordinator and starts registering the bench in the background.
+func (a *Agent) Open(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, a.coordinatorAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return errors.Wrap(err, "dial context")
+	}
+
+	a.conn = conn
+	a.client = pb.NewCoordinatorClient(conn)
+
+	go a.heartbeat(ctx)
+
+	return nil
+}
+
+// Close stops registering the bench. The coordinator marks the bench offline once it stops responding.
+func (a *Agent) Close() error {
+	a.l.Info("closing coordinator agent")
+
+	close(a.closing)
+
+	if a.conn == nil {
+		return nil
+	}
+
+	err := a.conn.Close()
+	if err != nil {
+		return errors.Wrap(err, "close conn")
+	}
+
+	return nil
+}
+
+// heartbeat registers the bench on every heartbeat interval, which also re-registers it if the coordinator restarts.
+func (a *Agent) heartbeat(ctx context.Context) {
+	registered := false
+
+	for {
+		interval := _retryInterval
+
+		reply, err := a.client.RegisterBench(ctx, &pb.RegisterBenchRequest{Bench: a.bench})
+
+		switch {
+		case err != nil:
+			a.l.Warn("failed to register with coordinator",
+				zap.String("coordinator", a.coordinatorAddr),
+				zap.Error(err))
+
+			registered = false
+		default:
+			if !registered {
+				a.l.Info("registered with coordinator",
+					zap.String("coordinator", a.coordinatorAddr),
+					zap.String("bench", a.bench.Name))
+			}
+
+			registered = true
+			interval = reply.GetHeartbeatInterval().AsDuration()
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		case <-a.closing:
+			return
+		}
+	}
+}
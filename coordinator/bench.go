@@ -0,0 +1,100 @@
+package coordinator
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/control"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// bench is a registered bench. All fields other than info and client are guarded by the Coordinator's mtx.
+type bench struct {
+	info   *pb.Bench
+	client *control.Client
+
+	cancel   context.CancelFunc
+	watching bool
+
+	online        bool
+	lastSeen      time.Time
+	lastHeartbeat time.Time
+	status        *pb.Status
+	// assigned is the test the coordinator handed to the bench, it is uuid.Nil if the bench is free.
+	assigned orchestrator.TestId
+	// reconciled is set once assigned has been checked against the first status after (re)connecting.
+	reconciled bool
+}
+
+func newBench(info *pb.Bench, l *zap.Logger) *bench {
+	return &bench{
+		info:          info,
+		client:        control.NewClient(info.ControlAddr, l.With(zap.String("bench", info.Name))),
+		lastSeen:      time.Now(),
+		lastHeartbeat: time.Now(),
+		assigned:      uuid.Nil,
+	}
+}
+
+// watch connects to the bench's Control service and calls onUpdate for every update until the stream ends.
+func (b *bench) watch(ctx context.Context, onUpdate func(b *bench, update *pb.WatchStatusResponse)) error {
+	err := b.client.Open(ctx)
+	if err != nil {
+		return errors.Wrap(err, "open client")
+	}
+
+	err = b.client.WatchStatus(ctx, func(update *pb.WatchStatusResponse) bool {
+		onUpdate(b, update)
+		return true
+	})
+	if err != nil {
+		return errors.Wrap(err, "watch status")
+	}
+
+	return nil
+}
+
+// stop ends the watch and closes the connection to the bench.
+func (b *bench) stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+
+	_ = b.client.Close()
+}
+
+// isIdle returns true if the bench is online, idle and has nothing queued or assigned.
+func (b *bench) isIdle() bool {
+	return b.online &&
+		b.assigned == uuid.Nil &&
+		b.status.GetOrchestratorState() == pb.OrchestratorState_ORCHESTRATOR_STATE_IDLE &&
+		len(b.status.GetQueue()) == 0
+}
+
+// canRun returns true if the bench has the requested sequence and revision.
+func (b *bench) canRun(req *pb.StartTestRequest) bool {
+	if req.Revision != "" && req.Revision != b.info.Revision {
+		return false
+	}
+
+	return slices.Contains(b.info.Sequences, req.SequenceName)
+}
+
+// hasTest returns true if the test is running, paused or queued on the bench.
+func (b *bench) hasTest(testId orchestrator.TestId) bool {
+	switch b.status.GetOrchestratorState() {
+	case pb.OrchestratorState_ORCHESTRATOR_STATE_RUNNING, pb.OrchestratorState_ORCHESTRATOR_STATE_PAUSED:
+		if b.status.GetTestId() == testId.String() {
+			return true
+		}
+	}
+
+	return slices.ContainsFunc(b.status.GetQueue(), func(queued *pb.QueuedTest) bool {
+		return queued.TestId == testId.String()
+	})
+}
@@ -0,0 +1,674 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/control"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	_loggerName = "coordinator"
+	// _heartbeatInterval is how often bench agents register again.
+	_heartbeatInterval = 10 * time.Second
+	// _offlineTimeout is how long a bench can be unreachable before its assigned test is requeued.
+	_offlineTimeout = 3 * _heartbeatInterval
+	_dispatchPeriod = 500 * time.Millisecond
+	// _maxRequeues is how many times a test is requeued after bench failures before it is failed.
+	_maxRequeues = 3
+	// _watcherBuffer is the number of updates buffered per WatchStatus call before that call is ended.
+	_watcherBuffer = 32
+)
+
+// queuedTest is a test waiting in the coordinator's queue for a bench.
+type queuedTest struct {
+	testId   orchestrator.TestId
+	req      *pb.StartTestRequest
+	priority orchestrator.Priority
+}
+
+// Coordinator fans tests out to several benches. Each bench runs its own hilapp with a control.GrpcDispatcher and
+// registers itself through an Agent. The Coordinator serves the Control service for all benches as a whole, so
+// clients such as hilctl can start tests without knowing which bench will run them.
+type Coordinator struct {
+	l    *zap.Logger
+	addr string
+
+	server  *grpc.Server
+	ctx     context.Context
+	closing chan struct{}
+
+	mtx     sync.Mutex
+	benches map[string]*bench
+	queue   []queuedTest
+	// assigned maps the tests handed to a bench to their request, so they can be requeued.
+	assigned map[orchestrator.TestId]*pb.StartTestRequest
+	// abandoned maps requeued tests to the bench they were taken from, results from that bench are ignored.
+	abandoned map[orchestrator.TestId]string
+	// requeues counts how many times each test has been requeued.
+	requeues map[orchestrator.TestId]int
+
+	watchersMtx sync.Mutex
+	watchers    map[chan *pb.WatchStatusResponse]struct{}
+}
+
+// NewCoordinator creates a coordinator that will listen on addr, for example ":9000".
+func NewCoordinator(addr string, l *zap.Logger) *Coordinator {
+	return &Coordinator{
+		l:         l.Named(_loggerName),
+		addr:      addr,
+		closing:   make(chan struct{}),
+		benches:   make(map[string]*bench),
+		queue:     make([]queuedTest, 0),
+		assigned:  make(map[orchestrator.TestId]*pb.StartTestRequest),
+		abandoned: make(map[orchestrator.TestId]string),
+		requeues:  make(map[orchestrator.TestId]int),
+		watchers:  make(map[chan *pb.WatchStatusResponse]struct{}),
+	}
+}
+
+// Open starts the grpc server and the dispatch loop.
+func (c *Coordinator) Open(ctx context.Context) error {
+	listener, err := net.Listen("tcp", c.addr)
+	if err != nil {
+		return errors.Wrapf(err, "listen (%s)", c.addr)
+	}
+
+	c.ctx = ctx
+
+	c.server = grpc.NewServer()
+	pb.RegisterControlServer(c.server, &controlServer{c: c})
+	pb.RegisterCoordinatorServer(c.server, &coordinatorServer{c: c})
+
+	go func() {
+		err := c.server.Serve(listener)
+		if err != nil {
+			c.l.Error("grpc server stopped", zap.Error(err))
+		}
+	}()
+
+	go c.dispatch(ctx)
+
+	c.l.Info("coordinator listening", zap.String("addr", listener.Addr().String()))
+
+	return nil
+}
+
+// Close stops the grpc server and disconnects from all benches.
+func (c *Coordinator) Close() error {
+	c.l.Info("closing coordinator")
+
+	close(c.closing)
+
+	if c.server != nil {
+		c.server.GracefulStop()
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, b := range c.benches {
+		b.stop()
+	}
+
+	return nil
+}
+
+// register adds a new bench, or replaces it if it restarted or moved. Heartbeats from a known bench only refresh it.
+func (c *Coordinator) register(info *pb.Bench) error {
+	if info.GetName() == "" || info.GetControlAddr() == "" {
+		return errors.New("bench name and control address are required")
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	existing, ok := c.benches[info.Name]
+	if ok && existing.info.InstanceId == info.InstanceId && existing.info.ControlAddr == info.ControlAddr {
+		existing.lastHeartbeat = time.Now()
+
+		if !existing.watching {
+			c.watch(existing)
+		}
+
+		return nil
+	}
+
+	b := newBench(info, c.l)
+
+	if ok {
+		c.l.Info("bench re-registered",
+			zap.String("bench", info.Name),
+			zap.String("instance id", info.InstanceId))
+
+		existing.stop()
+
+		// The bench may have resumed the test from its run history, this is checked on its first status.
+		b.assigned = existing.assigned
+	} else {
+		c.l.Info("bench registered",
+			zap.String("bench", info.Name),
+			zap.String("addr", info.ControlAddr),
+			zap.String("revision", info.Revision))
+	}
+
+	c.benches[info.Name] = b
+	c.watch(b)
+
+	return nil
+}
+
+// watch connects to the bench and follows its status until the bench is unreachable. The caller must hold mtx.
+func (c *Coordinator) watch(b *bench) {
+	ctx, cancel := context.WithCancel(c.ctx)
+
+	b.watching = true
+	b.cancel = cancel
+	// Updates can be missed while the bench is not watched, so its assigned test is checked again.
+	b.reconciled = false
+
+	go func() {
+		err := b.watch(ctx, c.onBenchUpdate)
+		if err != nil && ctx.Err() == nil {
+			c.l.Warn("lost connection to bench", zap.String("bench", b.info.Name), zap.Error(err))
+		}
+
+		c.mtx.Lock()
+		b.watching = false
+		b.online = false
+		c.mtx.Unlock()
+
+		c.publishStatus()
+	}()
+}
+
+// onBenchUpdate handles a status or results update from a bench.
+func (c *Coordinator) onBenchUpdate(b *bench, update *pb.WatchStatusResponse) {
+	switch signal := update.Signal.(type) {
+	case *pb.WatchStatusResponse_Status:
+		c.onBenchStatus(b, signal.Status)
+	case *pb.WatchStatusResponse_Results:
+		c.onBenchResults(b, signal.Results)
+	}
+}
+
+func (c *Coordinator) onBenchStatus(b *bench, status *pb.Status) {
+	c.mtx.Lock()
+
+	b.status = status
+	b.online = true
+	b.lastSeen = time.Now()
+
+	if status.OrchestratorState == pb.OrchestratorState_ORCHESTRATOR_STATE_UNKNOWN {
+		c.mtx.Unlock()
+		return
+	}
+
+	var failed *pb.Results
+
+	if !b.reconciled {
+		b.reconciled = true
+
+		if b.assigned != uuid.Nil && !b.hasTest(b.assigned) {
+			c.l.Warn("bench lost its assigned test, requeuing",
+				zap.String("bench", b.info.Name),
+				zap.String("test id", b.assigned.String()))
+
+			failed = c.requeue(b, "bench lost the test")
+		}
+	}
+
+	// A test that ran, or was dequeued, on the bench ends with results that decide whether it is requeued. A test
+	// still queued on the bench will not run until the bench is recovered, so another bench may run it now.
+	if status.OrchestratorState == pb.OrchestratorState_ORCHESTRATOR_STATE_FATAL_ERROR &&
+		b.assigned != uuid.Nil && b.hasTest(b.assigned) {
+		c.l.Warn("bench in fatal error, requeuing its queued test",
+			zap.String("bench", b.info.Name),
+			zap.String("test id", b.assigned.String()),
+			zap.String("fatal error", status.FatalError))
+
+		failed = c.requeue(b, "bench in fatal error: "+status.FatalError)
+	}
+
+	c.mtx.Unlock()
+
+	c.publishResults(failed)
+	c.publishStatus()
+}
+
+func (c *Coordinator) onBenchResults(b *bench, results *pb.Results) {
+	testId, err := uuid.Parse(results.TestId)
+	if err != nil {
+		c.l.Warn("bench sent results with an invalid test id", zap.String("bench", b.info.Name), zap.Error(err))
+		return
+	}
+
+	c.mtx.Lock()
+
+	if c.abandoned[testId] == b.info.Name {
+		delete(c.abandoned, testId)
+		c.mtx.Unlock()
+
+		c.l.Info("ignoring results of requeued test",
+			zap.String("bench", b.info.Name),
+			zap.String("test id", testId.String()))
+
+		return
+	}
+
+	if b.assigned != testId {
+		c.mtx.Unlock()
+		return
+	}
+
+	// The test did not start because a bench health check failed, another bench may be able to run it now. Tests
+	// that failed on their own, including with a fatal error, would fail the same way on any bench.
+	if results.BenchError != "" && c.requeues[testId] < _maxRequeues {
+		c.l.Warn("bench health check failed, requeuing its test",
+			zap.String("bench", b.info.Name),
+			zap.String("test id", testId.String()),
+			zap.String("bench error", results.BenchError))
+
+		c.requeue(b, results.BenchError)
+		delete(c.abandoned, testId)
+		c.mtx.Unlock()
+
+		c.publishStatus()
+
+		return
+	}
+
+	b.assigned = uuid.Nil
+	delete(c.assigned, testId)
+	delete(c.requeues, testId)
+
+	c.mtx.Unlock()
+
+	c.l.Info("results received",
+		zap.String("bench", b.info.Name),
+		zap.String("test id", testId.String()),
+		zap.Bool("is passing", results.IsPassing))
+
+	c.publishResults(results)
+	c.publishStatus()
+}
+
+// requeue takes the bench's assigned test after a bench failure and puts it back at the front of the queue. A test
+// that was already requeued _maxRequeues times is failed instead, its results are returned so the caller can
+// publish them once it no longer holds mtx. The caller must hold mtx.
+func (c *Coordinator) requeue(b *bench, reason string) *pb.Results {
+	testId := b.assigned
+	req := c.assigned[testId]
+
+	b.assigned = uuid.Nil
+	delete(c.assigned, testId)
+
+	if req == nil {
+		return nil
+	}
+
+	c.abandoned[testId] = b.info.Name
+
+	if c.requeues[testId] >= _maxRequeues {
+		delete(c.requeues, testId)
+
+		c.l.Error("test requeued too many times, failing it",
+			zap.String("test id", testId.String()),
+			zap.Int("requeues", _maxRequeues),
+			zap.String("reason", reason))
+
+		return &pb.Results{
+			TestId:     testId.String(),
+			IsPassing:  false,
+			FailedTags: make([]*pb.Tag, 0),
+			BenchError: fmt.Sprintf("test failed on a bench %d times, last on bench (%s): %s",
+				_maxRequeues+1, b.info.Name, reason),
+		}
+	}
+
+	c.requeues[testId]++
+	c.queue = append([]queuedTest{{
+		testId:   testId,
+		req:      req,
+		priority: control.PriorityFromPb(req.Priority),
+	}}, c.queue...)
+
+	return nil
+}
+
+// enqueue places the test behind all queued tests of the same or higher priority.
+func (c *Coordinator) enqueue(testId orchestrator.TestId, req *pb.StartTestRequest) {
+	c.mtx.Lock()
+
+	test := queuedTest{
+		testId:   testId,
+		req:      req,
+		priority: control.PriorityFromPb(req.Priority),
+	}
+
+	idx := len(c.queue)
+
+	for i, queued := range c.queue {
+		if queued.priority < test.priority {
+			idx = i
+			break
+		}
+	}
+
+	c.queue = append(c.queue[:idx], append([]queuedTest{test}, c.queue[idx:]...)...)
+
+	c.mtx.Unlock()
+
+	c.publishStatus()
+}
+
+// dequeue removes a test from the queue, it returns false if the test was not queued.
+func (c *Coordinator) dequeue(testId orchestrator.TestId) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for i, queued := range c.queue {
+		if queued.testId == testId {
+			c.queue = append(c.queue[:i], c.queue[i+1:]...)
+			delete(c.requeues, testId)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// canRun returns true if a registered bench, online or not, can run the request.
+func (c *Coordinator) canRun(req *pb.StartTestRequest) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, b := range c.benches {
+		if b.canRun(req) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dispatch hands queued tests to idle benches until ctx is done.
+func (c *Coordinator) dispatch(ctx context.Context) {
+	for {
+		select {
+		case <-time.After(_dispatchPeriod):
+		case <-ctx.Done():
+			return
+		case <-c.closing:
+			return
+		}
+
+		c.requeueOffline()
+
+		for c.dispatchNext(ctx) {
+		}
+	}
+}
+
+// dispatchNext starts the first runnable queued test on an idle bench. It returns true if a test was started.
+func (c *Coordinator) dispatchNext(ctx context.Context) bool {
+	c.mtx.Lock()
+
+	now := time.Now()
+
+	var (
+		test  queuedTest
+		idle  *bench
+		found bool
+	)
+
+	for i, queued := range c.queue {
+		if queued.req.ScheduledAt != nil && queued.req.ScheduledAt.AsTime().After(now) {
+			continue
+		}
+
+		idle = c.idleBench(queued.req)
+		if idle == nil {
+			continue
+		}
+
+		test = queued
+		found = true
+		c.queue = append(c.queue[:i], c.queue[i+1:]...)
+
+		break
+	}
+
+	if !found {
+		c.mtx.Unlock()
+		return false
+	}
+
+	idle.assigned = test.testId
+	c.assigned[test.testId] = test.req
+	delete(c.abandoned, test.testId)
+
+	c.mtx.Unlock()
+
+	c.l.Info("starting test on bench",
+		zap.String("bench", idle.info.Name),
+		zap.String("test id", test.testId.String()),
+		zap.String("sequence", test.req.SequenceName))
+
+	_, err := idle.client.StartTest(ctx, test.req.SequenceName,
+		control.WithTestId(test.testId),
+		control.WithMetadata(test.req.Metadata),
+		control.WithBreakpoints(test.req.Breakpoints...))
+	if err != nil {
+		c.l.Error("failed to start test on bench, requeuing",
+			zap.String("bench", idle.info.Name),
+			zap.String("test id", test.testId.String()),
+			zap.Error(err))
+
+		var failed *pb.Results
+
+		c.mtx.Lock()
+		if idle.assigned == test.testId {
+			failed = c.requeue(idle, err.Error())
+			delete(c.abandoned, test.testId)
+		}
+		c.mtx.Unlock()
+
+		c.publishResults(failed)
+		c.publishStatus()
+
+		return false
+	}
+
+	c.publishStatus()
+
+	return true
+}
+
+// idleBench returns an online, idle bench that can run the request, in name order. The caller must hold mtx.
+func (c *Coordinator) idleBench(req *pb.StartTestRequest) *bench {
+	for _, name := range sortedBenchNames(c.benches) {
+		b := c.benches[name]
+
+		if b.isIdle() && b.canRun(req) {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// requeueOffline requeues tests assigned to benches that have been unreachable for too long.
+func (c *Coordinator) requeueOffline() {
+	failed := make([]*pb.Results, 0)
+
+	c.mtx.Lock()
+
+	for _, b := range c.benches {
+		if b.online || b.assigned == uuid.Nil || time.Since(b.lastSeen) < _offlineTimeout {
+			continue
+		}
+
+		c.l.Warn("bench offline, requeuing its test",
+			zap.String("bench", b.info.Name),
+			zap.String("test id", b.assigned.String()))
+
+		results := c.requeue(b, "bench offline")
+		if results != nil {
+			failed = append(failed, results)
+		}
+	}
+
+	c.mtx.Unlock()
+
+	for _, results := range failed {
+		c.publishResults(results)
+	}
+
+	if len(failed) > 0 {
+		c.publishStatus()
+	}
+}
+
+// benchStatuses returns a snapshot of every registered bench, in name order.
+func (c *Coordinator) benchStatuses() []*pb.BenchStatus {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ret := make([]*pb.BenchStatus, 0, len(c.benches))
+
+	for _, name := range sortedBenchNames(c.benches) {
+		b := c.benches[name]
+
+		status := &pb.BenchStatus{
+			Bench:    b.info,
+			Online:   b.online,
+			Status:   b.status,
+			LastSeen: timestamppb.New(b.lastSeen),
+		}
+
+		if b.assigned != uuid.Nil {
+			status.AssignedTestId = b.assigned.String()
+		}
+
+		ret = append(ret, status)
+	}
+
+	return ret
+}
+
+// status aggregates the benches into a single status. The coordinator is Running while any bench has an assigned
+// test and reports the fatal errors of all benches.
+func (c *Coordinator) status() *pb.Status {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ret := &pb.Status{
+		OrchestratorState: pb.OrchestratorState_ORCHESTRATOR_STATE_IDLE,
+		Queue:             make([]*pb.QueuedTest, len(c.queue)),
+	}
+
+	fatalErrs := make([]string, 0)
+
+	for _, name := range sortedBenchNames(c.benches) {
+		b := c.benches[name]
+
+		if b.assigned != uuid.Nil {
+			ret.OrchestratorState = pb.OrchestratorState_ORCHESTRATOR_STATE_RUNNING
+		}
+
+		if b.status.GetFatalError() != "" {
+			fatalErrs = append(fatalErrs, name+": "+b.status.GetFatalError())
+		}
+	}
+
+	ret.FatalError = strings.Join(fatalErrs, "; ")
+
+	for i, queued := range c.queue {
+		ret.Queue[i] = &pb.QueuedTest{
+			TestId:       queued.testId.String(),
+			SequenceName: queued.req.SequenceName,
+			Metadata:     queued.req.Metadata,
+			Priority:     queued.req.Priority,
+			ScheduledAt:  queued.req.ScheduledAt,
+		}
+	}
+
+	return ret
+}
+
+// publishResults publishes the results of a test, it does nothing if results is nil.
+func (c *Coordinator) publishResults(results *pb.Results) {
+	if results == nil {
+		return
+	}
+
+	c.publish(&pb.WatchStatusResponse{
+		Signal: &pb.WatchStatusResponse_Results{Results: results},
+	})
+}
+
+func (c *Coordinator) publishStatus() {
+	c.publish(&pb.WatchStatusResponse{
+		Signal: &pb.WatchStatusResponse_Status{Status: c.status()},
+	})
+}
+
+func (c *Coordinator) subscribe() chan *pb.WatchStatusResponse {
+	c.watchersMtx.Lock()
+	defer c.watchersMtx.Unlock()
+
+	ch := make(chan *pb.WatchStatusResponse, _watcherBuffer)
+	c.watchers[ch] = struct{}{}
+
+	return ch
+}
+
+func (c *Coordinator) unsubscribe(ch chan *pb.WatchStatusResponse) {
+	c.watchersMtx.Lock()
+	defer c.watchersMtx.Unlock()
+
+	delete(c.watchers, ch)
+}
+
+// publish hands the update to every WatchStatus call. A call whose buffer is full is ended rather than blocking the
+// benches, so slow callers never silently miss results or status, they can watch again for the current status.
+func (c *Coordinator) publish(update *pb.WatchStatusResponse) {
+	c.watchersMtx.Lock()
+	defer c.watchersMtx.Unlock()
+
+	for ch := range c.watchers {
+		select {
+		case ch <- update:
+		default:
+			c.l.Warn("ending watch of slow watcher")
+
+			delete(c.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+func sortedBenchNames(benches map[string]*bench) []string {
+	names := make([]string, 0, len(benches))
+	for name := range benches {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,153 @@
+package coordinator
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestCoordinator(t *testing.T) (*Coordinator, *bench, chan *pb.WatchStatusResponse) {
+	t.Helper()
+
+	c := NewCoordinator("", zap.NewNop())
+	b := newBench(&pb.Bench{Name: "sil", ControlAddr: "localhost:0", Sequences: []string{"lv_sequence"}}, c.l)
+	c.benches[b.info.Name] = b
+
+	updates := c.subscribe()
+	t.Cleanup(func() { c.unsubscribe(updates) })
+
+	return c, b, updates
+}
+
+// assign hands the test to the bench the same way dispatchNext does.
+func assign(c *Coordinator, b *bench, testId uuid.UUID, req *pb.StartTestRequest) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.queue = c.queue[:0]
+	b.assigned = testId
+	c.assigned[testId] = req
+	delete(c.abandoned, testId)
+}
+
+// publishedResults returns the results published so far, skipping status updates.
+func publishedResults(updates chan *pb.WatchStatusResponse) []*pb.Results {
+	ret := make([]*pb.Results, 0)
+
+	for {
+		select {
+		case update := <-updates:
+			results, ok := update.Signal.(*pb.WatchStatusResponse_Results)
+			if ok {
+				ret = append(ret, results.Results)
+			}
+		default:
+			return ret
+		}
+	}
+}
+
+func TestCoordinatorRequeuesBenchErrorsUpToLimit(t *testing.T) {
+	c, b, updates := newTestCoordinator(t)
+
+	testId := uuid.New()
+	req := &pb.StartTestRequest{SequenceName: "lv_sequence", TestId: testId.String()}
+	benchErr := &pb.Results{TestId: testId.String(), BenchError: "can bus down", FatalError: "can bus down"}
+
+	for i := 0; i < _maxRequeues; i++ {
+		assign(c, b, testId, req)
+		c.onBenchResults(b, benchErr)
+
+		require.Len(t, c.queue, 1, "requeue %d", i+1)
+		assert.Equal(t, testId, c.queue[0].testId)
+		assert.Empty(t, publishedResults(updates))
+	}
+
+	assign(c, b, testId, req)
+	c.onBenchResults(b, benchErr)
+
+	assert.Empty(t, c.queue)
+	assert.Equal(t, uuid.Nil, b.assigned)
+	assert.NotContains(t, c.requeues, testId)
+
+	results := publishedResults(updates)
+	require.Len(t, results, 1)
+	assert.Equal(t, "can bus down", results[0].BenchError)
+}
+
+func TestCoordinatorPublishesFatalResults(t *testing.T) {
+	c, b, updates := newTestCoordinator(t)
+
+	testId := uuid.New()
+	assign(c, b, testId, &pb.StartTestRequest{SequenceName: "lv_sequence", TestId: testId.String()})
+
+	c.onBenchStatus(b, &pb.Status{
+		OrchestratorState: pb.OrchestratorState_ORCHESTRATOR_STATE_RUNNING,
+		TestId:            testId.String(),
+	})
+	c.onBenchStatus(b, &pb.Status{
+		OrchestratorState: pb.OrchestratorState_ORCHESTRATOR_STATE_FATAL_ERROR,
+		FatalError:        "state panicked",
+	})
+
+	// The test ran on the bench, so it waits for its results rather than being requeued.
+	assert.Equal(t, testId, b.assigned)
+	assert.Empty(t, c.queue)
+
+	c.onBenchResults(b, &pb.Results{TestId: testId.String(), FatalError: "state panicked"})
+
+	assert.Empty(t, c.queue)
+	assert.Equal(t, uuid.Nil, b.assigned)
+
+	results := publishedResults(updates)
+	require.Len(t, results, 1)
+	assert.Equal(t, "state panicked", results[0].FatalError)
+}
+
+func TestCoordinatorFailsTestOfOfflineBenchAfterLimit(t *testing.T) {
+	c, b, updates := newTestCoordinator(t)
+
+	testId := uuid.New()
+	req := &pb.StartTestRequest{SequenceName: "lv_sequence", TestId: testId.String()}
+
+	for i := 0; i <= _maxRequeues; i++ {
+		assign(c, b, testId, req)
+
+		c.mtx.Lock()
+		b.lastSeen = b.lastSeen.Add(-2 * _offlineTimeout)
+		c.mtx.Unlock()
+
+		c.requeueOffline()
+	}
+
+	assert.Empty(t, c.queue)
+
+	results := publishedResults(updates)
+	require.Len(t, results, 1)
+	assert.Equal(t, testId.String(), results[0].TestId)
+	assert.False(t, results[0].IsPassing)
+	assert.Contains(t, results[0].BenchError, "bench offline")
+}
+
+func TestCoordinatorEndsSlowWatcher(t *testing.T) {
+	c, _, slow := newTestCoordinator(t)
+
+	for i := 0; i < _watcherBuffer; i++ {
+		c.publishStatus()
+	}
+
+	c.publishResults(&pb.Results{TestId: uuid.NewString()})
+
+	for i := 0; i < _watcherBuffer; i++ {
+		_, ok := <-slow
+		require.True(t, ok)
+	}
+
+	_, ok := <-slow
+	assert.False(t, ok, "watch of slow watcher must end")
+	assert.NotContains(t, c.watchers, slow)
+}
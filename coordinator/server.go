@@ -0,0 +1,208 @@
+package coordinator
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	pb "github.com/macformula/hil/control/generated"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// controlServer implements the Control service for all benches of a Coordinator.
+type controlServer struct {
+	pb.UnimplementedControlServer
+
+	c *Coordinator
+}
+
+// ListSequences returns the sequences that at least one registered bench can run.
+func (s *controlServer) ListSequences(_ context.Context, _ *pb.ListSequencesRequest) (*pb.ListSequencesResponse, error) {
+	s.c.mtx.Lock()
+	defer s.c.mtx.Unlock()
+
+	names := make(map[string]struct{})
+
+	for _, b := range s.c.benches {
+		for _, name := range b.info.Sequences {
+			names[name] = struct{}{}
+		}
+	}
+
+	ret := &pb.ListSequencesResponse{
+		Sequences: make([]*pb.Sequence, 0, len(names)),
+	}
+
+	for name := range names {
+		ret.Sequences = append(ret.Sequences, &pb.Sequence{Name: name})
+	}
+
+	sort.Slice(ret.Sequences, func(i, j int) bool {
+		return ret.Sequences[i].Name < ret.Sequences[j].Name
+	})
+
+	return ret, nil
+}
+
+// StartTest queues the test until a bench that can run it is idle.
+func (s *controlServer) StartTest(_ context.Context, req *pb.StartTestRequest) (*pb.StartTestResponse, error) {
+	if !s.c.canRun(req) {
+		return nil, status.Errorf(codes.NotFound,
+			"no bench can run sequence (%s) revision (%s)", req.GetSequenceName(), req.GetRevision())
+	}
+
+	testId := uuid.New()
+
+	if req.GetTestId() != "" {
+		var err error
+
+		testId, err = uuid.Parse(req.GetTestId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parse test id: %v", err)
+		}
+	}
+
+	req = proto.Clone(req).(*pb.StartTestRequest)
+	req.TestId = testId.String()
+
+	s.c.enqueue(testId, req)
+
+	s.c.l.Info("test queued",
+		zap.String("test id", testId.String()),
+		zap.String("sequence", req.SequenceName),
+		zap.String("revision", req.Revision))
+
+	return &pb.StartTestResponse{TestId: testId.String()}, nil
+}
+
+// CancelTest removes a queued test or cancels it on the bench it was handed to.
+func (s *controlServer) CancelTest(ctx context.Context, req *pb.CancelTestRequest) (*pb.CancelTestResponse, error) {
+	testId, err := uuid.Parse(req.GetTestId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse test id: %v", err)
+	}
+
+	if s.c.dequeue(testId) {
+		s.c.publish(&pb.WatchStatusResponse{
+			Signal: &pb.WatchStatusResponse_Results{Results: &pb.Results{TestId: testId.String(), IsPassing: false}},
+		})
+		s.c.publishStatus()
+
+		return &pb.CancelTestResponse{}, nil
+	}
+
+	s.c.mtx.Lock()
+
+	var assignedTo *bench
+
+	for _, b := range s.c.benches {
+		if b.assigned == testId {
+			assignedTo = b
+			break
+		}
+	}
+
+	s.c.mtx.Unlock()
+
+	if assignedTo == nil {
+		return nil, status.Errorf(codes.NotFound, "test (%s) is not queued or running", testId)
+	}
+
+	err = assignedTo.client.CancelTest(ctx, testId)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cancel test on bench (%s): %v", assignedTo.info.Name, err)
+	}
+
+	return &pb.CancelTestResponse{}, nil
+}
+
+// RecoverFromFatal tells every bench in fatal error state that its fatal error has been fixed.
+func (s *controlServer) RecoverFromFatal(ctx context.Context, _ *pb.RecoverFromFatalRequest) (*pb.RecoverFromFatalResponse, error) {
+	s.c.mtx.Lock()
+
+	fatal := make([]*bench, 0)
+
+	for _, b := range s.c.benches {
+		if b.status.GetOrchestratorState() == pb.OrchestratorState_ORCHESTRATOR_STATE_FATAL_ERROR {
+			fatal = append(fatal, b)
+		}
+	}
+
+	s.c.mtx.Unlock()
+
+	if len(fatal) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no bench is in fatal error state")
+	}
+
+	for _, b := range fatal {
+		err := b.client.RecoverFromFatal(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "recover bench (%s): %v", b.info.Name, err)
+		}
+	}
+
+	return &pb.RecoverFromFatalResponse{}, nil
+}
+
+// Shutdown is not supported by the coordinator, benches must be shut down individually.
+func (s *controlServer) Shutdown(_ context.Context, _ *pb.ShutdownRequest) (*pb.ShutdownResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "benches must be shut down individually")
+}
+
+// WatchStatus streams the aggregated status and the results of every test routed through the coordinator.
+func (s *controlServer) WatchStatus(_ *pb.WatchStatusRequest, stream pb.Control_WatchStatusServer) error {
+	updates := s.c.subscribe()
+	defer s.c.unsubscribe(updates)
+
+	err := stream.Send(&pb.WatchStatusResponse{
+		Signal: &pb.WatchStatusResponse_Status{Status: s.c.status()},
+	})
+	if err != nil {
+		return errors.Wrap(err, "send current status")
+	}
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind, watch again for the current status")
+			}
+
+			err = stream.Send(update)
+			if err != nil {
+				return errors.Wrap(err, "send update")
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-s.c.closing:
+			return status.Error(codes.Unavailable, "coordinator is closing")
+		}
+	}
+}
+
+// coordinatorServer implements the Coordinator service.
+type coordinatorServer struct {
+	pb.UnimplementedCoordinatorServer
+
+	c *Coordinator
+}
+
+// RegisterBench adds or refreshes a bench.
+func (s *coordinatorServer) RegisterBench(_ context.Context, req *pb.RegisterBenchRequest) (*pb.RegisterBenchResponse, error) {
+	err := s.c.register(req.GetBench())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "register bench: %v", err)
+	}
+
+	return &pb.RegisterBenchResponse{HeartbeatInterval: durationpb.New(_heartbeatInterval)}, nil
+}
+
+// ListBenches returns the status of every registered bench.
+func (s *coordinatorServer) ListBenches(_ context.Context, _ *pb.ListBenchesRequest) (*pb.ListBenchesResponse, error) {
+	return &pb.ListBenchesResponse{Benches: s.c.benchStatuses()}, nil
+}
@@ -0,0 +1,95 @@
+package flow
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate enumer -type=ArtifactKind -trimprefix=Artifact -json artifact.go
+
+// ArtifactKind is the type of data held by an Artifact.
+type ArtifactKind int
+
+const (
+	// ArtifactFile is a file on disk, such as a CAN trace.
+	ArtifactFile ArtifactKind = iota
+	// ArtifactBytes is a blob of data held in memory, such as a screenshot or a register dump.
+	ArtifactBytes
+	// ArtifactTimeSeries is a series of timestamped measurements, such as a voltage captured during a state.
+	ArtifactTimeSeries
+)
+
+// Artifact is a named output attached to the current test by a state.
+type Artifact struct {
+	// Name identifies the artifact within the test, it is also used as its file name in the bundle.
+	Name string
+	Kind ArtifactKind
+	// StateName is the top-level state that attached the artifact, or the composite state holding the child that
+	// attached it. It is filled in by the Sequencer.
+	StateName string
+	// Path is the file to attach. Only valid if Kind is ArtifactFile.
+	Path string
+	// Data is the blob to attach. Only valid if Kind is ArtifactBytes.
+	Data []byte
+	// Series is the series to attach. Only valid if Kind is ArtifactTimeSeries.
+	Series TimeSeries
+	// AttachedAt is when the state attached the artifact.
+	AttachedAt time.Time
+}
+
+// TimeSeries is a series of measurements of a single signal.
+type TimeSeries struct {
+	// Unit of the values, for example "V". It is only used for display.
+	Unit   string
+	Points []Point
+}
+
+// Point is a single measurement of a TimeSeries.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// artifactSink is called with every artifact a state attaches to the current test.
+type artifactSink = func(artifact Artifact) error
+
+// AttachFile attaches the file at path to the current test. The file must not be removed until the test completes.
+func AttachFile(ctx context.Context, name, path string) error {
+	return attach(ctx, Artifact{Name: name, Kind: ArtifactFile, Path: path})
+}
+
+// AttachBytes attaches data to the current test.
+func AttachBytes(ctx context.Context, name string, data []byte) error {
+	return attach(ctx, Artifact{Name: name, Kind: ArtifactBytes, Data: data})
+}
+
+// AttachTimeSeries attaches a series of measurements to the current test.
+func AttachTimeSeries(ctx context.Context, name string, series TimeSeries) error {
+	return attach(ctx, Artifact{Name: name, Kind: ArtifactTimeSeries, Series: series})
+}
+
+func attach(ctx context.Context, artifact Artifact) error {
+	if artifact.Name == "" {
+		return errors.New("artifact name is required")
+	}
+
+	sink, ok := ctx.Value(_artifactSinkKey).(artifactSink)
+	if !ok {
+		return errors.Errorf("cannot attach artifact (%s), state is not run by a sequencer", artifact.Name)
+	}
+
+	artifact.AttachedAt = time.Now()
+
+	err := sink(artifact)
+	if err != nil {
+		return errors.Wrapf(err, "attach artifact (%s)", artifact.Name)
+	}
+
+	return nil
+}
+
+func withArtifactSink(ctx context.Context, sink artifactSink) context.Context {
+	return context.WithValue(ctx, _artifactSinkKey, sink)
+}
@@ -0,0 +1,100 @@
+// Code generated by "enumer -type=ArtifactKind -trimprefix=Artifact -json artifact.go"; DO NOT EDIT.
+
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _ArtifactKindName = "FileBytesTimeSeries"
+
+var _ArtifactKindIndex = [...]uint8{0, 4, 9, 19}
+
+const _ArtifactKindLowerName = "filebytestimeseries"
+
+func (i ArtifactKind) String() string {
+	if i < 0 || i >= ArtifactKind(len(_ArtifactKindIndex)-1) {
+		return fmt.Sprintf("ArtifactKind(%d)", i)
+	}
+	return _ArtifactKindName[_ArtifactKindIndex[i]:_ArtifactKindIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _ArtifactKindNoOp() {
+	var x [1]struct{}
+	_ = x[ArtifactFile-(0)]
+	_ = x[ArtifactBytes-(1)]
+	_ = x[ArtifactTimeSeries-(2)]
+}
+
+var _ArtifactKindValues = []ArtifactKind{ArtifactFile, ArtifactBytes, ArtifactTimeSeries}
+
+var _ArtifactKindNameToValueMap = map[string]ArtifactKind{
+	_ArtifactKindName[0:4]:       ArtifactFile,
+	_ArtifactKindLowerName[0:4]:  ArtifactFile,
+	_ArtifactKindName[4:9]:       ArtifactBytes,
+	_ArtifactKindLowerName[4:9]:  ArtifactBytes,
+	_ArtifactKindName[9:19]:      ArtifactTimeSeries,
+	_ArtifactKindLowerName[9:19]: ArtifactTimeSeries,
+}
+
+var _ArtifactKindNames = []string{
+	_ArtifactKindName[0:4],
+	_ArtifactKindName[4:9],
+	_ArtifactKindName[9:19],
+}
+
+// ArtifactKindString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func ArtifactKindString(s string) (ArtifactKind, error) {
+	if val, ok := _ArtifactKindNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _ArtifactKindNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to ArtifactKind values", s)
+}
+
+// ArtifactKindValues returns all values of the enum
+func ArtifactKindValues() []ArtifactKind {
+	return _ArtifactKindValues
+}
+
+// ArtifactKindStrings returns a slice of all String values of the enum
+func ArtifactKindStrings() []string {
+	strs := make([]string, len(_ArtifactKindNames))
+	copy(strs, _ArtifactKindNames)
+	return strs
+}
+
+// IsAArtifactKind returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i ArtifactKind) IsAArtifactKind() bool {
+	for _, v := range _ArtifactKindValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for ArtifactKind
+func (i ArtifactKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for ArtifactKind
+func (i *ArtifactKind) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("ArtifactKind should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = ArtifactKindString(s)
+	return err
+}
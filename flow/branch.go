@@ -0,0 +1,162 @@
+package flow
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BranchCondition chooses the key of the branch to run from the results of the prior states in the sequence.
+// Returning an empty key skips the branch entirely.
+type BranchCondition func(priorResults map[Tag]any) (string, error)
+
+// Branch is a composite State that runs one of its child states, chosen when the Branch is set up.
+type Branch struct {
+	name      string
+	condition BranchCondition
+	branches  map[string]State
+	opts      compositeOptions
+
+	chosen State
+}
+
+// NewBranch returns a Branch state that runs the child state whose key is returned by the condition.
+func NewBranch(name string, condition BranchCondition, branches map[string]State, opts ...CompositeOption) *Branch {
+	return &Branch{
+		name:      name,
+		condition: condition,
+		branches:  branches,
+		opts:      newCompositeOptions(opts...),
+	}
+}
+
+// Name of the branch state.
+func (b *Branch) Name() string {
+	return b.name
+}
+
+// Chosen returns the child state chosen during Setup, it is nil if no child was chosen.
+func (b *Branch) Chosen() State {
+	return b.chosen
+}
+
+// Setup chooses the child state from the prior results and sets it up.
+func (b *Branch) Setup(ctx context.Context) error {
+	b.chosen = nil
+
+	key, err := b.condition(PriorResults(ctx))
+	if err != nil {
+		return errors.Wrap(err, "branch condition")
+	}
+
+	if key == "" {
+		reportChildProgress(ctx, []ChildProgress{})
+		return nil
+	}
+
+	chosen, ok := b.branches[key]
+	if !ok {
+		return errors.Errorf("no branch for key (%s)", key)
+	}
+
+	b.chosen = chosen
+
+	reportChildProgress(ctx, []ChildProgress{{Name: chosen.Name(), Running: true}})
+
+	return b.chosen.Setup(ctx)
+}
+
+// Run runs the chosen child state.
+func (b *Branch) Run(ctx context.Context) error {
+	if b.chosen == nil {
+		return nil
+	}
+
+	startTime := time.Now()
+
+	err := b.chosen.Run(ctx)
+
+	reportChildProgress(ctx, []ChildProgress{{
+		Name:     b.chosen.Name(),
+		Passed:   err == nil && b.chosen.FatalError() == nil,
+		Duration: time.Since(startTime),
+	}})
+
+	return err
+}
+
+// GetResults returns the results of the chosen child state.
+func (b *Branch) GetResults() map[Tag]any {
+	if b.chosen == nil {
+		return map[Tag]any{}
+	}
+
+	return b.chosen.GetResults()
+}
+
+// ResultsByPath returns the results of the chosen child state by the path of the state that produced them.
+func (b *Branch) ResultsByPath() map[string]map[Tag]any {
+	if b.chosen == nil {
+		return map[string]map[Tag]any{}
+	}
+
+	return resultsByPath(b.chosen)
+}
+
+// Declaration combines the declarations of every possible child state, since any of them can be chosen.
+func (b *Branch) Declaration() Declaration {
+	keys := make([]string, 0, len(b.branches))
+	for key := range b.branches {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	children := make([]State, 0, len(keys))
+	for _, key := range keys {
+		children = append(children, b.branches[key])
+	}
+
+	return mergeDeclarations(children...)
+}
+
+// ContinueOnFail is the value of the chosen child state, unless overridden.
+func (b *Branch) ContinueOnFail() bool {
+	if b.opts.continueOnFail != nil {
+		return *b.opts.continueOnFail
+	}
+
+	if b.chosen == nil {
+		return true
+	}
+
+	return b.chosen.ContinueOnFail()
+}
+
+// Timeout is the longest timeout of all the possible child states, unless overridden.
+func (b *Branch) Timeout() time.Duration {
+	if b.opts.timeout != nil {
+		return *b.opts.timeout
+	}
+
+	var longest time.Duration
+
+	for _, child := range b.branches {
+		if child.Timeout() > longest {
+			longest = child.Timeout()
+		}
+	}
+
+	return longest
+}
+
+// FatalError returns the fatal error of the chosen child state.
+func (b *Branch) FatalError() error {
+	if b.chosen == nil {
+		return nil
+	}
+
+	return b.chosen.FatalError()
+}
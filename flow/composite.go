@@ -0,0 +1,158 @@
+package flow
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	_pathSeparator = "/"
+)
+
+type compositeOptions struct {
+	timeout        *time.Duration
+	continueOnFail *bool
+}
+
+// CompositeOption configures a composite state such as Parallel or Branch.
+type CompositeOption func(*compositeOptions)
+
+// WithCompositeTimeout overrides the timeout derived from the child states.
+func WithCompositeTimeout(timeout time.Duration) CompositeOption {
+	return func(o *compositeOptions) {
+		o.timeout = &timeout
+	}
+}
+
+// WithCompositeContinueOnFail overrides the continue on fail value derived from the child states.
+func WithCompositeContinueOnFail(continueOnFail bool) CompositeOption {
+	return func(o *compositeOptions) {
+		o.continueOnFail = &continueOnFail
+	}
+}
+
+func newCompositeOptions(opts ...CompositeOption) compositeOptions {
+	ret := compositeOptions{}
+
+	for _, o := range opts {
+		o(&ret)
+	}
+
+	return ret
+}
+
+// childOutcome is the outcome of running a single child state.
+type childOutcome struct {
+	err      error
+	fatalErr error
+	duration time.Duration
+}
+
+func (c childOutcome) passed() bool {
+	return c.err == nil && c.fatalErr == nil
+}
+
+// runChild sets up and runs a child state, giving each phase its own timeout the same way the Sequencer does.
+// Run is skipped if Setup fails. Panics and hung phases are fatal errors.
+func runChild(ctx context.Context, child State) childOutcome {
+	var (
+		outcome   childOutcome
+		startTime = time.Now()
+	)
+
+	ctx = withChildPath(ctx, child)
+
+	setupCtx, cancelSetup := context.WithTimeout(ctx, child.Timeout())
+	defer cancelSetup()
+
+	err := supervise(setupCtx, child, _setupPhase, child.Setup)
+	if IsSupervisorError(err) {
+		outcome.fatalErr = err
+		outcome.duration = time.Since(startTime)
+
+		return outcome
+	} else if err != nil {
+		outcome.err = errors.Wrapf(err, "setup (%s)", child.Name())
+	}
+
+	err = child.FatalError()
+	if err != nil {
+		outcome.fatalErr = errors.Wrapf(err, "fatal error during setup (%s)", child.Name())
+	}
+
+	if !outcome.passed() {
+		outcome.duration = time.Since(startTime)
+
+		return outcome
+	}
+
+	runCtx, cancelRun := context.WithTimeout(ctx, child.Timeout())
+	defer cancelRun()
+
+	err = supervise(runCtx, child, _runPhase, child.Run)
+	if IsSupervisorError(err) {
+		outcome.fatalErr = err
+		outcome.duration = time.Since(startTime)
+
+		return outcome
+	} else if err != nil {
+		outcome.err = errors.Wrapf(err, "run (%s)", child.Name())
+	}
+
+	outcome.duration = time.Since(startTime)
+
+	err = child.FatalError()
+	if err != nil {
+		outcome.fatalErr = errors.Wrapf(err, "fatal error during run (%s)", child.Name())
+	}
+
+	return outcome
+}
+
+// ResultPather can optionally be implemented by a composite State to report which of its nested states produced
+// each of its results. Unlike GetResults, a tag produced by more than one nested state keeps every value.
+type ResultPather interface {
+	// ResultsByPath maps the path of each nested state, relative to the composite state, to the results it produced.
+	// Path elements are separated by "/", an empty path is the composite state itself.
+	ResultsByPath() map[string]map[Tag]any
+}
+
+// resultsByPath returns the results of the state by the path of the state that produced them, starting with the
+// name of the given state.
+func resultsByPath(state State) map[string]map[Tag]any {
+	pather, ok := state.(ResultPather)
+	if !ok {
+		return map[string]map[Tag]any{state.Name(): state.GetResults()}
+	}
+
+	ret := map[string]map[Tag]any{}
+
+	for path, results := range pather.ResultsByPath() {
+		if path == "" {
+			ret[state.Name()] = results
+			continue
+		}
+
+		ret[joinPath(state.Name(), path)] = results
+	}
+
+	return ret
+}
+
+// withChildPath returns a copy of ctx carrying the path of the child, nested under the state path of ctx.
+func withChildPath(ctx context.Context, child State) context.Context {
+	path := StatePath(ctx)
+	if path == "" {
+		return WithStatePath(ctx, child.Name())
+	}
+
+	return WithStatePath(ctx, joinPath(path, child.Name()))
+}
+
+// joinPath joins the elements of a state path.
+func joinPath(elems ...string) string {
+	return strings.Join(elems, _pathSeparator)
+}
@@ -0,0 +1,183 @@
+package flow
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey int
+
+const (
+	_priorResultsKey contextKey = iota
+	_progressReporterKey
+	_iterationParamsKey
+	_artifactSinkKey
+	_testIdKey
+	_statePathKey
+	_sampleSinkKey
+	_hangGracePeriodKey
+	_metadataKey
+	_testStartedAtKey
+)
+
+// progressReporter is called by composite states whenever the progress of their children changes.
+type progressReporter = func(children []ChildProgress)
+
+// pathResults holds results by tag and by the path of the state that produced them. A tag produced by more than one
+// state, for example by a group of states that is reused, keeps its most recent value in byTag and every value in
+// byPath.
+type pathResults struct {
+	byTag  map[Tag]any
+	byPath map[string]map[Tag]any
+}
+
+func newPathResults() *pathResults {
+	return &pathResults{
+		byTag:  map[Tag]any{},
+		byPath: map[string]map[Tag]any{},
+	}
+}
+
+// add records the result produced by the state at path.
+func (p *pathResults) add(path string, tag Tag, value any) {
+	p.byTag[tag] = value
+
+	results, ok := p.byPath[path]
+	if !ok {
+		results = map[Tag]any{}
+		p.byPath[path] = results
+	}
+
+	results[tag] = value
+}
+
+// addState adds the results of state by the path of the nested state that produced them, nested under prefix if it
+// is not empty. The most recent value of each tag comes from GetResults, since the order of the paths is not kept.
+func (p *pathResults) addState(prefix string, state State) {
+	for path, results := range resultsByPath(state) {
+		if prefix != "" {
+			path = joinPath(prefix, path)
+		}
+
+		for tag, value := range results {
+			p.add(path, tag, value)
+		}
+	}
+
+	for tag, value := range state.GetResults() {
+		p.byTag[tag] = value
+	}
+}
+
+// merge adds the results of other, its values replace the values of p.
+func (p *pathResults) merge(other *pathResults) {
+	for path, results := range other.byPath {
+		for tag, value := range results {
+			p.add(path, tag, value)
+		}
+	}
+
+	// byTag is copied last, since iterating byPath does not preserve which value is the most recent.
+	for tag, value := range other.byTag {
+		p.byTag[tag] = value
+	}
+}
+
+// PriorResults returns the results of the states that have already run in the current sequence. A tag produced by
+// more than one state has its most recent value, see PriorResultsAt for the others.
+func PriorResults(ctx context.Context) map[Tag]any {
+	return priorResultsFrom(ctx).byTag
+}
+
+// PriorResultsAt returns the results produced by the state at path, for example "lv_sequence/lv_startup/poll_tsal",
+// if it has already run in the current sequence.
+func PriorResultsAt(ctx context.Context, path string) map[Tag]any {
+	results, ok := priorResultsFrom(ctx).byPath[path]
+	if !ok {
+		return map[Tag]any{}
+	}
+
+	return results
+}
+
+func priorResultsFrom(ctx context.Context) *pathResults {
+	results, ok := ctx.Value(_priorResultsKey).(*pathResults)
+	if !ok {
+		return newPathResults()
+	}
+
+	return results
+}
+
+// withPriorResults returns a copy of ctx carrying a snapshot of results, so states cannot modify the results of
+// the caller.
+func withPriorResults(ctx context.Context, results *pathResults) context.Context {
+	snapshot := newPathResults()
+	snapshot.merge(results)
+
+	return context.WithValue(ctx, _priorResultsKey, snapshot)
+}
+
+// CurrentTestId returns the TestId of the test being run, or uuid.Nil if the context is not from a Sequencer.
+func CurrentTestId(ctx context.Context) uuid.UUID {
+	testId, ok := ctx.Value(_testIdKey).(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return testId
+}
+
+// WithTestId returns a copy of ctx carrying the TestId of the test being run.
+func WithTestId(ctx context.Context, testId uuid.UUID) context.Context {
+	return context.WithValue(ctx, _testIdKey, testId)
+}
+
+// TestStartedAt returns when the Sequencer started the test being run, or the zero time if the context is not from
+// a Sequencer.
+func TestStartedAt(ctx context.Context) time.Time {
+	startedAt, ok := ctx.Value(_testStartedAtKey).(time.Time)
+	if !ok {
+		return time.Time{}
+	}
+
+	return startedAt
+}
+
+// WithTestStartedAt returns a copy of ctx carrying when the test being run was started.
+func WithTestStartedAt(ctx context.Context, startedAt time.Time) context.Context {
+	return context.WithValue(ctx, _testStartedAtKey, startedAt)
+}
+
+// StatePath returns the path of the running state, or of the state whose results are being submitted, for example
+// "lv_sequence/lv_startup/poll_tsal". It is empty if the context is not from a Sequencer.
+func StatePath(ctx context.Context) string {
+	path, ok := ctx.Value(_statePathKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return path
+}
+
+// WithStatePath returns a copy of ctx carrying the path of the running state, or of the state whose results are
+// being submitted.
+func WithStatePath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, _statePathKey, path)
+}
+
+func withProgressReporter(ctx context.Context, report progressReporter) context.Context {
+	return context.WithValue(ctx, _progressReporterKey, report)
+}
+
+// reportChildProgress forwards the progress of a composite state's children to its parent.
+func reportChildProgress(ctx context.Context, children []ChildProgress) {
+	report, ok := ctx.Value(_progressReporterKey).(progressReporter)
+	if !ok {
+		return
+	}
+
+	report(children)
+}
@@ -0,0 +1,58 @@
+package flow
+
+// Declarer can optionally be implemented by a State to declare the tags it submits and the physical IO it uses, so
+// that sequences can be validated before any test is run.
+type Declarer interface {
+	// Declaration returns what the state needs to run.
+	Declaration() Declaration
+}
+
+// Declaration is what a State needs to run.
+type Declaration struct {
+	// Tags are the tags the state can return from GetResults.
+	Tags []Tag
+	// PhysicalIo are the names of the physical IO the state reads or drives, as named by the pinout of the bench.
+	PhysicalIo []string
+}
+
+// DeclarationOf returns the declaration of the state, false is returned if the state does not implement Declarer.
+func DeclarationOf(state State) (Declaration, bool) {
+	declarer, ok := state.(Declarer)
+	if !ok {
+		return Declaration{}, false
+	}
+
+	return declarer.Declaration(), true
+}
+
+// mergeDeclarations combines the declarations of states, keeping the first occurrence of each tag and physical IO.
+func mergeDeclarations(states ...State) Declaration {
+	var (
+		ret      Declaration
+		seenTags = make(map[string]struct{})
+		seenIo   = make(map[string]struct{})
+	)
+
+	for _, state := range states {
+		declaration, ok := DeclarationOf(state)
+		if !ok {
+			continue
+		}
+
+		for _, tag := range declaration.Tags {
+			if _, ok := seenTags[tag.ID]; !ok {
+				seenTags[tag.ID] = struct{}{}
+				ret.Tags = append(ret.Tags, tag)
+			}
+		}
+
+		for _, io := range declaration.PhysicalIo {
+			if _, ok := seenIo[io]; !ok {
+				seenIo[io] = struct{}{}
+				ret.PhysicalIo = append(ret.PhysicalIo, io)
+			}
+		}
+	}
+
+	return ret
+}
@@ -15,11 +15,39 @@ type ResultProcessorIface interface {
 	// SubmitTag will return the passing status of a given tag.
 	SubmitTag(ctx context.Context, tagId string, value any) (bool, error)
 	// CompleteTest will signal the result processor that a test has been completed. The overall pass/fail is returned.
+	// The metadata of the test is available from ctx through Metadata.
 	CompleteTest(ctx context.Context, testId uuid.UUID, sequenceName string) (bool, error)
 	// SubmitError will be stored by the result processor and should make the sequence an overall fail.
 	SubmitError(ctx context.Context, err error) error
 }
 
+// AttemptProcessorIface can optionally be implemented by a ResultProcessorIface to receive the failed attempts of
+// states that were retried. These are diagnostic only and do not affect the overall pass/fail.
+type AttemptProcessorIface interface {
+	// SubmitAttempts will be called with the failed attempts of a state before its results are submitted.
+	SubmitAttempts(ctx context.Context, stateName string, attempts []Attempt) error
+}
+
+// TeardownProcessorIface can optionally be implemented by a ResultProcessorIface to receive errors from teardown
+// states. These are reported separately from test errors and do not affect the overall pass/fail.
+type TeardownProcessorIface interface {
+	// SubmitTeardownError will be called for every error returned by a teardown state.
+	SubmitTeardownError(ctx context.Context, err error) error
+}
+
+// ReportProcessorIface can optionally be implemented by a ResultProcessorIface that writes reports to disk.
+type ReportProcessorIface interface {
+	// ReportPaths returns the paths of the reports generated by the last call to CompleteTest.
+	ReportPaths() []string
+}
+
+// ArtifactProcessorIface can optionally be implemented by a ResultProcessorIface to receive the artifacts states
+// attach with AttachFile, AttachBytes and AttachTimeSeries. Artifacts are dropped if it is not implemented.
+type ArtifactProcessorIface interface {
+	// SubmitArtifact will be called for every artifact as soon as it is attached.
+	SubmitArtifact(ctx context.Context, artifact Artifact) error
+}
+
 // State is a set of logic that gets executed as a part of a Sequence.
 type State interface {
 	// Name of the state, should be in lower_snake_case.
@@ -0,0 +1,15 @@
+package flow
+
+//go:generate enumer -type=JoinPolicy joinpolicy.go
+
+// JoinPolicy determines when a Parallel state completes and whether it passes.
+type JoinPolicy int
+
+const (
+	// JoinAll waits for every child state to complete. Passes only if all children pass.
+	JoinAll JoinPolicy = iota
+	// JoinAny completes as soon as one child state passes, canceling the others. Passes if any child passes.
+	JoinAny
+	// JoinFirstFail completes as soon as one child state fails, canceling the others. Passes only if all children pass.
+	JoinFirstFail
+)
@@ -0,0 +1,82 @@
+// Code generated by "enumer -type=JoinPolicy joinpolicy.go"; DO NOT EDIT.
+
+package flow
+
+import (
+	"fmt"
+	"strings"
+)
+
+const _JoinPolicyName = "JoinAllJoinAnyJoinFirstFail"
+
+var _JoinPolicyIndex = [...]uint8{0, 7, 14, 27}
+
+const _JoinPolicyLowerName = "joinalljoinanyjoinfirstfail"
+
+func (i JoinPolicy) String() string {
+	if i < 0 || i >= JoinPolicy(len(_JoinPolicyIndex)-1) {
+		return fmt.Sprintf("JoinPolicy(%d)", i)
+	}
+	return _JoinPolicyName[_JoinPolicyIndex[i]:_JoinPolicyIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _JoinPolicyNoOp() {
+	var x [1]struct{}
+	_ = x[JoinAll-(0)]
+	_ = x[JoinAny-(1)]
+	_ = x[JoinFirstFail-(2)]
+}
+
+var _JoinPolicyValues = []JoinPolicy{JoinAll, JoinAny, JoinFirstFail}
+
+var _JoinPolicyNameToValueMap = map[string]JoinPolicy{
+	_JoinPolicyName[0:7]:        JoinAll,
+	_JoinPolicyLowerName[0:7]:   JoinAll,
+	_JoinPolicyName[7:14]:       JoinAny,
+	_JoinPolicyLowerName[7:14]:  JoinAny,
+	_JoinPolicyName[14:27]:      JoinFirstFail,
+	_JoinPolicyLowerName[14:27]: JoinFirstFail,
+}
+
+var _JoinPolicyNames = []string{
+	_JoinPolicyName[0:7],
+	_JoinPolicyName[7:14],
+	_JoinPolicyName[14:27],
+}
+
+// JoinPolicyString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func JoinPolicyString(s string) (JoinPolicy, error) {
+	if val, ok := _JoinPolicyNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _JoinPolicyNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to JoinPolicy values", s)
+}
+
+// JoinPolicyValues returns all values of the enum
+func JoinPolicyValues() []JoinPolicy {
+	return _JoinPolicyValues
+}
+
+// JoinPolicyStrings returns a slice of all String values of the enum
+func JoinPolicyStrings() []string {
+	strs := make([]string, len(_JoinPolicyNames))
+	copy(strs, _JoinPolicyNames)
+	return strs
+}
+
+// IsAJoinPolicy returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i JoinPolicy) IsAJoinPolicy() bool {
+	for _, v := range _JoinPolicyValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package flow
+
+import (
+	"context"
+	"time"
+)
+
+// Sample is a single live measurement published by a state while it runs, such as a pack voltage or a pin level.
+// Samples are for display only, they are not submitted to the result processor.
+type Sample struct {
+	// Name of the measurement, samples with the same name form a series.
+	Name string
+	// Value of the measurement. Boolean samples are 1 for true and 0 for false.
+	Value float64
+	// Unit of the value, for example "V". It is only used for display.
+	Unit string
+	// IsBool indicates the sample was published with PublishBool.
+	IsBool bool
+	// StateName is the top-level state that published the sample. It is filled in by the Sequencer.
+	StateName string
+	// Time is when the sample was published.
+	Time time.Time
+}
+
+// sampleSink is called with every sample a state publishes. It must not block.
+type sampleSink = func(sample Sample)
+
+// PublishMeasurement publishes a numeric sample to anyone watching the running test. Samples are dropped if the
+// state is not run by a Sequencer or if the watchers are falling behind, so states never wait on them.
+func PublishMeasurement(ctx context.Context, name string, value float64, unit string) {
+	publish(ctx, Sample{Name: name, Value: value, Unit: unit})
+}
+
+// PublishBool publishes a boolean sample, such as a pin level, to anyone watching the running test.
+// See PublishMeasurement.
+func PublishBool(ctx context.Context, name string, value bool) {
+	sample := Sample{Name: name, IsBool: true}
+	if value {
+		sample.Value = 1
+	}
+
+	publish(ctx, sample)
+}
+
+func publish(ctx context.Context, sample Sample) {
+	sink, ok := ctx.Value(_sampleSinkKey).(sampleSink)
+	if !ok {
+		return
+	}
+
+	sample.Time = time.Now()
+
+	sink(sample)
+}
+
+func withSampleSink(ctx context.Context, sink sampleSink) context.Context {
+	return context.WithValue(ctx, _sampleSinkKey, sink)
+}
@@ -0,0 +1,60 @@
+package flow
+
+import (
+	"context"
+	"sort"
+)
+
+// Metadata returns the metadata of the current test, such as the firmware commit, operator or CI job URL.
+// It is empty if the test was started without metadata.
+func Metadata(ctx context.Context) map[string]string {
+	metadata, ok := ctx.Value(_metadataKey).(map[string]string)
+	if !ok {
+		return map[string]string{}
+	}
+
+	ret := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		ret[key] = value
+	}
+
+	return ret
+}
+
+// MetadataValue returns the value of a single metadata key of the current test.
+func MetadataValue(ctx context.Context, key string) (string, bool) {
+	metadata, ok := ctx.Value(_metadataKey).(map[string]string)
+	if !ok {
+		return "", false
+	}
+
+	value, ok := metadata[key]
+
+	return value, ok
+}
+
+// WithMetadata returns a context holding the metadata of the test. The Sequencer passes it to every state and to
+// the result processor.
+func WithMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	snapshot := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		snapshot[key] = value
+	}
+
+	return context.WithValue(ctx, _metadataKey, snapshot)
+}
+
+// MissingMetadata returns the required metadata keys of the sequence that are not set, or are empty, in metadata.
+func (s Sequence) MissingMetadata(metadata map[string]string) []string {
+	missing := make([]string, 0)
+
+	for _, key := range s.RequiredMetadata {
+		if metadata[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
@@ -0,0 +1,242 @@
+package flow
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/macformula/hil/utils"
+	"github.com/pkg/errors"
+)
+
+// Parallel is a composite State that runs its child states concurrently.
+// A child passes if it completes Setup and Run without errors, tag results are still checked by the result processor.
+type Parallel struct {
+	name     string
+	children []State
+	policy   JoinPolicy
+	opts     compositeOptions
+
+	mtx      sync.Mutex
+	progress []ChildProgress
+	results  *pathResults
+	fatalErr *utils.ResettableError
+}
+
+// NewParallel returns a Parallel state that runs children concurrently and joins them with the given policy.
+func NewParallel(name string, policy JoinPolicy, children []State, opts ...CompositeOption) *Parallel {
+	return &Parallel{
+		name:     name,
+		children: children,
+		policy:   policy,
+		opts:     newCompositeOptions(opts...),
+		results:  newPathResults(),
+		fatalErr: utils.NewResettaleError(),
+	}
+}
+
+// Name of the parallel state.
+func (p *Parallel) Name() string {
+	return p.name
+}
+
+// Children returns the child states.
+func (p *Parallel) Children() []State {
+	return p.children
+}
+
+// Setup resets the state from any previous run. Children are set up concurrently in Run.
+func (p *Parallel) Setup(_ context.Context) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.results = newPathResults()
+	p.fatalErr.Reset()
+
+	p.progress = make([]ChildProgress, len(p.children))
+	for i, child := range p.children {
+		p.progress[i] = ChildProgress{Name: child.Name()}
+	}
+
+	return nil
+}
+
+// Run sets up and runs all children concurrently, completing according to the join policy.
+func (p *Parallel) Run(ctx context.Context) error {
+	if len(p.children) == 0 {
+		return errors.Errorf("parallel state (%s) has no children", p.name)
+	}
+
+	var (
+		joinCtx, cancelJoin = context.WithCancel(ctx)
+		outcomes            = make([]childOutcome, len(p.children))
+		completed           = make([]bool, len(p.children))
+		done                = make(chan int, len(p.children))
+		wg                  sync.WaitGroup
+	)
+
+	defer cancelJoin()
+
+	for i, child := range p.children {
+		p.updateProgress(ctx, i, ChildProgress{Name: child.Name(), Running: true})
+
+		wg.Add(1)
+
+		go func(i int, child State) {
+			defer wg.Done()
+
+			childCtx := withProgressReporter(joinCtx, func(children []ChildProgress) {
+				p.mtx.Lock()
+				p.progress[i].Children = children
+				p.mtx.Unlock()
+
+				reportChildProgress(ctx, p.childProgress())
+			})
+
+			outcomes[i] = runChild(childCtx, child)
+			done <- i
+		}(i, child)
+	}
+
+	for range p.children {
+		i := <-done
+		completed[i] = joinCtx.Err() == nil
+
+		p.updateProgress(ctx, i, ChildProgress{
+			Name:     p.children[i].Name(),
+			Passed:   outcomes[i].passed(),
+			Duration: outcomes[i].duration,
+		})
+
+		if p.policy == JoinAny && outcomes[i].passed() {
+			cancelJoin()
+		}
+
+		if p.policy == JoinFirstFail && !outcomes[i].passed() {
+			cancelJoin()
+		}
+	}
+
+	wg.Wait()
+
+	return p.join(outcomes, completed)
+}
+
+// GetResults returns the merged results of every child that completed before the join, except children abandoned
+// as hung.
+func (p *Parallel) GetResults() map[Tag]any {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.results.byTag
+}
+
+// ResultsByPath returns the results by the path of the child that produced them.
+func (p *Parallel) ResultsByPath() map[string]map[Tag]any {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.results.byPath
+}
+
+// Declaration combines the declarations of the children.
+func (p *Parallel) Declaration() Declaration {
+	return mergeDeclarations(p.children...)
+}
+
+// ContinueOnFail is true only if every child continues on fail, unless overridden.
+func (p *Parallel) ContinueOnFail() bool {
+	if p.opts.continueOnFail != nil {
+		return *p.opts.continueOnFail
+	}
+
+	for _, child := range p.children {
+		if !child.ContinueOnFail() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Timeout allows the slowest child to use its full timeout for both Setup and Run, unless overridden.
+func (p *Parallel) Timeout() time.Duration {
+	if p.opts.timeout != nil {
+		return *p.opts.timeout
+	}
+
+	var longest time.Duration
+
+	for _, child := range p.children {
+		if child.Timeout() > longest {
+			longest = child.Timeout()
+		}
+	}
+
+	return 2 * longest
+}
+
+// FatalError returns the first fatal error encountered by any child.
+func (p *Parallel) FatalError() error {
+	return p.fatalErr.Err()
+}
+
+func (p *Parallel) join(outcomes []childOutcome, completed []bool) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var (
+		anyPassed bool
+		childErrs = make([]string, 0)
+	)
+
+	for i, outcome := range outcomes {
+		p.fatalErr.Set(outcome.fatalErr)
+
+		if !completed[i] {
+			continue
+		}
+
+		// A child abandoned as hung may still be writing its results, so they cannot be read safely.
+		if !isHungError(outcome.fatalErr) {
+			p.results.addState("", p.children[i])
+		}
+
+		if outcome.passed() {
+			anyPassed = true
+			continue
+		}
+
+		if outcome.err != nil {
+			childErrs = append(childErrs, outcome.err.Error())
+		}
+	}
+
+	switch {
+	case p.policy == JoinAny && anyPassed:
+		return nil
+	case p.policy == JoinAny:
+		return errors.Errorf("no children passed (%s)", strings.Join(childErrs, "; "))
+	case len(childErrs) > 0:
+		return errors.Errorf("children failed (%s)", strings.Join(childErrs, "; "))
+	default:
+		return nil
+	}
+}
+
+func (p *Parallel) updateProgress(ctx context.Context, i int, progress ChildProgress) {
+	p.mtx.Lock()
+	progress.Children = p.progress[i].Children
+	p.progress[i] = progress
+	p.mtx.Unlock()
+
+	reportChildProgress(ctx, p.childProgress())
+}
+
+func (p *Parallel) childProgress() []ChildProgress {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return copyChildProgress(p.progress)
+}
@@ -0,0 +1,44 @@
+package flow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWritingHungState returns a state that ignores ctx and keeps writing its results until release is closed.
+func newWritingHungState(name string, release chan struct{}) *stubState {
+	state := &stubState{name: name, timeout: _testGracePeriod, results: map[Tag]any{}}
+
+	state.run = func(context.Context) error {
+		for i := 0; ; i++ {
+			select {
+			case <-release:
+				return nil
+			default:
+				state.results[Tag{ID: name}] = i
+			}
+		}
+	}
+
+	return state
+}
+
+func TestParallelSkipsResultsOfHungChild(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	parallel := NewParallel("parallel", JoinAll,
+		[]State{newWritingHungState("hung", release), &stubState{name: "ok"}})
+
+	ctx := withHangGracePeriod(context.Background(), _testGracePeriod)
+
+	require.NoError(t, parallel.Setup(ctx))
+	require.NoError(t, parallel.Run(ctx))
+
+	var hungErr *HungError
+	assert.ErrorAs(t, parallel.FatalError(), &hungErr)
+	assert.Equal(t, map[Tag]any{{ID: "ok"}: true}, parallel.GetResults())
+}
@@ -0,0 +1,86 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Params are the parameters of a single iteration of a parameterized Sequence.
+type Params map[string]any
+
+// String returns the params as comma separated key=value pairs sorted by key, for example "pedal=50,rev=sil".
+func (p Params) String() string {
+	pairs := make([]string, 0, len(p))
+
+	for _, key := range sortedKeys(p) {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, p[key]))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// IterationParams returns the params of the current iteration of a parameterized Sequence.
+// It returns nil if the sequence is not parameterized.
+func IterationParams(ctx context.Context) Params {
+	params, ok := ctx.Value(_iterationParamsKey).(Params)
+	if !ok {
+		return nil
+	}
+
+	return params
+}
+
+// WithIterationParams returns a copy of ctx carrying the params of an iteration.
+func WithIterationParams(ctx context.Context, params Params) context.Context {
+	return context.WithValue(ctx, _iterationParamsKey, params)
+}
+
+// iterations expands the matrix into the params of every iteration, in a stable order.
+// A sequence without a matrix has a single iteration with nil params.
+func iterations(matrix map[string][]any) ([]Params, error) {
+	if len(matrix) == 0 {
+		return []Params{nil}, nil
+	}
+
+	ret := []Params{{}}
+
+	for _, key := range sortedKeys(matrix) {
+		values := matrix[key]
+		if len(values) == 0 {
+			return nil, errors.Errorf("matrix parameter (%s) has no values", key)
+		}
+
+		expanded := make([]Params, 0, len(ret)*len(values))
+
+		for _, params := range ret {
+			for _, value := range values {
+				next := make(Params, len(params)+1)
+				for k, v := range params {
+					next[k] = v
+				}
+
+				next[key] = value
+				expanded = append(expanded, next)
+			}
+		}
+
+		ret = expanded
+	}
+
+	return ret, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,115 @@
+package flow
+
+import (
+	"context"
+	"sync"
+)
+
+// pauser halts a sequence between states. It is paused by Pause, by a single Step completing or by reaching a
+// state with a breakpoint.
+type pauser struct {
+	mtx         sync.Mutex
+	paused      bool
+	stepping    bool
+	breakpoints map[string]struct{}
+	// wake is signaled when the pauser is resumed or stepped. It is buffered so signaling never blocks.
+	wake chan struct{}
+}
+
+func newPauser() *pauser {
+	return &pauser{
+		breakpoints: make(map[string]struct{}),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// clear drops any pending pause or step.
+func (p *pauser) clear() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.paused = false
+	p.stepping = false
+}
+
+func (p *pauser) setBreakpoints(breakpoints []string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.breakpoints = make(map[string]struct{}, len(breakpoints))
+
+	for _, name := range breakpoints {
+		p.breakpoints[name] = struct{}{}
+	}
+}
+
+func (p *pauser) pause() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.paused = true
+}
+
+func (p *pauser) resume() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.paused = false
+	p.stepping = false
+	p.signal()
+}
+
+func (p *pauser) step() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.paused = false
+	p.stepping = true
+	p.signal()
+}
+
+// signal wakes a waiting sequence. The caller must hold mtx.
+func (p *pauser) signal() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// shouldPause returns true if the sequence must halt before running the state. It also ends a pending step.
+func (p *pauser) shouldPause(state State) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.stepping {
+		p.stepping = false
+		p.paused = true
+	}
+
+	if _, ok := p.breakpoints[state.Name()]; ok {
+		p.paused = true
+	}
+
+	return p.paused
+}
+
+// wait blocks until the pauser is resumed or stepped. It returns false if the test was canceled while waiting.
+func (p *pauser) wait(ctx context.Context, cancelTest chan struct{}) bool {
+	for {
+		p.mtx.Lock()
+		paused := p.paused
+		p.mtx.Unlock()
+
+		if !paused {
+			return true
+		}
+
+		select {
+		case <-p.wake:
+		case <-cancelTest:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -10,8 +10,78 @@ type Progress struct {
 	StateIndex int
 	// Sequence is the currently running sequence.
 	Sequence Sequence
-	// StatePassed indicates if the state at the given index has passed or failed.
+	// Iteration is the index of the current iteration of a parameterized sequence.
+	Iteration int
+	// Iterations is the total number of iterations of the sequence. It is 1 if the sequence is not parameterized.
+	Iterations int
+	// Params are the params of the current iteration. They are nil if the sequence is not parameterized.
+	Params Params
+	// StatePassed indicates if the state at the given index has passed or failed in the current iteration.
 	StatePassed []bool
 	// StateDuration indicates the duration for which the state at the given index ran for.
 	StateDuration []time.Duration
+	// StateAttempts are the failed attempts of the state at the given index if it was retried.
+	StateAttempts [][]Attempt
+	// Children is the progress of the child states if CurrentState is a composite state such as Parallel or Branch.
+	Children []ChildProgress
+	// Paused indicates the sequence is halted before CurrentState has been set up.
+	Paused bool
+}
+
+// ChildProgress represents the progress of a child state within a composite state.
+type ChildProgress struct {
+	// Name of the child state.
+	Name string
+	// Running indicates the child state has not completed yet.
+	Running bool
+	// Passed indicates if the child state passed. It is only valid once Running is false.
+	Passed bool
+	// Duration is the time the child state ran for. It is only valid once Running is false.
+	Duration time.Duration
+	// Children is the progress of the child's own children if it is also a composite state.
+	Children []ChildProgress
+}
+
+// copyChildProgress deep copies children so that the copy can be safely sent to subscribers.
+func copyChildProgress(children []ChildProgress) []ChildProgress {
+	if children == nil {
+		return nil
+	}
+
+	ret := make([]ChildProgress, len(children))
+	for i, child := range children {
+		ret[i] = child
+		ret[i].Children = copyChildProgress(child.Children)
+	}
+
+	return ret
+}
+
+// CurrentPath returns the path of the state that is currently running, starting with the sequence name, for example
+// "lv_sequence/lv_startup/poll_tsal". Only the first running child of a composite state is included in the path.
+// It is empty if no state is running.
+func (p Progress) CurrentPath() string {
+	if p.CurrentState == nil {
+		return ""
+	}
+
+	elems := []string{p.Sequence.Name, p.CurrentState.Name()}
+
+	running, ok := runningChild(p.Children)
+	for ok {
+		elems = append(elems, running.Name)
+		running, ok = runningChild(running.Children)
+	}
+
+	return joinPath(elems...)
+}
+
+func runningChild(children []ChildProgress) (ChildProgress, bool) {
+	for _, child := range children {
+		if child.Running {
+			return child, true
+		}
+	}
+
+	return ChildProgress{}, false
 }
@@ -0,0 +1,90 @@
+package flow
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// RecoveryAction is an automated step of a RecoveryProcedure, for example power cycling the test bench.
+type RecoveryAction struct {
+	Name string
+	Do   func(ctx context.Context) error
+}
+
+// RecoveryProcedure describes how to bring the bench back from a fatal error.
+type RecoveryProcedure struct {
+	// Checklist is shown to the operator, in order, before they recover from the fatal error.
+	Checklist []string
+	// Actions are run in order on recover from fatal, stopping at the first action that fails.
+	Actions []RecoveryAction
+	// Verify checks the bench is healthy once every action has run. It is optional.
+	Verify func(ctx context.Context) error
+}
+
+// ActionNames returns the names of the actions in the order they are run.
+func (p RecoveryProcedure) ActionNames() []string {
+	ret := make([]string, len(p.Actions))
+
+	for i, action := range p.Actions {
+		ret[i] = action.Name
+	}
+
+	return ret
+}
+
+// Run runs every action, then verifies the bench.
+func (p RecoveryProcedure) Run(ctx context.Context) error {
+	for _, action := range p.Actions {
+		err := action.Do(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "recovery action (%s)", action.Name)
+		}
+	}
+
+	if p.Verify != nil {
+		err := p.Verify(ctx)
+		if err != nil {
+			return errors.Wrap(err, "verify recovery")
+		}
+	}
+
+	return nil
+}
+
+// RecoverableError is a fatal error with the procedure to recover from it.
+type RecoverableError struct {
+	Err       error
+	Procedure RecoveryProcedure
+}
+
+func (e *RecoverableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RecoverableError) Unwrap() error {
+	return e.Err
+}
+
+// WithRecovery attaches a recovery procedure to a fatal error. States return it from FatalError.
+func WithRecovery(err error, procedure RecoveryProcedure) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RecoverableError{
+		Err:       err,
+		Procedure: procedure,
+	}
+}
+
+// Recovery returns the recovery procedure attached to err, or to any error it wraps.
+func Recovery(err error) (RecoveryProcedure, bool) {
+	var recoverableErr *RecoverableError
+
+	if !errors.As(err, &recoverableErr) {
+		return RecoveryProcedure{}, false
+	}
+
+	return recoverableErr.Procedure, true
+}
@@ -0,0 +1,59 @@
+package flow
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// Retrier is an optional interface a State can implement to be retried when Setup or Run fails.
+type Retrier interface {
+	// RetryPolicy returns how the Sequencer should retry the state.
+	RetryPolicy() RetryPolicy
+}
+
+// RetryPolicy describes how many times and how often a State is retried. Fatal errors are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the max number of times Setup and Run will be attempted, values below 1 are treated as 1.
+	MaxAttempts int
+	// Backoff decides how long to wait before each retry. If nil, retries happen immediately.
+	Backoff backoff.BackOff
+	// IsRetryable reports whether an error should be retried. If nil, all regular errors are retried.
+	IsRetryable func(err error) bool
+}
+
+// Attempt is a failed attempt of a State that was retried.
+type Attempt struct {
+	// Number is the attempt number, starting at 1.
+	Number int
+	// Duration is the time the attempt ran for.
+	Duration time.Duration
+	// Err is the error that caused the attempt to fail.
+	Err error
+}
+
+func getRetryPolicy(state State) RetryPolicy {
+	retrier, ok := state.(Retrier)
+	if !ok {
+		return RetryPolicy{MaxAttempts: 1}
+	}
+
+	policy := retrier.RetryPolicy()
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	if policy.Backoff != nil {
+		policy.Backoff.Reset()
+	}
+
+	return policy
+}
+
+func (r RetryPolicy) isRetryable(err error) bool {
+	if r.IsRetryable == nil {
+		return true
+	}
+
+	return r.IsRetryable(err)
+}
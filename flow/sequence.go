@@ -8,4 +8,14 @@ type Sequence struct {
 	Desc string
 	// States are the states to be run in the order provided.
 	States []State
+	// Teardown states always run after States, even if the sequence stopped early due to a failure, fatal error or
+	// cancel. They are run in the order provided and each one runs regardless of whether the previous one failed.
+	Teardown []State
+	// Matrix maps parameter names to the values they sweep through. States are run once for every combination of
+	// values, with the params of the current iteration available through IterationParams. Teardown runs once at the
+	// end. A nil Matrix runs States once.
+	Matrix map[string][]any
+	// RequiredMetadata are the metadata keys, such as "firmware_commit", that must be supplied when the sequence is
+	// started. States can read them with MetadataValue.
+	RequiredMetadata []string
 }
@@ -6,6 +6,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/cenkalti/backoff"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/google/uuid"
 	"github.com/macformula/hil/utils"
@@ -14,6 +15,9 @@ import (
 
 const (
 	_loggerName = "sequencer"
+	// _sampleBufferSize is the number of samples that can be waiting to be sent to subscribers before new samples
+	// are dropped.
+	_sampleBufferSize = 256
 )
 
 // Sequencer is responsible for managing the setup and execution of a Sequence.
@@ -21,31 +25,69 @@ type Sequencer struct {
 	l            *zap.Logger
 	progress     Progress
 	progressFeed event.Feed
+	sampleFeed   event.Feed
+	samples      chan Sample
+	closing      chan struct{}
 
 	fatalErr   *utils.ResettableError
 	regularErr *utils.ResettableError
 
 	rp ResultProcessorIface
 
+	pauser *pauser
+
 	cancelCurrentTest context.CancelFunc
 
 	testCanceled bool
+	// stateHung is true if the current state was abandoned as hung, its goroutine may still be running.
+	stateHung bool
+
+	failedTags      []Tag
+	testErrors      []error
+	priorResults    *pathResults
+	currentAttempts []Attempt
+	teardownErrors  []error
+	reportPaths     []string
 
-	failedTags []Tag
-	testErrors []error
+	hangGracePeriod time.Duration
+}
+
+// SequencerOption configures a Sequencer.
+type SequencerOption func(*Sequencer)
+
+// WithHangGracePeriod sets how long a state has to return after its timeout or cancellation before it is abandoned
+// as hung, which is a fatal error. Defaults to 5 seconds.
+func WithHangGracePeriod(gracePeriod time.Duration) SequencerOption {
+	return func(s *Sequencer) {
+		s.hangGracePeriod = gracePeriod
+	}
 }
 
 // NewSequencer returns a Sequencer object reference.
-func NewSequencer(rp ResultProcessorIface, l *zap.Logger) *Sequencer {
-	return &Sequencer{
-		l:            l.Named(_loggerName),
-		progressFeed: event.Feed{},
-		fatalErr:     utils.NewResettaleError(),
-		regularErr:   utils.NewResettaleError(),
-		failedTags:   make([]Tag, 0),
-		testErrors:   make([]error, 0),
-		rp:           rp,
+func NewSequencer(rp ResultProcessorIface, l *zap.Logger, opts ...SequencerOption) *Sequencer {
+	ret := &Sequencer{
+		l:               l.Named(_loggerName),
+		progressFeed:    event.Feed{},
+		sampleFeed:      event.Feed{},
+		samples:         make(chan Sample, _sampleBufferSize),
+		closing:         make(chan struct{}),
+		fatalErr:        utils.NewResettaleError(),
+		regularErr:      utils.NewResettaleError(),
+		failedTags:      make([]Tag, 0),
+		testErrors:      make([]error, 0),
+		priorResults:    newPathResults(),
+		teardownErrors:  make([]error, 0),
+		reportPaths:     make([]string, 0),
+		rp:              rp,
+		pauser:          newPauser(),
+		hangGracePeriod: _defaultHangGracePeriod,
 	}
+
+	for _, o := range opts {
+		o(ret)
+	}
+
+	return ret
 }
 
 // SubscribeToProgress subscribes to the progress of the Sequencer across its Sequence runs.
@@ -54,6 +96,12 @@ func (s *Sequencer) SubscribeToProgress(progCh chan Progress) event.Subscription
 	return s.progressFeed.Subscribe(progCh)
 }
 
+// SubscribeToMeasurements subscribes to the live samples published by the states of the running Sequence.
+// Samples are dropped rather than delaying the states if subscribers fall behind.
+func (s *Sequencer) SubscribeToMeasurements(sampleCh chan Sample) event.Subscription {
+	return s.sampleFeed.Subscribe(sampleCh)
+}
+
 // Open will be called at the start of the app.
 func (s *Sequencer) Open(ctx context.Context) error {
 	err := s.rp.Open(ctx)
@@ -61,6 +109,8 @@ func (s *Sequencer) Open(ctx context.Context) error {
 		return errors.Wrap(err, "open")
 	}
 
+	go s.forwardSamples()
+
 	return nil
 }
 
@@ -76,21 +126,30 @@ func (s *Sequencer) Run(
 
 	s.testErrors = []error{}
 	s.failedTags = []Tag{}
+	s.priorResults = newPathResults()
+	s.teardownErrors = []error{}
+	s.reportPaths = []string{}
+	s.testCanceled = false
+	s.pauser.clear()
 
-	// Reset failed tags and test errors at the end of run.
+	// Reset failed tags, test errors and prior results at the end of run.
 	defer func() {
 		s.testErrors = []error{}
 		s.failedTags = []Tag{}
+		s.priorResults = newPathResults()
 	}()
 
 	s.progress = Progress{
 		CurrentState:  nil,
 		StateDuration: make([]time.Duration, 0),
 		StatePassed:   make([]bool, 0),
+		StateAttempts: make([][]Attempt, 0),
 		StateIndex:    0,
 		Sequence:      seq,
 	}
 
+	ctx = withHangGracePeriod(WithTestStartedAt(WithTestId(ctx, testId), time.Now()), s.hangGracePeriod)
+
 	isPassing, err := s.runSequence(ctx, seq, cancelTest, testId)
 	if err != nil {
 		return false, s.failedTags, s.testErrors, errors.Wrap(err, "run sequence")
@@ -105,6 +164,43 @@ func (s *Sequencer) FatalError() error {
 	return s.fatalErr.Err()
 }
 
+// TeardownErrors returns the errors from the teardown states of the last Run. These are not test failures.
+func (s *Sequencer) TeardownErrors() []error {
+	return s.teardownErrors
+}
+
+// ReportPaths returns the paths of the reports generated for the last Run, if the result processor writes any.
+func (s *Sequencer) ReportPaths() []string {
+	return s.reportPaths
+}
+
+// Pause halts the running sequence before its next state. The state that is currently running is not interrupted.
+func (s *Sequencer) Pause() {
+	s.l.Info("pause requested")
+
+	s.pauser.pause()
+}
+
+// Resume continues a paused sequence.
+func (s *Sequencer) Resume() {
+	s.l.Info("resume requested")
+
+	s.pauser.resume()
+}
+
+// Step runs the next state of a paused sequence, then pauses again.
+func (s *Sequencer) Step() {
+	s.l.Info("step requested")
+
+	s.pauser.step()
+}
+
+// SetBreakpoints pauses the sequence before the Setup of any top-level state with one of the given names.
+// It replaces the previous breakpoints, and applies to the next and subsequent runs.
+func (s *Sequencer) SetBreakpoints(stateNames []string) {
+	s.pauser.setBreakpoints(stateNames)
+}
+
 // ResetFatalError sets the fatal error to nil.
 func (s *Sequencer) ResetFatalError() {
 	s.fatalErr.Reset()
@@ -114,6 +210,8 @@ func (s *Sequencer) ResetFatalError() {
 func (s *Sequencer) Close() error {
 	s.l.Info("closing sequencer")
 
+	close(s.closing)
+
 	err := s.rp.Close()
 	if err != nil {
 		return errors.Wrap(err, "result processor close")
@@ -123,9 +221,99 @@ func (s *Sequencer) Close() error {
 }
 
 func (s *Sequencer) runSequence(ctx context.Context, seq Sequence, cancelTest chan struct{}, testId uuid.UUID) (bool, error) {
+	err := s.runIterations(ctx, seq, cancelTest)
+
+	// Teardown cannot be canceled. Cancels sent from here on are read and dropped, so they neither block the sender
+	// nor cancel the first state of the next test.
+	teardownDone := make(chan struct{})
+	defer close(teardownDone)
+
+	go s.ignoreCancelSignal(teardownDone, cancelTest)
+
+	// Teardown states always run, no matter how the main states ended.
+	teardownErr := s.runTeardown(ctx, seq)
+
+	if err != nil {
+		return false, errors.Wrap(err, "run iterations")
+	}
+
+	if teardownErr != nil {
+		return false, errors.Wrap(teardownErr, "run teardown")
+	}
+
+	s.l.Info("sequence complete")
+
+	_ = s.progressFeed.Send(s.progress)
+
+	passingTest, err := s.rp.CompleteTest(ctx, testId, seq.Name)
+	if err != nil {
+		return false, errors.Wrap(err, "complete test")
+	}
+
+	reportProcessor, ok := s.rp.(ReportProcessorIface)
+	if ok {
+		s.reportPaths = reportProcessor.ReportPaths()
+	}
+
+	return passingTest, nil
+}
+
+// runIterations runs the sequence's states once for every set of params in its matrix.
+func (s *Sequencer) runIterations(ctx context.Context, seq Sequence, cancelTest chan struct{}) error {
+	iters, err := iterations(seq.Matrix)
+	if err != nil {
+		return errors.Wrap(err, "expand matrix")
+	}
+
+	s.progress.Iterations = len(iters)
+
+	for idx, params := range iters {
+		if s.testCanceled || s.fatalErr.Err() != nil {
+			s.l.Info("skipping remaining iterations", zap.Int("iteration", idx))
+			break
+		}
+
+		if params != nil {
+			s.l.Info("starting iteration",
+				zap.Int("iteration", idx),
+				zap.String("params", params.String()))
+		}
+
+		s.priorResults = newPathResults()
+		s.progress.Iteration = idx
+		s.progress.Params = params
+		s.progress.StatePassed = make([]bool, 0)
+		s.progress.StateDuration = make([]time.Duration, 0)
+		s.progress.StateAttempts = make([][]Attempt, 0)
+
+		err = s.runStates(WithIterationParams(ctx, params), seq, cancelTest)
+		if err != nil {
+			return errors.Wrapf(err, "iteration (%d)", idx)
+		}
+	}
+
+	return nil
+}
+
+func (s *Sequencer) runStates(ctx context.Context, seq Sequence, cancelTest chan struct{}) error {
 	for idx, state := range seq.States {
 		s.progress.CurrentState = state
 		s.progress.StateIndex = idx
+		s.progress.Children = nil
+
+		if !s.waitIfPaused(ctx, cancelTest, state) {
+			s.l.Info("test canceled while paused", zap.String("state", state.Name()))
+
+			canceledErr := errors.Errorf("test canceled while paused before (%s)", state.Name())
+			s.testErrors = append(s.testErrors, canceledErr)
+
+			err := s.rp.SubmitError(ctx, canceledErr)
+			if err != nil {
+				return errors.Wrap(err, "submit error")
+			}
+
+			break
+		}
 
 		_ = s.progressFeed.Send(s.progress)
 
@@ -137,7 +325,7 @@ func (s *Sequencer) runSequence(ctx context.Context, seq Sequence, cancelTest ch
 
 		continueSequence, err := s.processResults(ctx, state)
 		if err != nil {
-			return false, errors.Wrap(err, "process results")
+			return errors.Wrap(err, "process results")
 		}
 
 		if !continueSequence {
@@ -146,36 +334,148 @@ func (s *Sequencer) runSequence(ctx context.Context, seq Sequence, cancelTest ch
 		}
 	}
 
-	s.l.Info("sequence complete")
+	return nil
+}
 
-	_ = s.progressFeed.Send(s.progress)
+func (s *Sequencer) runTeardown(ctx context.Context, seq Sequence) error {
+	// Teardown must still run if the test was canceled.
+	ctx = WithStatePath(context.WithoutCancel(ctx), s.progress.Sequence.Name)
 
-	passingTest, err := s.rp.CompleteTest(ctx, testId, seq.Name)
-	if err != nil {
-		return false, errors.Wrap(err, "complete test")
+	for _, state := range seq.Teardown {
+		s.progress.CurrentState = state
+		s.progress.Children = nil
+
+		_ = s.progressFeed.Send(s.progress)
+
+		s.l.Info("starting teardown state", zap.String("state", state.Name()))
+
+		stateCtx := withArtifactSink(withPriorResults(ctx, s.priorResults), s.artifactSink(ctx, state))
+		stateCtx = withSampleSink(stateCtx, s.sampleSink(state))
+
+		outcome := runChild(stateCtx, state)
+
+		if outcome.err != nil {
+			s.l.Error("teardown state failed", zap.String("state", state.Name()), zap.Error(outcome.err))
+
+			err := errors.Wrap(outcome.err, "teardown")
+			s.teardownErrors = append(s.teardownErrors, err)
+
+			teardownProcessor, ok := s.rp.(TeardownProcessorIface)
+			if ok {
+				err = teardownProcessor.SubmitTeardownError(ctx, err)
+				if err != nil {
+					return errors.Wrap(err, "submit teardown error")
+				}
+			}
+		}
+
+		// A failed teardown can leave the bench in an unsafe state, so fatal errors are still treated as fatal.
+		if outcome.fatalErr != nil {
+			s.l.Error("encountered fatal error", zap.String("state", state.Name()), zap.Error(outcome.fatalErr))
+
+			fatalErr := errors.Wrap(outcome.fatalErr, "teardown")
+			s.fatalErr.Set(fatalErr)
+
+			err := s.rp.SubmitError(ctx, fatalErr)
+			if err != nil {
+				return errors.Wrap(err, "submit error")
+			}
+		}
+
+		// Teardown states abandoned as hung may still be running, so their results cannot be read safely.
+		if isHungError(outcome.fatalErr) {
+			continue
+		}
+
+		for path, results := range s.resultsByPath(state) {
+			for tag, value := range results {
+				isPassing, err := s.rp.SubmitTag(WithStatePath(ctx, path), tag.ID, value)
+				if err != nil {
+					return errors.Wrap(err, "submit tag")
+				}
+
+				if !isPassing {
+					s.failedTags = append(s.failedTags, tag)
+				}
+			}
+		}
 	}
 
-	return passingTest, nil
+	return nil
 }
 
 func (s *Sequencer) runState(ctx context.Context, cancelTest chan struct{}, state State) {
 	var (
-		timeoutCtx context.Context
-		startTime  time.Time
+		stateCtx  context.Context
+		startTime = time.Now()
+		policy    = getRetryPolicy(state)
 	)
 
-	startTime = time.Now()
+	// Give composite states access to prior results and a way to report the progress of their children.
+	ctx = withPriorResults(ctx, s.priorResults)
+	ctx = WithStatePath(ctx, joinPath(s.progress.Sequence.Name, state.Name()))
+	ctx = withProgressReporter(ctx, s.reportChildProgress)
+	ctx = withArtifactSink(ctx, s.artifactSink(ctx, state))
+	ctx = withSampleSink(ctx, s.sampleSink(state))
 
-	timeoutCtx, s.cancelCurrentTest = context.WithTimeout(ctx, state.Timeout())
+	stateCtx, s.cancelCurrentTest = context.WithCancel(ctx)
 	defer s.cancelCurrentTest()
 
-	go s.monitorCancelSignal(ctx, cancelTest)
+	go s.monitorCancelSignal(stateCtx, cancelTest)
+
+	s.currentAttempts = []Attempt{}
+	s.stateHung = false
+
+	for attemptNum := 1; ; attemptNum++ {
+		attemptStartTime := time.Now()
+
+		s.runAttempt(stateCtx, state)
+
+		if !s.shouldRetry(policy, attemptNum) {
+			break
+		}
+
+		s.currentAttempts = append(s.currentAttempts, Attempt{
+			Number:   attemptNum,
+			Duration: time.Since(attemptStartTime),
+			Err:      s.regularErr.Err(),
+		})
+
+		s.l.Warn("retrying state",
+			zap.String("state", state.Name()),
+			zap.Int("attempt", attemptNum),
+			zap.Int("max attempts", policy.MaxAttempts),
+			zap.Error(s.regularErr.Err()))
+
+		s.regularErr.Reset()
+
+		err := s.waitForRetry(stateCtx, policy)
+		if err != nil {
+			s.regularErr.Set(errors.Wrapf(err, "wait for retry (%s)", state.Name()))
+			break
+		}
+	}
+
+	s.progress.StateDuration = append(s.progress.StateDuration, time.Since(startTime))
+	s.progress.StateAttempts = append(s.progress.StateAttempts, s.currentAttempts)
+}
+
+func (s *Sequencer) runAttempt(ctx context.Context, state State) {
+	timeoutCtx, cancelSetup := context.WithTimeout(ctx, state.Timeout())
+	defer cancelSetup()
 
 	s.l.Info("setting up state", zap.String("state", state.Name()))
 
-	// Set up the state for execution
-	err := state.Setup(timeoutCtx)
-	if err != nil {
+	// Set up the state for execution. Panics and hung states are fatal, the state cannot be trusted afterwards.
+	err := supervise(timeoutCtx, state, _setupPhase, state.Setup)
+	if IsSupervisorError(err) {
+		s.l.Error("state supervisor stopped setup", zap.String("state", state.Name()), zap.Error(err))
+
+		s.fatalErr.Set(err)
+		s.stateHung = isHungError(err)
+
+		return
+	} else if err != nil {
 		s.l.Error("received error during setup",
 			zap.String("state", state.Name()),
 			zap.Error(err))
@@ -193,24 +493,27 @@ func (s *Sequencer) runState(ctx context.Context, cancelTest chan struct{}, stat
 
 	// If we encounter an error during setup, return early and do not call run.
 	if s.regularErr.Err() != nil || s.fatalErr.Err() != nil {
-		s.progress.StateDuration = append(s.progress.StateDuration, time.Since(startTime))
-
 		return
 	}
 
-	timeoutCtx, s.cancelCurrentTest = context.WithTimeout(ctx, state.Timeout())
-	defer s.cancelCurrentTest()
+	timeoutCtx, cancelRun := context.WithTimeout(ctx, state.Timeout())
+	defer cancelRun()
 
 	s.l.Info("running state", zap.String("state", state.Name()))
 
 	// Run the state logic
-	err = state.Run(timeoutCtx)
-	if err != nil {
+	err = supervise(timeoutCtx, state, _runPhase, state.Run)
+	if IsSupervisorError(err) {
+		s.l.Error("state supervisor stopped run", zap.String("state", state.Name()), zap.Error(err))
+
+		s.fatalErr.Set(err)
+		s.stateHung = isHungError(err)
+
+		return
+	} else if err != nil {
 		s.regularErr.Set(errors.Wrapf(err, "run (%s)", state.Name()))
 	}
 
-	s.progress.StateDuration = append(s.progress.StateDuration, time.Since(startTime))
-
 	// Check for fatal error after run
 	err = state.FatalError()
 	if err != nil {
@@ -218,8 +521,33 @@ func (s *Sequencer) runState(ctx context.Context, cancelTest chan struct{}, stat
 
 		s.fatalErr.Set(errors.Wrapf(err, "fatal error during run (%s)", state.Name()))
 	}
+}
+
+// shouldRetry returns true if the last attempt failed with a retryable error and attempts remain.
+func (s *Sequencer) shouldRetry(policy RetryPolicy, attemptNum int) bool {
+	switch {
+	case attemptNum >= policy.MaxAttempts:
+		return false
+	case s.testCanceled, s.fatalErr.Err() != nil:
+		return false
+	case s.regularErr.Err() == nil:
+		return false
+	default:
+		return policy.isRetryable(s.regularErr.Err())
+	}
+}
+
+func (s *Sequencer) waitForRetry(ctx context.Context, policy RetryPolicy) error {
+	if policy.Backoff == nil {
+		return nil
+	}
 
-	return
+	wait := policy.Backoff.NextBackOff()
+	if wait == backoff.Stop {
+		return errors.New("backoff stopped retries")
+	}
+
+	return utils.Sleep(ctx, wait)
 }
 
 func (s *Sequencer) processResults(ctx context.Context, state State) (bool, error) {
@@ -228,6 +556,16 @@ func (s *Sequencer) processResults(ctx context.Context, state State) (bool, erro
 		continueSequence bool
 	)
 
+	if len(s.currentAttempts) > 0 {
+		attemptProcessor, ok := s.rp.(AttemptProcessorIface)
+		if ok {
+			err := attemptProcessor.SubmitAttempts(ctx, state.Name(), s.currentAttempts)
+			if err != nil {
+				return false, errors.Wrap(err, "submit attempts")
+			}
+		}
+	}
+
 	if s.regularErr.Err() != nil {
 		statePassed = false
 
@@ -248,17 +586,24 @@ func (s *Sequencer) processResults(ctx context.Context, state State) (bool, erro
 		}
 	}
 
-	results := state.GetResults()
-
-	for tag, value := range results {
-		isPassing, err := s.rp.SubmitTag(ctx, tag.ID, value)
-		if err != nil {
-			return false, errors.Wrap(err, "submit tag")
-		}
+	// A hung state is still running on its abandoned goroutine, so its results cannot be read safely.
+	var results map[string]map[Tag]any
+	if !s.stateHung {
+		results = s.resultsByPath(state)
+		s.priorResults.addState(s.progress.Sequence.Name, state)
+	}
 
-		if !isPassing {
-			statePassed = false
-			s.failedTags = append(s.failedTags, tag)
+	for path, pathResults := range results {
+		for tag, value := range pathResults {
+			isPassing, err := s.rp.SubmitTag(WithStatePath(ctx, path), tag.ID, value)
+			if err != nil {
+				return false, errors.Wrap(err, "submit tag")
+			}
+
+			if !isPassing {
+				statePassed = false
+				s.failedTags = append(s.failedTags, tag)
+			}
 		}
 	}
 
@@ -267,10 +612,10 @@ func (s *Sequencer) processResults(ctx context.Context, state State) (bool, erro
 	switch {
 	// If test canceled should not continue to next states.
 	case s.testCanceled:
-		s.testCanceled = false
 		continueSequence = false
-	// If encountered fatal error, should not continue.
-	case state.FatalError() != nil:
+	// If encountered fatal error, should not continue. This includes panics and hangs caught by the supervisor,
+	// which the state's own FatalError does not report.
+	case s.fatalErr.Err() != nil:
 		continueSequence = false
 	// If state passed and did not get any regular errors, continue sequence.
 	case statePassed && (s.regularErr.Err() == nil):
@@ -287,6 +632,111 @@ func (s *Sequencer) processResults(ctx context.Context, state State) (bool, erro
 	return continueSequence, nil
 }
 
+// waitIfPaused blocks before the state while the sequence is paused. It returns false if the test was canceled.
+func (s *Sequencer) waitIfPaused(ctx context.Context, cancelTest chan struct{}, state State) bool {
+	if !s.pauser.shouldPause(state) {
+		return true
+	}
+
+	s.l.Info("sequence paused", zap.String("next state", state.Name()))
+
+	s.progress.Paused = true
+	_ = s.progressFeed.Send(s.progress)
+
+	resumed := s.pauser.wait(ctx, cancelTest)
+
+	s.progress.Paused = false
+
+	if !resumed {
+		s.testCanceled = true
+		return false
+	}
+
+	s.l.Info("sequence resumed", zap.String("next state", state.Name()))
+
+	return true
+}
+
+// resultsByPath returns the results of the state by the path of the state that produced them, starting with the
+// sequence name.
+func (s *Sequencer) resultsByPath(state State) map[string]map[Tag]any {
+	ret := map[string]map[Tag]any{}
+
+	for path, results := range resultsByPath(state) {
+		ret[joinPath(s.progress.Sequence.Name, path)] = results
+	}
+
+	return ret
+}
+
+// artifactSink returns the sink for the artifacts attached by the state and its children.
+func (s *Sequencer) artifactSink(ctx context.Context, state State) artifactSink {
+	return func(artifact Artifact) error {
+		artifact.StateName = state.Name()
+
+		artifactProcessor, ok := s.rp.(ArtifactProcessorIface)
+		if !ok {
+			s.l.Warn("result processor does not accept artifacts, dropping artifact",
+				zap.String("artifact", artifact.Name),
+				zap.String("state", state.Name()))
+
+			return nil
+		}
+
+		err := artifactProcessor.SubmitArtifact(ctx, artifact)
+		if err != nil {
+			return errors.Wrap(err, "submit artifact")
+		}
+
+		return nil
+	}
+}
+
+// sampleSink returns the sink for the samples published by the state and its children.
+func (s *Sequencer) sampleSink(state State) sampleSink {
+	return func(sample Sample) {
+		sample.StateName = state.Name()
+
+		select {
+		case s.samples <- sample:
+		default:
+			s.l.Debug("sample buffer full, dropping sample", zap.String("sample", sample.Name))
+		}
+	}
+}
+
+// forwardSamples sends the published samples to subscribers until the Sequencer is closed.
+func (s *Sequencer) forwardSamples() {
+	for {
+		select {
+		case sample := <-s.samples:
+			_ = s.sampleFeed.Send(sample)
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+func (s *Sequencer) reportChildProgress(children []ChildProgress) {
+	s.progress.Children = copyChildProgress(children)
+
+	progress := s.progress
+	progress.Children = copyChildProgress(children)
+
+	_ = s.progressFeed.Send(progress)
+}
+
+func (s *Sequencer) ignoreCancelSignal(done chan struct{}, cancelTest chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-cancelTest:
+			s.l.Info("ignoring cancel, the test is already tearing down")
+		}
+	}
+}
+
 func (s *Sequencer) monitorCancelSignal(ctx context.Context, cancelTest chan struct{}) {
 	select {
 	case <-ctx.Done():
@@ -0,0 +1,244 @@
+package flow
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const _testGracePeriod = 20 * time.Millisecond
+
+// stubResultProcessor passes every tag and records the submitted tags, their state paths and errors.
+type stubResultProcessor struct {
+	tags   []string
+	paths  []string
+	errors []error
+}
+
+func (r *stubResultProcessor) Open(context.Context) error { return nil }
+
+func (r *stubResultProcessor) Close() error { return nil }
+
+func (r *stubResultProcessor) SubmitTag(ctx context.Context, tagId string, _ any) (bool, error) {
+	r.tags = append(r.tags, tagId)
+	r.paths = append(r.paths, StatePath(ctx))
+	return true, nil
+}
+
+func (r *stubResultProcessor) CompleteTest(context.Context, uuid.UUID, string) (bool, error) {
+	return len(r.errors) == 0, nil
+}
+
+func (r *stubResultProcessor) SubmitError(_ context.Context, err error) error {
+	r.errors = append(r.errors, err)
+	return nil
+}
+
+// stubState calls run during Run and records which of its methods were called. Its results are a passing tag
+// named after the state, unless results is set.
+type stubState struct {
+	name           string
+	continueOnFail bool
+	timeout        time.Duration
+	run            func(ctx context.Context) error
+	results        map[Tag]any
+
+	setupCalled      atomic.Bool
+	getResultsCalled atomic.Bool
+}
+
+func (s *stubState) Name() string { return s.name }
+
+func (s *stubState) Setup(context.Context) error {
+	s.setupCalled.Store(true)
+	return nil
+}
+
+func (s *stubState) Run(ctx context.Context) error {
+	if s.run == nil {
+		return nil
+	}
+
+	return s.run(ctx)
+}
+
+func (s *stubState) GetResults() map[Tag]any {
+	s.getResultsCalled.Store(true)
+
+	if s.results != nil {
+		return s.results
+	}
+
+	return map[Tag]any{{ID: s.name}: true}
+}
+
+func (s *stubState) ContinueOnFail() bool { return s.continueOnFail }
+
+func (s *stubState) Timeout() time.Duration {
+	if s.timeout == 0 {
+		return time.Second
+	}
+
+	return s.timeout
+}
+
+func (s *stubState) FatalError() error { return nil }
+
+func newTestSequencer(rp *stubResultProcessor) *Sequencer {
+	return NewSequencer(rp, zap.NewNop(), WithHangGracePeriod(_testGracePeriod))
+}
+
+func TestSequencerPanicStopsSequenceWithContinueOnFail(t *testing.T) {
+	rp := &stubResultProcessor{}
+	sequencer := newTestSequencer(rp)
+
+	panicking := &stubState{
+		name:           "a",
+		continueOnFail: true,
+		run: func(context.Context) error {
+			panic("boom")
+		},
+	}
+	next := &stubState{name: "b"}
+
+	_, _, _, err := sequencer.Run(context.Background(),
+		Sequence{Name: "panic", States: []State{panicking, next}}, make(chan struct{}), uuid.New())
+	require.NoError(t, err)
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, sequencer.FatalError(), &panicErr)
+	assert.False(t, next.setupCalled.Load(), "state after a panic must not be set up")
+	assert.True(t, panicking.getResultsCalled.Load())
+}
+
+func TestSequencerHangStopsSequenceWithContinueOnFail(t *testing.T) {
+	rp := &stubResultProcessor{}
+	sequencer := newTestSequencer(rp)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	hung := &stubState{
+		name:           "a",
+		continueOnFail: true,
+		timeout:        _testGracePeriod,
+		run: func(context.Context) error {
+			// Ignores ctx, so the supervisor abandons it.
+			<-release
+			return nil
+		},
+	}
+	next := &stubState{name: "b"}
+
+	_, _, _, err := sequencer.Run(context.Background(),
+		Sequence{Name: "hang", States: []State{hung, next}}, make(chan struct{}), uuid.New())
+	require.NoError(t, err)
+
+	var hungErr *HungError
+	assert.ErrorAs(t, sequencer.FatalError(), &hungErr)
+	assert.False(t, next.setupCalled.Load(), "state after a hung state must not be set up")
+	assert.False(t, hung.getResultsCalled.Load(), "results of a hung state must not be read")
+	assert.NotContains(t, rp.tags, "a")
+}
+
+func TestSequencerIgnoresCancelDuringTeardown(t *testing.T) {
+	rp := &stubResultProcessor{}
+	sequencer := newTestSequencer(rp)
+	cancelTest := make(chan struct{})
+
+	var (
+		entered          = make(chan struct{})
+		release          = make(chan struct{})
+		teardownCanceled atomic.Bool
+	)
+
+	teardown := &stubState{
+		name: "teardown",
+		run: func(ctx context.Context) error {
+			close(entered)
+			<-release
+			teardownCanceled.Store(ctx.Err() != nil)
+			return nil
+		},
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, _, _, err := sequencer.Run(context.Background(),
+			Sequence{Name: "teardown", States: []State{&stubState{name: "a"}}, Teardown: []State{teardown}},
+			cancelTest, uuid.New())
+		done <- err
+	}()
+
+	<-entered
+
+	select {
+	case cancelTest <- struct{}{}:
+	case <-time.After(time.Second):
+		t.Fatal("cancel during teardown blocked")
+	}
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.False(t, teardownCanceled.Load(), "teardown must not be canceled")
+	assert.True(t, teardown.getResultsCalled.Load())
+
+	// The cancel was for the previous test, the next test must run to completion.
+	next := &stubState{
+		name: "b",
+		run: func(ctx context.Context) error {
+			return utils.Sleep(ctx, 50*time.Millisecond)
+		},
+	}
+
+	passing, _, _, err := sequencer.Run(context.Background(),
+		Sequence{Name: "next", States: []State{next}}, cancelTest, uuid.New())
+	require.NoError(t, err)
+	assert.True(t, passing)
+	assert.Empty(t, rp.errors)
+}
+
+func TestSequencerPriorResultsOfReusedGroup(t *testing.T) {
+	rp := &stubResultProcessor{}
+	sequencer := newTestSequencer(rp)
+	tsal := Tag{ID: "tsal"}
+
+	// The same group of states runs twice, under different parents.
+	group := func(name string, value int) State {
+		return NewSubSequence(Sequence{
+			Name:   name,
+			States: []State{&stubState{name: "poll_tsal", results: map[Tag]any{tsal: value}}},
+		})
+	}
+
+	var startup, restart, latest map[Tag]any
+
+	check := &stubState{
+		name: "check",
+		run: func(ctx context.Context) error {
+			startup = PriorResultsAt(ctx, "lv/startup/poll_tsal")
+			restart = PriorResultsAt(ctx, "lv/restart/poll_tsal")
+			latest = PriorResults(ctx)
+			return nil
+		},
+	}
+
+	_, _, _, err := sequencer.Run(context.Background(),
+		Sequence{Name: "lv", States: []State{group("startup", 1), group("restart", 2), check}},
+		make(chan struct{}), uuid.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, startup[tsal])
+	assert.Equal(t, 2, restart[tsal])
+	assert.Equal(t, 2, latest[tsal])
+	assert.Contains(t, rp.paths, "lv/startup/poll_tsal")
+	assert.Contains(t, rp.paths, "lv/restart/poll_tsal")
+}
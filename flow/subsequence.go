@@ -0,0 +1,236 @@
+package flow
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/macformula/hil/utils"
+	"github.com/pkg/errors"
+)
+
+// SubSequence is a composite State that runs the states of a Sequence in order, so that groups of states can be
+// reused inside other sequences. Like the Sequencer, it stops at the first state that fails and does not continue on
+// fail, and its teardown states always run afterwards. A state passes if it completes Setup and Run without errors,
+// tag results are still checked by the result processor.
+type SubSequence struct {
+	seq  Sequence
+	opts compositeOptions
+
+	mtx      sync.Mutex
+	progress []ChildProgress
+	results  *pathResults
+	// prior holds the results of the states that ran, by their path, so a group used twice keeps both results.
+	prior    *pathResults
+	fatalErr *utils.ResettableError
+}
+
+// NewSubSequence returns a SubSequence state that runs the states of seq. The sequence cannot have a Matrix.
+func NewSubSequence(seq Sequence, opts ...CompositeOption) *SubSequence {
+	return &SubSequence{
+		seq:      seq,
+		opts:     newCompositeOptions(opts...),
+		results:  newPathResults(),
+		prior:    newPathResults(),
+		fatalErr: utils.NewResettaleError(),
+	}
+}
+
+// Name of the sub-sequence, this is the name of its Sequence.
+func (s *SubSequence) Name() string {
+	return s.seq.Name
+}
+
+// Sequence returns the sequence run by the sub-sequence.
+func (s *SubSequence) Sequence() Sequence {
+	return s.seq
+}
+
+// Setup resets the state from any previous run. The states of the sequence are set up one at a time in Run.
+func (s *SubSequence) Setup(_ context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.results = newPathResults()
+	s.prior = newPathResults()
+	s.fatalErr.Reset()
+
+	s.progress = make([]ChildProgress, 0, len(s.seq.States)+len(s.seq.Teardown))
+	for _, state := range s.seq.States {
+		s.progress = append(s.progress, ChildProgress{Name: state.Name()})
+	}
+
+	for _, state := range s.seq.Teardown {
+		s.progress = append(s.progress, ChildProgress{Name: state.Name()})
+	}
+
+	if len(s.seq.States) == 0 {
+		return errors.Errorf("sub-sequence (%s) has no states", s.seq.Name)
+	}
+
+	if s.seq.Matrix != nil {
+		return errors.Errorf("sub-sequence (%s) cannot have a matrix", s.seq.Name)
+	}
+
+	return nil
+}
+
+// Run sets up and runs the states of the sequence in order, followed by its teardown states.
+func (s *SubSequence) Run(ctx context.Context) error {
+	stateErrs := make([]string, 0)
+
+	for i, state := range s.seq.States {
+		if ctx.Err() != nil {
+			stateErrs = append(stateErrs, errors.Wrapf(ctx.Err(), "skipped (%s)", state.Name()).Error())
+			break
+		}
+
+		outcome := s.runState(ctx, i, state)
+
+		if outcome.err != nil {
+			stateErrs = append(stateErrs, outcome.err.Error())
+		}
+
+		if outcome.fatalErr != nil || (!outcome.passed() && !state.ContinueOnFail()) {
+			break
+		}
+	}
+
+	// Teardown states must still run if the sub-sequence was canceled or timed out.
+	teardownCtx := context.WithoutCancel(ctx)
+
+	for i, state := range s.seq.Teardown {
+		outcome := s.runState(teardownCtx, len(s.seq.States)+i, state)
+
+		if outcome.err != nil {
+			stateErrs = append(stateErrs, errors.Wrap(outcome.err, "teardown").Error())
+		}
+	}
+
+	if len(stateErrs) > 0 {
+		return errors.Errorf("states failed (%s)", strings.Join(stateErrs, "; "))
+	}
+
+	return nil
+}
+
+// GetResults returns the merged results of every state that ran. A tag produced by more than one state has its most
+// recent value, see ResultsByPath for the others.
+func (s *SubSequence) GetResults() map[Tag]any {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.results.byTag
+}
+
+// ResultsByPath returns the results by the path of the state that produced them, so a tag produced by more than
+// one state keeps every value.
+func (s *SubSequence) ResultsByPath() map[string]map[Tag]any {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.results.byPath
+}
+
+// Declaration combines the declarations of the states and teardown states.
+func (s *SubSequence) Declaration() Declaration {
+	return mergeDeclarations(append(append([]State{}, s.seq.States...), s.seq.Teardown...)...)
+}
+
+// ContinueOnFail is true only if every state continues on fail, unless overridden.
+func (s *SubSequence) ContinueOnFail() bool {
+	if s.opts.continueOnFail != nil {
+		return *s.opts.continueOnFail
+	}
+
+	for _, state := range s.seq.States {
+		if !state.ContinueOnFail() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Timeout allows every state, including teardown states, to use its full timeout for both Setup and Run,
+// unless overridden.
+func (s *SubSequence) Timeout() time.Duration {
+	if s.opts.timeout != nil {
+		return *s.opts.timeout
+	}
+
+	var total time.Duration
+
+	for _, state := range s.seq.States {
+		total += 2 * state.Timeout()
+	}
+
+	for _, state := range s.seq.Teardown {
+		total += 2 * state.Timeout()
+	}
+
+	return total
+}
+
+// FatalError returns the first fatal error encountered by any state.
+func (s *SubSequence) FatalError() error {
+	return s.fatalErr.Err()
+}
+
+// runState runs the state at index i of the progress, giving it access to the results of the states before it.
+func (s *SubSequence) runState(ctx context.Context, i int, state State) childOutcome {
+	s.updateProgress(ctx, i, ChildProgress{Name: state.Name(), Running: true})
+
+	priorResults := newPathResults()
+	priorResults.merge(priorResultsFrom(ctx))
+
+	s.mtx.Lock()
+	priorResults.merge(s.prior)
+	s.mtx.Unlock()
+
+	stateCtx := withPriorResults(ctx, priorResults)
+	stateCtx = withProgressReporter(stateCtx, func(children []ChildProgress) {
+		s.mtx.Lock()
+		s.progress[i].Children = children
+		s.mtx.Unlock()
+
+		reportChildProgress(ctx, s.childProgress())
+	})
+
+	outcome := runChild(stateCtx, state)
+
+	// A state abandoned as hung may still be writing its results, so they cannot be read safely.
+	if !isHungError(outcome.fatalErr) {
+		s.mtx.Lock()
+		s.results.addState("", state)
+		s.prior.addState(StatePath(ctx), state)
+		s.mtx.Unlock()
+	}
+
+	s.fatalErr.Set(outcome.fatalErr)
+
+	s.updateProgress(ctx, i, ChildProgress{
+		Name:     state.Name(),
+		Passed:   outcome.passed(),
+		Duration: outcome.duration,
+	})
+
+	return outcome
+}
+
+func (s *SubSequence) updateProgress(ctx context.Context, i int, progress ChildProgress) {
+	s.mtx.Lock()
+	progress.Children = s.progress[i].Children
+	s.progress[i] = progress
+	s.mtx.Unlock()
+
+	reportChildProgress(ctx, s.childProgress())
+}
+
+func (s *SubSequence) childProgress() []ChildProgress {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return copyChildProgress(s.progress)
+}
@@ -0,0 +1,58 @@
+package flow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubSequenceSkipsResultsOfHungState(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	subSequence := NewSubSequence(Sequence{
+		Name:     "group",
+		States:   []State{newWritingHungState("hung", release)},
+		Teardown: []State{&stubState{name: "teardown"}},
+	})
+
+	ctx := withHangGracePeriod(context.Background(), _testGracePeriod)
+
+	require.NoError(t, subSequence.Setup(ctx))
+	require.NoError(t, subSequence.Run(ctx))
+
+	var hungErr *HungError
+	assert.ErrorAs(t, subSequence.FatalError(), &hungErr)
+	assert.Equal(t, map[Tag]any{{ID: "teardown"}: true}, subSequence.GetResults())
+}
+
+func TestSubSequenceSubmitsDuplicateTags(t *testing.T) {
+	rp := &stubResultProcessor{}
+	sequencer := newTestSequencer(rp)
+	tsal := Tag{ID: "tsal"}
+
+	group := func(name string, value int) State {
+		return NewSubSequence(Sequence{
+			Name:   name,
+			States: []State{&stubState{name: "poll_tsal", results: map[Tag]any{tsal: value}}},
+		})
+	}
+
+	// Both groups submit the same tag inside a single sub-sequence.
+	startup := NewSubSequence(Sequence{Name: "startup", States: []State{group("first", 1), group("second", 2)}})
+
+	_, _, _, err := sequencer.Run(context.Background(),
+		Sequence{Name: "lv", States: []State{startup}}, make(chan struct{}), uuid.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]map[Tag]any{
+		"first/poll_tsal":  {tsal: 1},
+		"second/poll_tsal": {tsal: 2},
+	}, startup.ResultsByPath())
+	assert.Equal(t, 2, startup.GetResults()[tsal])
+	assert.ElementsMatch(t, []string{"tsal", "tsal"}, rp.tags)
+	assert.ElementsMatch(t, []string{"lv/startup/first/poll_tsal", "lv/startup/second/poll_tsal"}, rp.paths)
+}
@@ -0,0 +1,113 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// _defaultHangGracePeriod is how long a state has to return after its timeout or cancellation before it is
+	// abandoned as hung.
+	_defaultHangGracePeriod = 5 * time.Second
+	_setupPhase             = "setup"
+	_runPhase               = "run"
+)
+
+// PanicError is a panic recovered from a state. It is always treated as a fatal error.
+type PanicError struct {
+	StateName string
+	Phase     string
+	Value     any
+	Stack     []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic during %s (%s): %v\n%s", e.Phase, e.StateName, e.Value, e.Stack)
+}
+
+// HungError is returned for a state that did not return within the grace period after its context was done.
+// The state is abandoned and left running, so it is always treated as a fatal error since it may still be driving
+// the bench.
+type HungError struct {
+	StateName   string
+	Phase       string
+	GracePeriod time.Duration
+}
+
+func (e *HungError) Error() string {
+	return fmt.Sprintf("state hung during %s (%s), it did not return within %s of its timeout or cancellation",
+		e.Phase, e.StateName, e.GracePeriod)
+}
+
+// IsSupervisorError returns true if err is, or wraps, a PanicError or a HungError.
+func IsSupervisorError(err error) bool {
+	var (
+		panicErr *PanicError
+		hungErr  *HungError
+	)
+
+	return errors.As(err, &panicErr) || errors.As(err, &hungErr)
+}
+
+// isHungError returns true if err is, or wraps, a HungError.
+func isHungError(err error) bool {
+	var hungErr *HungError
+
+	return errors.As(err, &hungErr)
+}
+
+// supervise calls a phase of a state on its own goroutine so the caller stays responsive when the state ignores
+// ctx. A panic is returned as a PanicError. If the phase has not returned within the grace period after ctx is
+// done, it is abandoned and a HungError is returned.
+func supervise(ctx context.Context, state State, phase string, fn func(ctx context.Context) error) error {
+	// Buffered so an abandoned phase can still return without leaking its goroutine forever.
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			value := recover()
+			if value != nil {
+				done <- &PanicError{
+					StateName: state.Name(),
+					Phase:     phase,
+					Value:     value,
+					Stack:     debug.Stack(),
+				}
+			}
+		}()
+
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	gracePeriod := hangGracePeriod(ctx)
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(gracePeriod):
+		return &HungError{StateName: state.Name(), Phase: phase, GracePeriod: gracePeriod}
+	}
+}
+
+func hangGracePeriod(ctx context.Context) time.Duration {
+	gracePeriod, ok := ctx.Value(_hangGracePeriodKey).(time.Duration)
+	if !ok {
+		return _defaultHangGracePeriod
+	}
+
+	return gracePeriod
+}
+
+func withHangGracePeriod(ctx context.Context, gracePeriod time.Duration) context.Context {
+	return context.WithValue(ctx, _hangGracePeriodKey, gracePeriod)
+}
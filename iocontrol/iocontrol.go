@@ -328,6 +328,36 @@ func (io *IOControl) ReadCurrent(input AnalogPin) (float64, error) {
 	return current, nil
 }
 
+// Reopen closes and opens the raspi and speedgoat controllers, for example to re-establish a dropped speedgoat
+// connection. Errors closing a controller are logged since the connection is often already broken.
+func (io *IOControl) Reopen(ctx context.Context) error {
+	if io.rp != nil {
+		err := io.rp.Close()
+		if err != nil {
+			io.l.Warn("failed to close raspi controller", zap.Error(err))
+		}
+
+		err = io.rp.Open(ctx)
+		if err != nil {
+			return errors.Wrap(err, "raspi controller open")
+		}
+	}
+
+	if io.sg != nil {
+		err := io.sg.Close()
+		if err != nil {
+			io.l.Warn("failed to close speedgoat controller", zap.Error(err))
+		}
+
+		err = io.sg.Open(ctx)
+		if err != nil {
+			return errors.Wrap(err, "speedgoat controller open")
+		}
+	}
+
+	return nil
+}
+
 func (io *IOControl) Close() error {
 	if io.rp != nil {
 		err := io.rp.Close()
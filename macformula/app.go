@@ -2,6 +2,7 @@ package macformula
 
 import (
 	"github.com/macformula/hil/canlink"
+	"github.com/macformula/hil/iocontrol"
 	"github.com/macformula/hil/iocontrol/sil"
 	"github.com/macformula/hil/macformula/config"
 	"github.com/macformula/hil/macformula/ecu/frontcontroller"
@@ -17,6 +18,7 @@ type App struct {
 	PtBusManager          *canlink.BusManager
 	VehCanTracer          *canlink.Tracer
 	PtCanTracer           *canlink.Tracer
+	IoController          *iocontrol.IOControl
 	PinoutController      *pinout.Controller // remove this eventually
 	PinModel              *sil.PinModel
 	TestBench             *TestBench
@@ -18,8 +18,31 @@ type Config struct {
 	ResultsDir              string `yaml:"resultsDir"`
 	LogsDir                 string `yaml:"logsDir"`
 	TagsFilePath            string `yaml:"tagsFilePath"`
+	SequencesDir            string `yaml:"sequencesDir"`
+	RunHistoryPath          string `yaml:"runHistoryPath"`
+	RunHistoryMaxRuns       int    `yaml:"runHistoryMaxRuns"`
+	HttpDispatcherAddr      string `yaml:"httpDispatcherAddr"`
+	GrpcDispatcherAddr      string `yaml:"grpcDispatcherAddr"`
 	CanTracerTimeoutMinutes int    `yaml:"canTracerTimeoutMinutes"`
 	SilPort                 int    `yaml:"silPort"`
+	Coordinator             struct {
+		Addr        string `yaml:"addr"`
+		BenchName   string `yaml:"benchName"`
+		ControlAddr string `yaml:"controlAddr"`
+	} `yaml:"coordinator"`
+	HealthChecks struct {
+		ConnectivityPin     string `yaml:"connectivityPin"`
+		LoopbackOutput      string `yaml:"loopbackOutput"`
+		LoopbackInput       string `yaml:"loopbackInput"`
+		CanTrafficTimeoutMs int    `yaml:"canTrafficTimeoutMs"`
+		MinFreeDiskMb       int    `yaml:"minFreeDiskMb"`
+	} `yaml:"healthChecks"`
+	Schedules []struct {
+		Cron     string            `yaml:"cron"`
+		Sequence string            `yaml:"sequence"`
+		Metadata map[string]string `yaml:"metadata"`
+		Priority string            `yaml:"priority"`
+	} `yaml:"schedules"`
 }
 
 // NewConfig returns a new Config type
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/macformula/hil/flow"
+import (
+	"reflect"
+
+	"github.com/macformula/hil/flow"
+)
 
 type FirmwareTagCollection struct {
 	FrontControllerFlashed flow.Tag
@@ -177,3 +181,29 @@ var BasicIoTags = BasicIoTagCollection{
 	LedMatchesButtonHigh: flow.Tag{ID: "BASICIO001", Description: "Indicator LED is high when button is set to high."},
 	LedMatchesButtonLow:  flow.Tag{ID: "BASICIO002", Description: "Indicator LED is low when button is set to low."},
 }
+
+// All returns every tag in the collection.
+func (c LvStartupTagCollection) All() []flow.Tag {
+	return collectionTags(c)
+}
+
+// All returns every tag in the collection.
+func (c BasicIoTagCollection) All() []flow.Tag {
+	return collectionTags(c)
+}
+
+// collectionTags returns the flow.Tag fields of a tag collection struct in the order they are declared.
+func collectionTags(collection any) []flow.Tag {
+	v := reflect.ValueOf(collection)
+
+	ret := make([]flow.Tag, 0, v.NumField())
+
+	for i := 0; i < v.NumField(); i++ {
+		tag, ok := v.Field(i).Interface().(flow.Tag)
+		if ok {
+			ret = append(ret, tag)
+		}
+	}
+
+	return ret
+}
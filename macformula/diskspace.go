@@ -0,0 +1,19 @@
+//go:build !windows
+
+package macformula
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// freeSpace returns the bytes available to unprivileged users on the filesystem containing dir.
+func freeSpace(dir string) (uint64, error) {
+	var stat unix.Statfs_t
+
+	err := unix.Statfs(dir, &stat)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,22 @@
+package macformula
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// freeSpace returns the bytes available to the current user on the volume containing dir.
+func freeSpace(dir string) (uint64, error) {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var free uint64
+
+	err = windows.GetDiskFreeSpaceEx(path, &free, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return free, nil
+}
@@ -0,0 +1,208 @@
+package macformula
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.einride.tech/can/pkg/socketcan"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula/pinout"
+	"github.com/macformula/hil/utils"
+)
+
+const (
+	_loopbackSettleTime = 50 * time.Millisecond
+	_bytesPerMb         = 1024 * 1024
+)
+
+// ConnectivityCheck reads a digital input to check the io controllers respond.
+type ConnectivityCheck struct {
+	a   *App
+	pin pinout.PhysicalIo
+}
+
+func NewConnectivityCheck(a *App, pin pinout.PhysicalIo) *ConnectivityCheck {
+	return &ConnectivityCheck{
+		a:   a,
+		pin: pin,
+	}
+}
+
+func (c *ConnectivityCheck) Name() string {
+	return "controller connectivity"
+}
+
+func (c *ConnectivityCheck) Check(_ context.Context) error {
+	_, err := c.a.PinoutController.ReadDigitalLevel(c.pin)
+	if err == nil {
+		return nil
+	}
+
+	procedure := flow.RecoveryProcedure{
+		Checklist: []string{"Check the io controllers are powered and their network cables are connected"},
+	}
+
+	if c.a.IoController != nil {
+		procedure.Actions = append(procedure.Actions, flow.RecoveryAction{
+			Name: "reopen io controllers",
+			Do:   c.a.IoController.Reopen,
+		})
+	}
+
+	return flow.WithRecovery(errors.Wrapf(err, "read digital level (%s)", c.pin.String()), procedure)
+}
+
+// LoopbackCheck drives a digital output high then low and checks an input wired to it follows.
+type LoopbackCheck struct {
+	pc     *pinout.Controller
+	output pinout.PhysicalIo
+	input  pinout.PhysicalIo
+}
+
+func NewLoopbackCheck(pc *pinout.Controller, output, input pinout.PhysicalIo) *LoopbackCheck {
+	return &LoopbackCheck{
+		pc:     pc,
+		output: output,
+		input:  input,
+	}
+}
+
+func (l *LoopbackCheck) Name() string {
+	return "io loopback"
+}
+
+func (l *LoopbackCheck) Check(ctx context.Context) error {
+	for _, level := range []bool{true, false} {
+		err := l.pc.SetDigitalLevel(l.output, level)
+		if err != nil {
+			return errors.Wrapf(err, "set digital level (%s)", l.output.String())
+		}
+
+		err = utils.Sleep(ctx, _loopbackSettleTime)
+		if err != nil {
+			return errors.Wrap(err, "sleep")
+		}
+
+		got, err := l.pc.ReadDigitalLevel(l.input)
+		if err != nil {
+			return errors.Wrapf(err, "read digital level (%s)", l.input.String())
+		}
+
+		if got != level {
+			return flow.WithRecovery(
+				errors.Errorf("input (%s) read (%t) after setting output (%s) to (%t)",
+					l.input.String(), got, l.output.String(), level),
+				flow.RecoveryProcedure{
+					Checklist: []string{
+						fmt.Sprintf("Check the loopback wiring between %s and %s", l.output.String(), l.input.String()),
+					},
+				})
+		}
+	}
+
+	return nil
+}
+
+// CanTrafficCheck waits for a frame on a can interface.
+type CanTrafficCheck struct {
+	canInterface string
+	timeout      time.Duration
+}
+
+func NewCanTrafficCheck(canInterface string, timeout time.Duration) *CanTrafficCheck {
+	return &CanTrafficCheck{
+		canInterface: canInterface,
+		timeout:      timeout,
+	}
+}
+
+func (c *CanTrafficCheck) Name() string {
+	return fmt.Sprintf("can traffic (%s)", c.canInterface)
+}
+
+func (c *CanTrafficCheck) Check(ctx context.Context) error {
+	procedure := flow.RecoveryProcedure{
+		Checklist: []string{
+			fmt.Sprintf("Check the %s interface is up with \"ip link show %s\"", c.canInterface, c.canInterface),
+			"Check the ecus on the bus are powered",
+		},
+	}
+
+	conn, err := socketcan.DialContext(ctx, _canNetwork, c.canInterface)
+	if err != nil {
+		return flow.WithRecovery(errors.Wrap(err, "dial context"), procedure)
+	}
+
+	receiver := socketcan.NewReceiver(conn)
+	defer receiver.Close()
+
+	// Buffered so the receive can return after the check gave up on it.
+	received := make(chan bool, 1)
+
+	go func() {
+		received <- receiver.Receive()
+	}()
+
+	select {
+	case ok := <-received:
+		if !ok {
+			err = receiver.Err()
+			if err == nil {
+				err = errors.New("connection closed")
+			}
+
+			return flow.WithRecovery(errors.Wrap(err, "receive"), procedure)
+		}
+
+		return nil
+	case <-time.After(c.timeout):
+		return flow.WithRecovery(
+			errors.Errorf("no frames received on (%s) within (%s)", c.canInterface, c.timeout), procedure)
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "wait for frame")
+	}
+}
+
+// DiskSpaceCheck checks a directory has enough free space for the traces and reports of a test.
+type DiskSpaceCheck struct {
+	dir     string
+	minFree uint64
+}
+
+func NewDiskSpaceCheck(dir string, minFreeMb int) *DiskSpaceCheck {
+	return &DiskSpaceCheck{
+		dir:     dir,
+		minFree: uint64(minFreeMb) * _bytesPerMb,
+	}
+}
+
+func (d *DiskSpaceCheck) Name() string {
+	return "free disk space"
+}
+
+func (d *DiskSpaceCheck) Check(_ context.Context) error {
+	// The first test creates the directory otherwise.
+	err := os.MkdirAll(d.dir, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "create dir (%s)", d.dir)
+	}
+
+	free, err := freeSpace(d.dir)
+	if err != nil {
+		return errors.Wrapf(err, "free space (%s)", d.dir)
+	}
+
+	if free < d.minFree {
+		return flow.WithRecovery(
+			errors.Errorf("only (%d MB) free in (%s), need (%d MB)", free/_bytesPerMb, d.dir, d.minFree/_bytesPerMb),
+			flow.RecoveryProcedure{
+				Checklist: []string{fmt.Sprintf("Free up space in %s, for example by archiving old test bundles", d.dir)},
+			})
+	}
+
+	return nil
+}
@@ -184,3 +184,22 @@ func GetAnalogOutputs(rev Revision) (AnalogPinout, error) {
 
 	return ret, nil
 }
+
+// HasPin returns true if the physical IO is mapped to a pin in any of the pinouts of the given revision.
+func HasPin(rev Revision, io PhysicalIo) bool {
+	if _, ok := _revisionDigitalInputPinout[rev][io]; ok {
+		return true
+	}
+
+	if _, ok := _revisionDigitalOutputPinout[rev][io]; ok {
+		return true
+	}
+
+	if _, ok := _revisionAnalogInputPinout[rev][io]; ok {
+		return true
+	}
+
+	_, ok := _revisionAnalogOutputPinout[rev][io]
+
+	return ok
+}
@@ -0,0 +1,27 @@
+package preflight
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula/config"
+	"github.com/macformula/hil/macformula/pinout"
+	"github.com/macformula/hil/results"
+	"github.com/pkg/errors"
+)
+
+// Check validates the sequences against the tag database and pinout revision set in the config.
+// It returns the problems found, or an error if the tag database or revision could not be loaded.
+func Check(cfg *config.Config, sequences []flow.Sequence, l *zap.Logger) ([]Problem, error) {
+	rev, err := pinout.RevisionString(cfg.Revision)
+	if err != nil {
+		return nil, errors.Errorf("invalid revision (%s) valid options (%v)", cfg.Revision, pinout.RevisionStrings())
+	}
+
+	tagDB, err := results.LoadTags(cfg.TagsFilePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "load tags")
+	}
+
+	return NewValidator(tagDB, rev, l).Validate(sequences), nil
+}
@@ -0,0 +1,129 @@
+package preflight
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula/pinout"
+	"github.com/macformula/hil/results"
+	"github.com/pkg/errors"
+)
+
+const (
+	_loggerName = "preflight"
+)
+
+// Problem is an issue with a sequence that would make it fail at runtime.
+type Problem struct {
+	// Sequence is the name of the sequence with the problem.
+	Sequence string
+	// State is the name of the top-level state with the problem.
+	State string
+	// Message describes the problem.
+	Message string
+}
+
+// String formats the problem as "sequence/state: message".
+func (p Problem) String() string {
+	return fmt.Sprintf("%s/%s: %s", p.Sequence, p.State, p.Message)
+}
+
+// Validator checks sequences against the tag database and the pinout of a revision before any test is run.
+// Only states that implement flow.Declarer can be checked.
+type Validator struct {
+	l     *zap.Logger
+	tagDB map[string]results.Tag
+	rev   pinout.Revision
+}
+
+// NewValidator returns a Validator for the given tag database and pinout revision.
+func NewValidator(tagDB map[string]results.Tag, rev pinout.Revision, l *zap.Logger) *Validator {
+	return &Validator{
+		l:     l.Named(_loggerName),
+		tagDB: tagDB,
+		rev:   rev,
+	}
+}
+
+// Validate returns every problem found in the sequences.
+func (v *Validator) Validate(sequences []flow.Sequence) []Problem {
+	problems := make([]Problem, 0)
+
+	for _, seq := range sequences {
+		for _, state := range append(append([]flow.State{}, seq.States...), seq.Teardown...) {
+			declaration, ok := flow.DeclarationOf(state)
+			if !ok {
+				v.l.Debug("state does not declare its tags or physical io",
+					zap.String("sequence", seq.Name),
+					zap.String("state", state.Name()))
+
+				continue
+			}
+
+			for _, tag := range declaration.Tags {
+				msg, ok := v.checkTag(tag)
+				if !ok {
+					problems = append(problems, Problem{Sequence: seq.Name, State: state.Name(), Message: msg})
+				}
+			}
+
+			for _, name := range declaration.PhysicalIo {
+				msg, ok := v.checkPhysicalIo(name)
+				if !ok {
+					problems = append(problems, Problem{Sequence: seq.Name, State: state.Name(), Message: msg})
+				}
+			}
+		}
+	}
+
+	v.l.Info("validated sequences",
+		zap.Int("sequences", len(sequences)),
+		zap.Int("problems", len(problems)),
+		zap.String("revision", v.rev.String()))
+
+	return problems
+}
+
+func (v *Validator) checkTag(tag flow.Tag) (string, bool) {
+	dbTag, ok := v.tagDB[tag.ID]
+	if !ok {
+		return fmt.Sprintf("tag (%s) is missing from the tag database", tag.ID), false
+	}
+
+	err := dbTag.Validate()
+	if err != nil {
+		return fmt.Sprintf("tag (%s) has impossible limits: %v", tag.ID, err), false
+	}
+
+	return "", true
+}
+
+func (v *Validator) checkPhysicalIo(name string) (string, bool) {
+	io, err := pinout.PhysicalIoString(name)
+	if err != nil {
+		return fmt.Sprintf("unknown physical io (%s)", name), false
+	}
+
+	if !pinout.HasPin(v.rev, io) {
+		return fmt.Sprintf("physical io (%s) has no pin for revision (%s)", name, v.rev), false
+	}
+
+	return "", true
+}
+
+// Err joins the problems into a single error, it returns nil if there are no problems.
+func Err(problems []Problem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(problems))
+	for i, problem := range problems {
+		lines[i] = problem.String()
+	}
+
+	return errors.Errorf("pre-flight validation failed:\n\t%s", strings.Join(lines, "\n\t"))
+}
@@ -0,0 +1,70 @@
+package macformula
+
+import (
+	"context"
+
+	"github.com/macformula/hil/flow"
+	"github.com/pkg/errors"
+)
+
+const _canNetwork = "can"
+
+// PowerCycleAction power cycles the test bench.
+func PowerCycleAction(tb *TestBench) flow.RecoveryAction {
+	return flow.RecoveryAction{
+		Name: "power cycle test bench",
+		Do: func(_ context.Context) error {
+			return tb.PowerCycle()
+		},
+	}
+}
+
+// RecoveryProcedure returns the procedure to recover the bench after a fatal error, with the given checklist for
+// the operator. It reopens the io controllers, redials the can interfaces and power cycles the test bench, then
+// verifies the lv controller is enabled.
+func (a *App) RecoveryProcedure(checklist ...string) flow.RecoveryProcedure {
+	actions := make([]flow.RecoveryAction, 0)
+
+	if a.IoController != nil {
+		actions = append(actions, flow.RecoveryAction{
+			Name: "reopen io controllers",
+			Do:   a.IoController.Reopen,
+		})
+	}
+
+	if a.WithVcan {
+		actions = append(actions,
+			flow.RecoveryAction{
+				Name: "redial veh can",
+				Do: func(ctx context.Context) error {
+					return a.VehBusManager.Redial(ctx, _canNetwork, a.Config.CanInterfaces.Veh)
+				},
+			},
+			flow.RecoveryAction{
+				Name: "redial pt can",
+				Do: func(ctx context.Context) error {
+					return a.PtBusManager.Redial(ctx, _canNetwork, a.Config.CanInterfaces.Pt)
+				},
+			},
+		)
+	}
+
+	actions = append(actions, PowerCycleAction(a.TestBench))
+
+	return flow.RecoveryProcedure{
+		Checklist: checklist,
+		Actions:   actions,
+		Verify: func(_ context.Context) error {
+			enabled, err := a.TestBench.IsLvControllerEnabled()
+			if err != nil {
+				return errors.Wrap(err, "is lv controller enabled")
+			}
+
+			if !enabled {
+				return errors.New("lv controller is not enabled after power cycle")
+			}
+
+			return nil
+		},
+	}
+}
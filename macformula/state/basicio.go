@@ -82,6 +82,14 @@ func (l *BasicIo) Run(ctx context.Context) error {
 	return nil
 }
 
+// Declaration lists the tags the state submits and the button and led it drives and reads.
+func (l *BasicIo) Declaration() flow.Declaration {
+	return flow.Declaration{
+		Tags:       config.BasicIoTags.All(),
+		PhysicalIo: physicalIoNames(pinout.IndicatorButton, pinout.IndicatorLed),
+	}
+}
+
 func (l *BasicIo) GetResults() map[flow.Tag]any {
 	return l.results
 }
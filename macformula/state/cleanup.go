@@ -6,6 +6,7 @@ import (
 
 	"github.com/macformula/hil/flow"
 	"github.com/macformula/hil/macformula"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -48,6 +49,16 @@ func (c *cleanup) Run(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+
+		err = flow.AttachFile(ctx, "veh_can_trace", c.app.VehCanTracer.GetFilePath())
+		if err != nil {
+			return errors.Wrap(err, "attach veh can trace")
+		}
+
+		err = flow.AttachFile(ctx, "pt_can_trace", c.app.PtCanTracer.GetFilePath())
+		if err != nil {
+			return errors.Wrap(err, "attach pt can trace")
+		}
 	}
 
 	return nil
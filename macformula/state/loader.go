@@ -0,0 +1,507 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula"
+	"github.com/pkg/errors"
+)
+
+var _sequenceFileExtensions = []string{".yaml", ".yml", ".json"}
+
+// sequenceFile is the on-disk representation of a flow.Sequence.
+type sequenceFile struct {
+	Name     string           `yaml:"name"`
+	Desc     string           `yaml:"desc"`
+	States   []stateFile      `yaml:"states"`
+	Teardown []stateFile      `yaml:"teardown"`
+	Matrix   map[string][]any `yaml:"matrix"`
+	// RequiredMetadata are the metadata keys that must be supplied to start the sequence. Sequences also require the
+	// metadata of their sub-sequences.
+	RequiredMetadata []string `yaml:"requiredMetadata"`
+	// Group sequences can only be used as sub-sequences of other sequences, they cannot be run on their own.
+	Group bool `yaml:"group"`
+}
+
+// stateFile references a registered state, or another sequence to run as a sub-sequence, along with its
+// parameters and overrides.
+type stateFile struct {
+	State          string         `yaml:"state"`
+	Sequence       string         `yaml:"sequence"`
+	Params         yaml.Node      `yaml:"params"`
+	Timeout        *time.Duration `yaml:"timeout"`
+	ContinueOnFail *bool          `yaml:"continueOnFail"`
+	Retry          *retryFile     `yaml:"retry"`
+
+	line int
+}
+
+// retryFile overrides the retry policy of a state.
+type retryFile struct {
+	MaxAttempts int           `yaml:"maxAttempts"`
+	Backoff     time.Duration `yaml:"backoff"`
+}
+
+// UnmarshalYAML keeps track of the line each state is declared on for error reporting.
+func (s *stateFile) UnmarshalYAML(node *yaml.Node) error {
+	type plain stateFile
+
+	err := node.Decode((*plain)(s))
+	if err != nil {
+		return err
+	}
+
+	s.line = node.Line
+
+	return nil
+}
+
+// parsedFile is a sequence file that has been read but whose states have not been built yet.
+type parsedFile struct {
+	path    string
+	seqFile sequenceFile
+}
+
+// sequenceLoader builds sequences from parsed files, resolving the sub-sequences they reference by name.
+type sequenceLoader struct {
+	a *macformula.App
+	l *zap.Logger
+
+	files map[string]parsedFile
+	// building is the chain of sequences currently being built, it is used to detect cycles.
+	building []string
+	// cycles are the sub-sequence cycles found. They are reported on their own since a cycle found in a
+	// sub-sequence only makes the sequence using it invalid.
+	cycles []string
+}
+
+// LoadSequences loads every sequence file in dir. States are built from the state registry, and sub-sequences from
+// the other files in dir. All problems found are reported together, each prefixed with its file and line.
+func LoadSequences(dir string, a *macformula.App, l *zap.Logger) ([]flow.Sequence, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read sequences dir (%s)", dir)
+	}
+
+	var (
+		files    = make([]parsedFile, 0, len(entries))
+		problems = make([]string, 0)
+		loader   = &sequenceLoader{
+			a:     a,
+			l:     l,
+			files: make(map[string]parsedFile),
+		}
+	)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isSequenceFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		file, err := parseSequenceFile(path)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		if other, ok := loader.files[file.seqFile.Name]; ok && file.seqFile.Name != "" {
+			problems = append(problems, fmt.Sprintf("%s: sequence name (%s) is already used by %s",
+				path, file.seqFile.Name, other.path))
+			continue
+		}
+
+		loader.files[file.seqFile.Name] = file
+		files = append(files, file)
+	}
+
+	sequences := make([]flow.Sequence, 0, len(files))
+
+	for _, file := range files {
+		seq, fileProblems := loader.buildSequence(file)
+		if len(fileProblems) > 0 {
+			problems = append(problems, fileProblems...)
+			continue
+		}
+
+		if !file.seqFile.Group {
+			sequences = append(sequences, seq)
+		}
+	}
+
+	problems = append(problems, loader.cycles...)
+
+	if len(problems) > 0 {
+		return nil, errors.Errorf("invalid sequence files:\n\t%s", strings.Join(problems, "\n\t"))
+	}
+
+	sort.SliceStable(sequences, func(i, j int) bool {
+		return sequences[i].Name < sequences[j].Name
+	})
+
+	return sequences, nil
+}
+
+func parseSequenceFile(path string) (parsedFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return parsedFile{}, errors.Errorf("%s: %v", path, err)
+	}
+
+	var seqFile sequenceFile
+
+	err = yaml.Unmarshal(data, &seqFile)
+	if err != nil {
+		return parsedFile{}, errors.Errorf("%s: %v", path, err)
+	}
+
+	return parsedFile{path: path, seqFile: seqFile}, nil
+}
+
+func (s *sequenceLoader) buildSequence(file parsedFile) (flow.Sequence, []string) {
+	var (
+		path     = file.path
+		seqFile  = file.seqFile
+		problems = make([]string, 0)
+	)
+
+	if seqFile.Name == "" {
+		problems = append(problems, fmt.Sprintf("%s: sequence name is required", path))
+	}
+
+	if len(seqFile.States) == 0 {
+		problems = append(problems, fmt.Sprintf("%s: sequence must have at least one state", path))
+	}
+
+	if seqFile.Group && seqFile.Matrix != nil {
+		problems = append(problems, fmt.Sprintf("%s: group sequence cannot have a matrix", path))
+	}
+
+	for key, values := range seqFile.Matrix {
+		if len(values) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: matrix parameter (%s) has no values", path, key))
+		}
+	}
+
+	s.building = append(s.building, seqFile.Name)
+	defer func() { s.building = s.building[:len(s.building)-1] }()
+
+	states, stateProblems := s.buildStates(path, seqFile.States)
+	teardown, teardownProblems := s.buildStates(path, seqFile.Teardown)
+
+	problems = append(problems, stateProblems...)
+	problems = append(problems, teardownProblems...)
+
+	return flow.Sequence{
+		Name:             seqFile.Name,
+		Desc:             seqFile.Desc,
+		States:           states,
+		Teardown:         teardown,
+		Matrix:           seqFile.Matrix,
+		RequiredMetadata: requiredMetadata(seqFile.RequiredMetadata, append(states, teardown...)),
+	}, problems
+}
+
+// requiredMetadata combines the required metadata keys of a sequence with those of its sub-sequences.
+func requiredMetadata(keys []string, states []flow.State) []string {
+	seen := make(map[string]struct{})
+
+	for _, key := range keys {
+		seen[key] = struct{}{}
+	}
+
+	for _, state := range states {
+		if override, ok := state.(*overrideState); ok {
+			state = override.State
+		}
+
+		if subSequence, ok := state.(*flow.SubSequence); ok {
+			for _, key := range subSequence.Sequence().RequiredMetadata {
+				seen[key] = struct{}{}
+			}
+		}
+	}
+
+	if len(seen) == 0 {
+		return nil
+	}
+
+	ret := make([]string, 0, len(seen))
+	for key := range seen {
+		ret = append(ret, key)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
+
+func (s *sequenceLoader) buildStates(path string, stateFiles []stateFile) ([]flow.State, []string) {
+	var (
+		states   = make([]flow.State, 0, len(stateFiles))
+		problems = make([]string, 0)
+	)
+
+	for _, sf := range stateFiles {
+		state, err := s.buildState(path, sf)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		states = append(states, state)
+	}
+
+	return states, problems
+}
+
+func (s *sequenceLoader) buildState(path string, sf stateFile) (flow.State, error) {
+	var (
+		state flow.State
+		err   error
+	)
+
+	switch {
+	case sf.State != "" && sf.Sequence != "":
+		return nil, errors.Errorf("%s:%d: state (%s) and sequence (%s) cannot both be set",
+			path, sf.line, sf.State, sf.Sequence)
+	case sf.Sequence != "":
+		state, err = s.buildSubSequence(path, sf)
+	default:
+		state, err = s.buildRegisteredState(path, sf)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if sf.Timeout == nil && sf.ContinueOnFail == nil && sf.Retry == nil {
+		return state, nil
+	}
+
+	return &overrideState{
+		State:          state,
+		timeout:        sf.Timeout,
+		continueOnFail: sf.ContinueOnFail,
+		retry:          sf.Retry,
+	}, nil
+}
+
+func (s *sequenceLoader) buildRegisteredState(path string, sf stateFile) (flow.State, error) {
+	factory, ok := _stateRegistry[sf.State]
+	if !ok {
+		return nil, errors.Errorf("%s:%d: unknown state (%s) valid options (%v)",
+			path, sf.line, sf.State, RegisteredStates())
+	}
+
+	p, err := newParams(path, &sf.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Param errors already carry their location.
+	state, err := factory(s.a, s.l, p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.checkUnused(sf.State)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// buildSubSequence builds a new instance of the referenced sequence every time, so that the states of a sequence
+// used more than once do not share results.
+func (s *sequenceLoader) buildSubSequence(path string, sf stateFile) (flow.State, error) {
+	if sf.Params.Kind != 0 {
+		return nil, errors.Errorf("%s:%d: sub-sequence (%s) cannot have params", path, sf.line, sf.Sequence)
+	}
+
+	for _, name := range s.building {
+		if name == sf.Sequence {
+			err := errors.Errorf("%s:%d: sub-sequence cycle (%s -> %s)",
+				path, sf.line, strings.Join(s.building, " -> "), sf.Sequence)
+			s.addCycle(sf.Sequence, err.Error())
+
+			return nil, err
+		}
+	}
+
+	file, ok := s.files[sf.Sequence]
+	if !ok {
+		return nil, errors.Errorf("%s:%d: unknown sequence (%s)", path, sf.line, sf.Sequence)
+	}
+
+	if file.seqFile.Matrix != nil {
+		return nil, errors.Errorf("%s:%d: sub-sequence (%s) cannot have a matrix", path, sf.line, sf.Sequence)
+	}
+
+	seq, problems := s.buildSequence(file)
+	if len(problems) > 0 {
+		// The problems are reported again with the referenced file's own location.
+		return nil, errors.Errorf("%s:%d: invalid sub-sequence (%s)", path, sf.line, sf.Sequence)
+	}
+
+	return flow.NewSubSequence(seq), nil
+}
+
+// addCycle records a cycle found in a sub-sequence that leads back to the sequence being built from its own file,
+// so each sequence in a cycle reports it once. A sequence that references itself reports the cycle directly.
+func (s *sequenceLoader) addCycle(name, problem string) {
+	if len(s.building) < 2 || s.building[0] != name || slices.Contains(s.cycles, problem) {
+		return
+	}
+
+	s.cycles = append(s.cycles, problem)
+}
+
+func isSequenceFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+
+	for _, e := range _sequenceFileExtensions {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// params are the parameters passed to a state factory from a sequence file.
+type params struct {
+	path   string
+	values map[string]*yaml.Node
+	used   map[string]bool
+}
+
+func newParams(path string, node *yaml.Node) (*params, error) {
+	p := &params{
+		path:   path,
+		values: make(map[string]*yaml.Node),
+		used:   make(map[string]bool),
+	}
+
+	// Params were not provided.
+	if node.Kind == 0 {
+		return p, nil
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil, errors.Errorf("%s:%d: params must be a mapping", path, node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		p.values[node.Content[i].Value] = node.Content[i+1]
+	}
+
+	return p, nil
+}
+
+// param decodes the parameter with the given key, returning def if it was not provided.
+func param[T any](p *params, key string, def T) (T, error) {
+	node, ok := p.values[key]
+	if !ok {
+		return def, nil
+	}
+
+	p.used[key] = true
+
+	var value T
+
+	err := node.Decode(&value)
+	if err != nil {
+		return def, errors.Errorf("%s:%d: invalid param (%s) value (%s) expected type (%T)",
+			p.path, node.Line, key, node.Value, def)
+	}
+
+	return value, nil
+}
+
+func (p *params) checkUnused(stateName string) error {
+	for key, node := range p.values {
+		if !p.used[key] {
+			return errors.Errorf("%s:%d: unknown param (%s) for state (%s)", p.path, node.Line, key, stateName)
+		}
+	}
+
+	return nil
+}
+
+// overrideState replaces the timeout, continue on fail and retry behaviour of a state as set in a sequence file.
+type overrideState struct {
+	flow.State
+
+	timeout        *time.Duration
+	continueOnFail *bool
+	retry          *retryFile
+}
+
+// Timeout returns the overridden timeout if set, otherwise the state's own timeout.
+func (o *overrideState) Timeout() time.Duration {
+	if o.timeout != nil {
+		return *o.timeout
+	}
+
+	return o.State.Timeout()
+}
+
+// ContinueOnFail returns the overridden value if set, otherwise the state's own value.
+func (o *overrideState) ContinueOnFail() bool {
+	if o.continueOnFail != nil {
+		return *o.continueOnFail
+	}
+
+	return o.State.ContinueOnFail()
+}
+
+// ResultsByPath forwards the results of composite states such as sub-sequences. Other states produce their results
+// themselves, at the empty path.
+func (o *overrideState) ResultsByPath() map[string]map[flow.Tag]any {
+	pather, ok := o.State.(flow.ResultPather)
+	if !ok {
+		return map[string]map[flow.Tag]any{"": o.State.GetResults()}
+	}
+
+	return pather.ResultsByPath()
+}
+
+// Declaration forwards the declaration of the state, it is empty if the state does not declare anything.
+func (o *overrideState) Declaration() flow.Declaration {
+	declaration, _ := flow.DeclarationOf(o.State)
+
+	return declaration
+}
+
+// RetryPolicy returns the overridden retry policy if set, otherwise the state's own policy.
+func (o *overrideState) RetryPolicy() flow.RetryPolicy {
+	policy := flow.RetryPolicy{MaxAttempts: 1}
+
+	retrier, ok := o.State.(flow.Retrier)
+	if ok {
+		policy = retrier.RetryPolicy()
+	}
+
+	if o.retry == nil {
+		return policy
+	}
+
+	policy.MaxAttempts = o.retry.MaxAttempts
+	policy.Backoff = backoff.NewConstantBackOff(o.retry.Backoff)
+
+	return policy
+}
@@ -0,0 +1,369 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/flow"
+)
+
+// writeSequenceFiles writes each file to a new directory and returns its path.
+func writeSequenceFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, contents := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644)
+		require.NoError(t, err)
+	}
+
+	return dir
+}
+
+func TestLoadSequences(t *testing.T) {
+	dir := writeSequenceFiles(t, map[string]string{
+		"lv.yaml": `name: "lv"
+requiredMetadata: [operator]
+states:
+  - state: init_state
+  - state: sleep_state
+    params:
+      duration: 2s
+  - state: sleep_state
+  - sequence: "startup"
+    timeout: 30s
+teardown:
+  - state: cleanup_state
+`,
+		"startup.yml": `name: "startup"
+group: true
+requiredMetadata: [firmware_commit]
+states:
+  - state: do_nothing_state
+teardown:
+  - state: sleep_state
+    params:
+      duration: 10ms
+`,
+		"notes.txt": "not a sequence file",
+	})
+
+	sequences, err := LoadSequences(dir, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	// Group sequences are only used as sub-sequences.
+	require.Len(t, sequences, 1)
+
+	seq := sequences[0]
+	assert.Equal(t, "lv", seq.Name)
+	assert.Equal(t, []string{"firmware_commit", "operator"}, seq.RequiredMetadata)
+
+	require.Len(t, seq.States, 4)
+	assert.Equal(t, "init_state", seq.States[0].Name())
+	assert.Equal(t, 2*time.Second, seq.States[1].(*sleep).sleepTime)
+	assert.Equal(t, time.Second, seq.States[2].(*sleep).sleepTime, "missing params use the default")
+
+	override, ok := seq.States[3].(*overrideState)
+	require.True(t, ok, "states with overrides are wrapped")
+	assert.Equal(t, 30*time.Second, override.Timeout())
+
+	subSequence, ok := override.State.(*flow.SubSequence)
+	require.True(t, ok)
+	require.Len(t, subSequence.Sequence().Teardown, 1)
+	assert.Equal(t, 10*time.Millisecond, subSequence.Sequence().Teardown[0].(*sleep).sleepTime)
+
+	require.Len(t, seq.Teardown, 1)
+	assert.Equal(t, "cleanup_state", seq.Teardown[0].Name())
+}
+
+func TestLoadSequencesNewSubSequencePerUse(t *testing.T) {
+	dir := writeSequenceFiles(t, map[string]string{
+		"lv.yaml": `name: "lv"
+states:
+  - sequence: "startup"
+  - sequence: "startup"
+`,
+		"startup.yaml": `name: "startup"
+group: true
+states:
+  - state: do_nothing_state
+`,
+	})
+
+	sequences, err := LoadSequences(dir, nil, zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, sequences, 1)
+	require.Len(t, sequences[0].States, 2)
+
+	first := sequences[0].States[0].(*flow.SubSequence).Sequence().States[0]
+	second := sequences[0].States[1].(*flow.SubSequence).Sequence().States[0]
+	assert.NotSame(t, first, second)
+}
+
+func TestLoadSequencesProblems(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		// want are the problems expected in the error, each prefixed with the path of its file in the test dir.
+		want []string
+	}{
+		{
+			name: "unknown state",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: init_state
+  - state: warp_drive
+`},
+			want: []string{"a.yaml:4: unknown state (warp_drive) valid options"},
+		},
+		{
+			name: "unknown teardown state",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: init_state
+teardown:
+  - state: cleanup_state
+  - state: warp_drive
+`},
+			want: []string{"a.yaml:6: unknown state (warp_drive)"},
+		},
+		{
+			name: "unknown teardown sequence",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: init_state
+teardown:
+  - sequence: "shutdown"
+`},
+			want: []string{"a.yaml:5: unknown sequence (shutdown)"},
+		},
+		{
+			name: "bad param type",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: sleep_state
+    params:
+      duration: fast
+`},
+			want: []string{"a.yaml:5: invalid param (duration) value (fast) expected type (time.Duration)"},
+		},
+		{
+			name: "unknown param",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: sleep_state
+    params:
+      duration: 2s
+      speed: 2
+`},
+			want: []string{"a.yaml:6: unknown param (speed) for state (sleep_state)"},
+		},
+		{
+			name: "param for state without params",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: init_state
+    params:
+      duration: 2s
+`},
+			want: []string{"a.yaml:5: unknown param (duration) for state (init_state)"},
+		},
+		{
+			name: "params not a mapping",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: sleep_state
+    params: 2s
+`},
+			want: []string{"a.yaml:4: params must be a mapping"},
+		},
+		{
+			name:  "missing name and states",
+			files: map[string]string{"a.yaml": `desc: "nothing to run"` + "\n"},
+			want: []string{
+				"a.yaml: sequence name is required",
+				"a.yaml: sequence must have at least one state",
+			},
+		},
+		{
+			name: "state and sequence",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: init_state
+    sequence: "b"
+`},
+			want: []string{"a.yaml:3: state (init_state) and sequence (b) cannot both be set"},
+		},
+		{
+			name: "sub-sequence cycle",
+			files: map[string]string{
+				"a.yaml": `name: "a"
+states:
+  - sequence: "b"
+`,
+				"b.yaml": `name: "b"
+group: true
+states:
+  - state: init_state
+  - sequence: "a"
+`,
+			},
+			want: []string{
+				"b.yaml:5: sub-sequence cycle (a -> b -> a)",
+				"a.yaml:3: invalid sub-sequence (b)",
+				"a.yaml:3: sub-sequence cycle (b -> a -> b)",
+				"b.yaml:5: invalid sub-sequence (a)",
+			},
+		},
+		{
+			name: "sub-sequence cycle below the sequence",
+			files: map[string]string{
+				"a.yaml": `name: "a"
+group: true
+states:
+  - sequence: "b"
+`,
+				"b.yaml": `name: "b"
+group: true
+states:
+  - sequence: "a"
+`,
+				"lv.yaml": `name: "lv"
+states:
+  - state: init_state
+  - sequence: "a"
+`,
+			},
+			want: []string{
+				"lv.yaml:4: invalid sub-sequence (a)",
+				"b.yaml:4: sub-sequence cycle (a -> b -> a)",
+				"a.yaml:4: sub-sequence cycle (b -> a -> b)",
+			},
+		},
+		{
+			name: "sequence references itself",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: init_state
+  - sequence: "a"
+`},
+			want: []string{"a.yaml:4: sub-sequence cycle (a -> a)"},
+		},
+		{
+			name: "unknown sequence",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - sequence: "b"
+`},
+			want: []string{"a.yaml:3: unknown sequence (b)"},
+		},
+		{
+			name: "sub-sequence with matrix",
+			files: map[string]string{
+				"a.yaml": `name: "a"
+states:
+  - sequence: "b"
+`,
+				"b.yaml": `name: "b"
+states:
+  - state: init_state
+matrix:
+  speed: [1, 2]
+`,
+			},
+			want: []string{"a.yaml:3: sub-sequence (b) cannot have a matrix"},
+		},
+		{
+			name: "sub-sequence with params",
+			files: map[string]string{
+				"a.yaml": `name: "a"
+states:
+  - sequence: "b"
+    params:
+      duration: 2s
+`,
+				"b.yaml": `name: "b"
+group: true
+states:
+  - state: init_state
+`,
+			},
+			want: []string{"a.yaml:3: sub-sequence (b) cannot have params"},
+		},
+		{
+			name: "group with matrix",
+			files: map[string]string{"a.yaml": `name: "a"
+group: true
+states:
+  - state: init_state
+matrix:
+  speed: [1, 2]
+`},
+			want: []string{"a.yaml: group sequence cannot have a matrix"},
+		},
+		{
+			name: "matrix parameter without values",
+			files: map[string]string{"a.yaml": `name: "a"
+states:
+  - state: init_state
+matrix:
+  speed: []
+`},
+			want: []string{"a.yaml: matrix parameter (speed) has no values"},
+		},
+		{
+			name: "duplicate name",
+			files: map[string]string{
+				"a.yaml": `name: "a"
+states:
+  - state: init_state
+`,
+				"b.yaml": `name: "a"
+states:
+  - state: init_state
+`,
+			},
+			want: []string{"b.yaml: sequence name (a) is already used by"},
+		},
+		{
+			name:  "invalid yaml",
+			files: map[string]string{"a.yaml": "states: [\n"},
+			want:  []string{"a.yaml: yaml:"},
+		},
+		{
+			name: "problems in several files",
+			files: map[string]string{
+				"a.yaml": `name: "a"
+states:
+  - state: warp_drive
+`,
+				"b.json": `{"name": "b", "states": [{"state": "sleep_state", "params": {"duration": "slow"}}]}`,
+			},
+			want: []string{
+				"a.yaml:3: unknown state (warp_drive)",
+				"b.json:1: invalid param (duration) value (slow) expected type (time.Duration)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeSequenceFiles(t, tt.files)
+
+			sequences, err := LoadSequences(dir, nil, zap.NewNop())
+			require.Error(t, err)
+			assert.Nil(t, sequences)
+
+			for _, problem := range tt.want {
+				assert.Contains(t, err.Error(), filepath.Join(dir, problem))
+			}
+		})
+	}
+}
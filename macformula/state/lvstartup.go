@@ -4,13 +4,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/cenkalti/backoff"
 	"go.uber.org/zap"
 
 	"github.com/macformula/hil/flow"
 	"github.com/macformula/hil/macformula"
 	"github.com/macformula/hil/macformula/config"
-	"github.com/macformula/hil/macformula/ecu/frontcontroller"
-	"github.com/macformula/hil/macformula/ecu/lvcontroller"
 	"github.com/macformula/hil/macformula/pinout"
 	"github.com/macformula/hil/utils"
 	"github.com/pkg/errors"
@@ -21,6 +20,8 @@ const (
 	_lvStartupPollTimeout    = 10 * time.Second
 	_lvStartupContinueOnFail = true
 	_lvStartupTimeout        = 2 * time.Minute
+	_lvStartupMaxAttempts    = 3
+	_lvStartupRetryBackoff   = 5 * time.Second
 
 	_hvPositiveOn   = true
 	_hvPositiveOff  = false
@@ -30,12 +31,43 @@ const (
 	_inverterEnable = true
 )
 
+// powerCycler power cycles the test bench, it is implemented by macformula.TestBench.
+type powerCycler interface {
+	PowerCycle() error
+}
+
+// lvController reads and drives the lv controller pins, it is implemented by lvcontroller.Client.
+type lvController interface {
+	ReadDigital(pin pinout.PhysicalIo) (bool, error)
+	SetDcdcValid(b bool) error
+}
+
+// contactorCommander sends front controller commands on the veh bus, it is implemented by frontcontroller.Client.
+type contactorCommander interface {
+	CommandContactors(ctx context.Context, hvPositive, hvNegative, precharge bool) error
+	CommandInverter(ctx context.Context, enable bool) error
+}
+
+// retryableError marks a failure that happened before the hv contactors were commanded closed, so the test bench
+// can safely be power cycled again.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+
+func (e *retryableError) Unwrap() error { return e.err }
+
+func retryable(err error) error {
+	return &retryableError{err: err}
+}
+
 type lvStartup struct {
 	l  *zap.Logger
 	a  *macformula.App
-	tb *macformula.TestBench
-	lv *lvcontroller.Client
-	fc *frontcontroller.Client
+	tb powerCycler
+	lv lvController
+	fc contactorCommander
 
 	fatalErr utils.ResettableError
 
@@ -57,7 +89,25 @@ func (l *lvStartup) Name() string {
 	return _lvStartupName
 }
 
+// RetryPolicy retries the state when the test bench fails to power cycle or a pin cannot be polled. Failures once
+// the contactors have been commanded closed are not retried, since a retry power cycles the test bench.
+func (l *lvStartup) RetryPolicy() flow.RetryPolicy {
+	return flow.RetryPolicy{
+		MaxAttempts: _lvStartupMaxAttempts,
+		Backoff:     backoff.NewConstantBackOff(_lvStartupRetryBackoff),
+		IsRetryable: func(err error) bool {
+			var retryableErr *retryableError
+
+			return errors.As(err, &retryableErr)
+		},
+	}
+}
+
+// Setup clears the results and fatal error of a previous attempt.
 func (l *lvStartup) Setup(_ context.Context) error {
+	l.fatalErr.Reset()
+	l.results = map[flow.Tag]any{}
+
 	return nil
 }
 
@@ -71,55 +121,55 @@ func (l *lvStartup) Run(ctx context.Context) error {
 	if err != nil {
 		r[tags.PowerCycledTestBench] = false
 
-		return errors.Wrap(err, "power cycle")
+		return retryable(errors.Wrap(err, "power cycle"))
 	}
 
 	r[tags.PowerCycledTestBench] = true
 
-	r[tags.TsalEnabled], r[tags.TsalTimeToEnableMs], err = pollPinMs(ctx, *l.lv, pinout.TsalEn, _lvStartupPollTimeout)
+	r[tags.TsalEnabled], r[tags.TsalTimeToEnableMs], err = pollPinMs(ctx, l.lv, pinout.TsalEn, _lvStartupPollTimeout)
 	if err != nil {
-		return errors.Wrap(err, "poll read tsal on")
+		return retryable(errors.Wrap(err, "poll read tsal on"))
 	}
 
-	r[tags.RaspiEnabled], r[tags.RaspiTimeToEnableMs], err = pollPinMs(ctx, *l.lv, pinout.RaspiEn, _lvStartupPollTimeout)
+	r[tags.RaspiEnabled], r[tags.RaspiTimeToEnableMs], err = pollPinMs(ctx, l.lv, pinout.RaspiEn, _lvStartupPollTimeout)
 	if err != nil {
-		return errors.Wrap(err, "poll read raspi on")
+		return retryable(errors.Wrap(err, "poll read raspi on"))
 	}
 
 	r[tags.FrontControllerEnabled], r[tags.FrontControllerTimeToEnableMs], err =
-		pollPinMs(ctx, *l.lv, pinout.FrontControllerEn, _lvStartupPollTimeout)
+		pollPinMs(ctx, l.lv, pinout.FrontControllerEn, _lvStartupPollTimeout)
 	if err != nil {
-		return errors.Wrap(err, "poll read front controller on")
+		return retryable(errors.Wrap(err, "poll read front controller on"))
 	}
 
 	r[tags.SpeedgoatEnabled], r[tags.SpeedgoatTimeToEnableMs], err =
-		pollPinMs(ctx, *l.lv, pinout.SpeedgoatEn, _lvStartupPollTimeout)
+		pollPinMs(ctx, l.lv, pinout.SpeedgoatEn, _lvStartupPollTimeout)
 	if err != nil {
-		return errors.Wrap(err, "poll read speedgoat on")
+		return retryable(errors.Wrap(err, "poll read speedgoat on"))
 	}
 
 	r[tags.AccumulatorEnabled], r[tags.AccumulatorTimeToEnableMs], err =
-		pollPinMs(ctx, *l.lv, pinout.AccumulatorEn, _lvStartupPollTimeout)
+		pollPinMs(ctx, l.lv, pinout.AccumulatorEn, _lvStartupPollTimeout)
 	if err != nil {
-		return errors.Wrap(err, "poll read accumulator on")
+		return retryable(errors.Wrap(err, "poll read accumulator on"))
 	}
 
 	r[tags.MotorPrechageEnabled], r[tags.MotorPrechargeTimeToEnableMs], err =
-		pollPinMs(ctx, *l.lv, pinout.MotorControllerPrechargeEn, _lvStartupPollTimeout)
+		pollPinMs(ctx, l.lv, pinout.MotorControllerPrechargeEn, _lvStartupPollTimeout)
 	if err != nil {
-		return errors.Wrap(err, "poll read motor controller precharge on")
+		return retryable(errors.Wrap(err, "poll read motor controller precharge on"))
 	}
 
 	r[tags.MotorControllerEnabled], r[tags.MotorControllerTimeToEnable], err =
-		pollPinMs(ctx, *l.lv, pinout.MotorControllerEn, _lvStartupPollTimeout)
+		pollPinMs(ctx, l.lv, pinout.MotorControllerEn, _lvStartupPollTimeout)
 	if err != nil {
-		return errors.Wrap(err, "poll read motor controller on")
+		return retryable(errors.Wrap(err, "poll read motor controller on"))
 	}
 
 	r[tags.MotorPrechargeDisabled], r[tags.MotorPrechargeTimeToDisableMs], err =
-		pollPinMs(ctx, *l.lv, pinout.MotorControllerPrechargeEn, _lvStartupPollTimeout, utils.CheckForFalse())
+		pollPinMs(ctx, l.lv, pinout.MotorControllerPrechargeEn, _lvStartupPollTimeout, utils.CheckForFalse())
 	if err != nil {
-		return errors.Wrap(err, "poll read motor controller precharge on")
+		return retryable(errors.Wrap(err, "poll read motor controller precharge on"))
 	}
 
 	time.Sleep(2 * time.Second)
@@ -188,7 +238,10 @@ func (l *lvStartup) Run(ctx context.Context) error {
 
 	err = l.fc.CommandContactors(ctx, _hvPositiveOn, _hvNegativeOn, _prechargeOff)
 	if err != nil {
-		return errors.Wrap(err, "command contactors")
+		err = errors.Wrap(err, "command contactors")
+		l.setContactorsFatal(err)
+
+		return err
 	}
 
 	time.Sleep(1 * time.Second)
@@ -216,11 +269,14 @@ func (l *lvStartup) Run(ctx context.Context) error {
 
 	err = l.fc.CommandInverter(ctx, _inverterEnable)
 	if err != nil {
-		return errors.Wrap(err, "command inverter")
+		err = errors.Wrap(err, "command inverter")
+		l.setContactorsFatal(err)
+
+		return err
 	}
 
 	r[tags.InverterSwitchEnabled], r[tags.InverterSwitchTimeToEnable], err = pollPinMs(
-		ctx, *l.lv, pinout.InverterSwitchEn, _lvStartupPollTimeout)
+		ctx, l.lv, pinout.InverterSwitchEn, _lvStartupPollTimeout)
 	if err != nil {
 		return errors.Wrap(err, "poll read inverter switch on")
 	}
@@ -228,6 +284,28 @@ func (l *lvStartup) Run(ctx context.Context) error {
 	return nil
 }
 
+// Declaration lists every tag the state submits and the pins used through the lv controller and test bench.
+// The test bench only reads the lv controller voltage if configured to, so it is not declared.
+func (l *lvStartup) Declaration() flow.Declaration {
+	return flow.Declaration{
+		Tags: config.LvStartupTags.All(),
+		PhysicalIo: physicalIoNames(
+			pinout.GlvmsDisable,
+			pinout.TsalEn,
+			pinout.RaspiEn,
+			pinout.FrontControllerEn,
+			pinout.SpeedgoatEn,
+			pinout.AccumulatorEn,
+			pinout.MotorControllerPrechargeEn,
+			pinout.MotorControllerEn,
+			pinout.ShutdownCircuitEn,
+			pinout.InverterSwitchEn,
+			pinout.DcdcEn,
+			pinout.DcdcValid,
+		),
+	}
+}
+
 func (l *lvStartup) GetResults() map[flow.Tag]any {
 	return l.results
 }
@@ -244,6 +322,15 @@ func (l *lvStartup) FatalError() error {
 	return l.fatalErr.Err()
 }
 
+// setContactorsFatal sets a fatal error for failures after the contactors were commanded closed, since the bench
+// may still be energized.
+func (l *lvStartup) setContactorsFatal(err error) {
+	l.fatalErr.Set(flow.WithRecovery(err, l.a.RecoveryProcedure(
+		"Check the hv contactors are open and the accumulator is isolated",
+		"Check the front controller is connected to the veh can bus",
+	)))
+}
+
 func (l *lvStartup) sendContactorCommandCheckShutdownOn(ctx context.Context,
 	hvPositive, hvNegative, precharge bool, timeout time.Duration, period time.Duration) (bool, error) {
 	timeoutChan := time.After(timeout)
@@ -275,7 +362,7 @@ func (l *lvStartup) sendContactorCommandCheckShutdownOn(ctx context.Context,
 }
 
 // pollMs polls the checkFunc until it returns true or the timeout is reached. It wraps the utils.Poll function
-// as the results cannot be time.Duration values (so it converts these to int.
+// and converts the duration to whole milliseconds.
 func pollMs(ctx context.Context,
 	checkFunc utils.CheckFunc,
 	timeout time.Duration,
@@ -285,7 +372,7 @@ func pollMs(ctx context.Context,
 }
 
 func pollPinMs(ctx context.Context,
-	client lvcontroller.Client,
+	client lvController,
 	pin pinout.PhysicalIo,
 	timeout time.Duration,
 	opts ...utils.PollOption) (bool, int, error) {
@@ -0,0 +1,137 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula"
+	"github.com/macformula/hil/macformula/config"
+	"github.com/macformula/hil/macformula/pinout"
+)
+
+type stubPowerCycler struct {
+	err error
+}
+
+func (s *stubPowerCycler) PowerCycle() error { return s.err }
+
+// stubLvController reads every pin as on, except the motor controller precharge which toggles so it can be polled on
+// and then off, and failPin which fails to read.
+type stubLvController struct {
+	failPin   pinout.PhysicalIo
+	precharge bool
+}
+
+func (s *stubLvController) ReadDigital(pin pinout.PhysicalIo) (bool, error) {
+	switch pin {
+	case s.failPin:
+		return false, errors.Errorf("read (%s)", pin)
+	case pinout.MotorControllerPrechargeEn:
+		s.precharge = !s.precharge
+		return s.precharge, nil
+	default:
+		return true, nil
+	}
+}
+
+func (s *stubLvController) SetDcdcValid(bool) error { return nil }
+
+type stubContactorCommander struct {
+	inverterErr error
+}
+
+func (s *stubContactorCommander) CommandContactors(context.Context, bool, bool, bool) error {
+	return nil
+}
+
+func (s *stubContactorCommander) CommandInverter(context.Context, bool) error { return s.inverterErr }
+
+// lvStartupAttempt sets up how the test bench behaves during one attempt of lv startup.
+type lvStartupAttempt struct {
+	powerCycleErr error
+	failPin       pinout.PhysicalIo
+	inverterErr   error
+}
+
+func TestLvStartupRetry(t *testing.T) {
+	tags := config.LvStartupTags
+
+	tests := []struct {
+		name      string
+		attempts  []lvStartupAttempt
+		wantErr   bool
+		retryable bool
+		check     func(t *testing.T, results map[flow.Tag]any)
+	}{
+		{
+			name: "fails then succeeds",
+			attempts: []lvStartupAttempt{
+				{powerCycleErr: errors.New("relay stuck")},
+				{},
+			},
+			check: func(t *testing.T, results map[flow.Tag]any) {
+				assert.Equal(t, true, results[tags.PowerCycledTestBench])
+				assert.Equal(t, true, results[tags.TsalEnabled])
+				assert.Equal(t, true, results[tags.InverterSwitchEnabled])
+			},
+		},
+		{
+			name: "results of an earlier attempt are cleared",
+			attempts: []lvStartupAttempt{
+				{failPin: pinout.RaspiEn},
+				{powerCycleErr: errors.New("relay stuck")},
+			},
+			wantErr:   true,
+			retryable: true,
+			check: func(t *testing.T, results map[flow.Tag]any) {
+				assert.Equal(t, map[flow.Tag]any{tags.PowerCycledTestBench: false}, results)
+			},
+		},
+		{
+			name:      "pin poll failure is retryable",
+			attempts:  []lvStartupAttempt{{failPin: pinout.TsalEn}},
+			wantErr:   true,
+			retryable: true,
+		},
+		{
+			name:     "failure after the contactors close is not retryable",
+			attempts: []lvStartupAttempt{{inverterErr: errors.New("veh bus down")}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := &lvStartup{l: zap.NewNop(), a: &macformula.App{}, results: map[flow.Tag]any{}}
+
+			var err error
+
+			for _, attempt := range tt.attempts {
+				state.tb = &stubPowerCycler{err: attempt.powerCycleErr}
+				state.lv = &stubLvController{failPin: attempt.failPin}
+				state.fc = &stubContactorCommander{inverterErr: attempt.inverterErr}
+
+				require.NoError(t, state.Setup(ctx))
+				err = state.Run(ctx)
+			}
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tt.retryable, state.RetryPolicy().IsRetryable(err))
+			}
+
+			if tt.check != nil {
+				tt.check(t, state.GetResults())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package state
+
+import (
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula"
+	"github.com/macformula/hil/macformula/pinout"
+)
+
+const (
+	_basicIoStateName = "basic_io"
+	_sleepParamKey    = "duration"
+)
+
+// stateFactory builds a state from the parameters given to it in a sequence file.
+type stateFactory = func(a *macformula.App, l *zap.Logger, p *params) (flow.State, error)
+
+// _stateRegistry maps the state names usable in sequence files to their constructors.
+var _stateRegistry = map[string]stateFactory{
+	_initStateName: func(a *macformula.App, l *zap.Logger, _ *params) (flow.State, error) {
+		return newSetup(a, l), nil
+	},
+	_cleanupStateName: func(a *macformula.App, l *zap.Logger, _ *params) (flow.State, error) {
+		return newCleanup(a, l), nil
+	},
+	_name: func(_ *macformula.App, _ *zap.Logger, _ *params) (flow.State, error) {
+		return newNothing(), nil
+	},
+	_sleepStateName: func(_ *macformula.App, _ *zap.Logger, p *params) (flow.State, error) {
+		sleepTime, err := param(p, _sleepParamKey, time.Second)
+		if err != nil {
+			return nil, err
+		}
+
+		return newSleep(sleepTime), nil
+	},
+	_lvStartupName: func(a *macformula.App, l *zap.Logger, _ *params) (flow.State, error) {
+		return newLvStartup(a, l), nil
+	},
+	_basicIoStateName: func(a *macformula.App, l *zap.Logger, _ *params) (flow.State, error) {
+		return newBasicIo(a, l), nil
+	},
+}
+
+// physicalIoNames converts physical IO to the names used in a flow.Declaration.
+func physicalIoNames(ios ...pinout.PhysicalIo) []string {
+	ret := make([]string, len(ios))
+	for i, io := range ios {
+		ret[i] = io.String()
+	}
+
+	return ret
+}
+
+// RegisteredStates returns the names of all states that can be used in sequence files.
+func RegisteredStates() []string {
+	names := make([]string, 0, len(_stateRegistry))
+	for name := range _stateRegistry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -30,71 +30,53 @@ var _sequenceConstructors = []sequenceConstructor{
 	newBasicIoSequence,
 }
 
-func newLvControllerSequence(a *macformula.App, l *zap.Logger) flow.Sequence {
+// newBenchSequence returns a sequence that sets up the test bench, runs states, then cleans up the test bench.
+func newBenchSequence(a *macformula.App, l *zap.Logger, name, desc string, states ...flow.State) flow.Sequence {
 	return flow.Sequence{
-		Name: "Lv Controller Sequence ⚡",
-		Desc: "Tests the lv controller.",
-		States: []flow.State{
-			newSetup(a, l),
-			newLvStartup(a, l),
+		Name:   name,
+		Desc:   desc,
+		States: append([]flow.State{newSetup(a, l)}, states...),
+		Teardown: []flow.State{
 			newCleanup(a, l),
 		},
 	}
 }
 
+func newLvControllerSequence(a *macformula.App, l *zap.Logger) flow.Sequence {
+	return newBenchSequence(a, l, "Lv Controller Sequence ⚡", "Tests the lv controller.",
+		newLvStartup(a, l),
+	)
+}
+
 func newTracerSequence(a *macformula.App, l *zap.Logger) flow.Sequence {
-	return flow.Sequence{
-		Name: "Can Tracer ✍️",
-		Desc: "Obtains a can trace",
-		States: []flow.State{
-			newSetup(a, l),
-			newSleep(10 * time.Second),
-			newCleanup(a, l),
-		},
-	}
+	return newBenchSequence(a, l, "Can Tracer ✍️", "Obtains a can trace",
+		newSleep(10*time.Second),
+	)
 }
 
 func newDoNothingSequence(a *macformula.App, l *zap.Logger) flow.Sequence {
-	return flow.Sequence{
-		Name: "Do Nothing 🥱",
-		Desc: "Wow... it does nothing",
-		States: []flow.State{
-			newSetup(a, l),
-			newNothing(),
-			newNothing(),
-			newNothing(),
-			newNothing(),
-			newNothing(),
-			newNothing(),
-			newNothing(),
-			newCleanup(a, l),
-		},
-	}
+	return newBenchSequence(a, l, "Do Nothing 🥱", "Wow... it does nothing",
+		newNothing(),
+		newNothing(),
+		newNothing(),
+		newNothing(),
+		newNothing(),
+		newNothing(),
+		newNothing(),
+	)
 }
 
 func newSleepSequence(a *macformula.App, l *zap.Logger) flow.Sequence {
-	return flow.Sequence{
-		Name: "Sleeper 💤",
-		Desc: "zzz",
-		States: []flow.State{
-			newSetup(a, l),
-			newSleep(1 * time.Second),
-			newSleep(5 * time.Second),
-			newSleep(2 * time.Second),
-			newSleep(1 * time.Second),
-			newCleanup(a, l),
-		},
-	}
+	return newBenchSequence(a, l, "Sleeper 💤", "zzz",
+		newSleep(1*time.Second),
+		newSleep(5*time.Second),
+		newSleep(2*time.Second),
+		newSleep(1*time.Second),
+	)
 }
 
 func newBasicIoSequence(a *macformula.App, l *zap.Logger) flow.Sequence {
-	return flow.Sequence{
-		Name: "BasicIo 🧪",
-		Desc: "Test sequence for BasicIo firmware project.",
-		States: []flow.State{
-			newSetup(a, l),
-			newBasicIo(a, l),
-			newCleanup(a, l),
-		},
-	}
+	return newBenchSequence(a, l, "BasicIo 🧪", "Test sequence for BasicIo firmware project.",
+		newBasicIo(a, l),
+	)
 }
@@ -3,11 +3,12 @@ package state
 import (
 	"context"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/macformula/hil/flow"
 	"github.com/macformula/hil/macformula"
+	"github.com/macformula/hil/results"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -39,12 +40,17 @@ func (s *setup) Setup(ctx context.Context) error {
 func (s *setup) Run(ctx context.Context) error {
 	s.app.CurrProcess = macformula.NewProcessInfo()
 
-	sequenceResultsDir := filepath.Join(s.app.Config.ResultsDir, time.Now().Format("15:04:05.0000"))
-	os.Mkdir(sequenceResultsDir, 0755)
+	// Traces are written straight into the test's bundle, the cleanup state attaches them once they are closed.
+	bundleDir := results.BundleDir(s.app.Config.ResultsDir, flow.CurrentTestId(ctx))
+
+	err := os.MkdirAll(bundleDir, 0755)
+	if err != nil {
+		return errors.Wrap(err, "create bundle dir")
+	}
 
 	if s.app.WithVcan {
-		s.app.VehCanTracer.SetTraceDir(sequenceResultsDir)
-		s.app.PtCanTracer.SetTraceDir(sequenceResultsDir)
+		s.app.VehCanTracer.SetTraceDir(bundleDir)
+		s.app.PtCanTracer.SetTraceDir(bundleDir)
 
 		s.app.VehBusManager.Register(s.app.VehCanTracer)
 		s.app.PtBusManager.Register(s.app.PtCanTracer)
@@ -53,8 +59,6 @@ func (s *setup) Run(ctx context.Context) error {
 		s.app.PtBusManager.Start(ctx)
 	}
 
-	s.app.ResultsProcessor.SetReportsDir(sequenceResultsDir)
-
 	return nil
 }
 
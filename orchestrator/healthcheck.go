@@ -0,0 +1,85 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/macformula/hil/flow"
+	"go.uber.org/zap"
+)
+
+// _healthCheckTimeout is how long a single health check has to complete.
+const _healthCheckTimeout = 30 * time.Second
+
+// HealthCheckIface checks that part of the bench works, for example that a controller responds or a can bus has
+// traffic. Health checks run before every test and after recovering from a fatal error.
+type HealthCheckIface interface {
+	// Name of the health check.
+	Name() string
+	// Check returns an error with the diagnosis if the bench is not healthy. It can attach a recovery procedure
+	// with flow.WithRecovery.
+	Check(ctx context.Context) error
+}
+
+// HealthCheckError is a failed health check. Tests that do not start because of it are marked with the BenchError
+// run status rather than failing.
+type HealthCheckError struct {
+	CheckName string
+	Err       error
+}
+
+func (e *HealthCheckError) Error() string {
+	return fmt.Sprintf("bench health check failed (%s): %v", e.CheckName, e.Err)
+}
+
+func (e *HealthCheckError) Unwrap() error {
+	return e.Err
+}
+
+// AddHealthChecks registers health checks, they run in the order they were added. It must be called before Open.
+func (o *Orchestrator) AddHealthChecks(checks ...HealthCheckIface) {
+	o.healthChecks = append(o.healthChecks, checks...)
+}
+
+// checkBenchHealth runs every health check, stopping at the first one that fails.
+func (o *Orchestrator) checkBenchHealth(ctx context.Context) error {
+	for _, check := range o.healthChecks {
+		checkCtx, cancel := context.WithTimeout(ctx, _healthCheckTimeout)
+		err := check.Check(checkCtx)
+
+		cancel()
+
+		if err != nil {
+			return &HealthCheckError{CheckName: check.Name(), Err: err}
+		}
+	}
+
+	return nil
+}
+
+// failBenchCheck ends a dequeued test that did not start because of a failed health check and puts the
+// orchestrator into FatalError with the diagnosis.
+func (o *Orchestrator) failBenchCheck(startSig StartSignal, benchErr error) {
+	o.l.Error("bench health check failed",
+		zap.String("test id", startSig.TestId.String()),
+		zap.Error(benchErr))
+
+	o.updateRun(startSig.TestId, func(run *RunRecord) {
+		run.Status = BenchError
+		run.EndedAt = time.Now()
+		run.BenchError = benchErr.Error()
+	})
+
+	o.resultFeed.Send(ResultsSignal{
+		TestId:     startSig.TestId,
+		IsPassing:  false,
+		FailedTags: make([]flow.Tag, 0),
+		BenchError: benchErr,
+		FatalError: benchErr,
+	})
+
+	o.fatalErr.Set(benchErr)
+	o.state = FatalError
+	o.statusUpdate()
+}
@@ -15,12 +15,26 @@ type SequencerIface interface {
 	Open(ctx context.Context) error
 	// SubscribeToProgress subscribes to the progress of the Sequencer across its Sequence runs.
 	SubscribeToProgress(progCh chan flow.Progress) event.Subscription
+	// SubscribeToMeasurements subscribes to the live samples published by the states of the running Sequence.
+	SubscribeToMeasurements(sampleCh chan flow.Sample) event.Subscription
 	// Run will run the sequence provided. FatalError must be called after Run to check for any non-recoverable errors.
 	Run(context.Context, flow.Sequence, chan struct{}, TestId) (bool, []flow.Tag, []error, error)
 	// FatalError indicates that there is an error that requires intervention.
 	FatalError() error
 	// ResetFatalError sets the fatal error to nil.
 	ResetFatalError()
+	// TeardownErrors returns the errors from the teardown states of the last Run.
+	TeardownErrors() []error
+	// ReportPaths returns the paths of the reports generated for the last Run.
+	ReportPaths() []string
+	// Pause halts the running sequence before its next state.
+	Pause()
+	// Resume continues a paused sequence.
+	Resume()
+	// Step runs the next state of a paused sequence, then pauses again.
+	Step()
+	// SetBreakpoints pauses the next Run before the states with the given names.
+	SetBreakpoints(stateNames []string)
 }
 
 // DispatcherIface is responsible for commanding start of execution.
@@ -34,12 +48,22 @@ type DispatcherIface interface {
 	Start() <-chan StartSignal
 	// CancelTest will cancel execution of the test with the given ID.
 	CancelTest() <-chan CancelTestSignal
+	// MoveTest will move a queued test to a new position in the test queue.
+	MoveTest() <-chan MoveTestSignal
 	// Shutdown will shut down the hil app.
 	Shutdown() <-chan ShutdownSignal
 	// RecoverFromFatal will tell the orchestrator to leave the fatal error state and go back to idle.
 	RecoverFromFatal() <-chan RecoverFromFatalSignal
+	// Pause will halt the running test before its next state.
+	Pause() <-chan PauseSignal
+	// Resume will continue a paused test.
+	Resume() <-chan ResumeSignal
+	// Step will run the next state of a paused test and pause again.
+	Step() <-chan StepSignal
 	// Status signal is sent on updates to the dispatchers.
 	Status() chan<- StatusSignal
 	// Results signal is sent at the end of a test execution or on test cancel.
 	Results() chan<- ResultsSignal
+	// Measurements signal is sent for every live sample published by the running test.
+	Measurements() chan<- MeasurementSignal
 }
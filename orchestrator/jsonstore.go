@@ -0,0 +1,219 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	_jsonStoreLoggerName = "json_store"
+	_jsonStoreFilePerm   = 0644
+)
+
+// JsonStore is the default StoreIface. It keeps every run in memory and rewrites a single JSON file on each change.
+// Use WithMaxRuns to bound the size of the file.
+type JsonStore struct {
+	l       *zap.Logger
+	path    string
+	maxRuns int
+
+	mtx  sync.Mutex
+	runs []RunRecord
+}
+
+type JsonStoreOption func(*JsonStore)
+
+// WithMaxRuns keeps at most maxRuns runs, dropping the oldest finished runs first. Pending runs are always kept so
+// they can be queued again. A value of 0 keeps every run.
+func WithMaxRuns(maxRuns int) JsonStoreOption {
+	return func(j *JsonStore) {
+		j.maxRuns = maxRuns
+	}
+}
+
+// NewJsonStore returns a JsonStore that persists runs to the file at path.
+func NewJsonStore(path string, l *zap.Logger, opts ...JsonStoreOption) *JsonStore {
+	j := &JsonStore{
+		l:    l.Named(_jsonStoreLoggerName),
+		path: path,
+		runs: make([]RunRecord, 0),
+	}
+
+	for _, o := range opts {
+		o(j)
+	}
+
+	return j
+}
+
+// Open loads previously persisted runs. A missing file is treated as an empty history.
+func (j *JsonStore) Open(_ context.Context) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		j.l.Info("no existing run history", zap.String("path", j.path))
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "read run history (%s)", j.path)
+	}
+
+	err = json.Unmarshal(data, &j.runs)
+	if err != nil {
+		return errors.Wrapf(err, "unmarshal run history (%s)", j.path)
+	}
+
+	j.trim()
+
+	return nil
+}
+
+// Close is a no-op, every change is written as it happens.
+func (j *JsonStore) Close() error {
+	return nil
+}
+
+// SaveRun inserts the run or updates the existing run with the same TestId.
+func (j *JsonStore) SaveRun(run RunRecord) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	updated := false
+
+	for i := range j.runs {
+		if j.runs[i].TestId == run.TestId {
+			j.runs[i] = run
+			updated = true
+
+			break
+		}
+	}
+
+	if !updated {
+		j.runs = append(j.runs, run)
+		j.trim()
+	}
+
+	return j.write()
+}
+
+// PendingRuns returns the runs that are Queued or Started, in the order they were queued.
+func (j *JsonStore) PendingRuns() ([]RunRecord, error) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	query := RunQuery{Statuses: []RunStatus{Queued, Started}}
+	pending := make([]RunRecord, 0)
+
+	for _, run := range j.runs {
+		if query.matches(run) {
+			pending = append(pending, run)
+		}
+	}
+
+	sort.SliceStable(pending, func(a, b int) bool {
+		return pending[a].QueuedAt.Before(pending[b].QueuedAt)
+	})
+
+	return pending, nil
+}
+
+// ListRuns returns the runs matching the query, most recently queued first.
+func (j *JsonStore) ListRuns(query RunQuery) ([]RunRecord, error) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	ret := make([]RunRecord, 0)
+
+	for _, run := range j.runs {
+		if query.matches(run) {
+			ret = append(ret, run)
+		}
+	}
+
+	sort.SliceStable(ret, func(a, b int) bool {
+		return ret[a].QueuedAt.After(ret[b].QueuedAt)
+	})
+
+	if query.Limit > 0 && len(ret) > query.Limit {
+		ret = ret[:query.Limit]
+	}
+
+	return ret, nil
+}
+
+// trim drops the oldest finished runs while there are more than maxRuns.
+func (j *JsonStore) trim() {
+	if j.maxRuns <= 0 || len(j.runs) <= j.maxRuns {
+		return
+	}
+
+	var (
+		pending  = RunQuery{Statuses: []RunStatus{Queued, Started}}
+		finished = make([]RunRecord, 0, len(j.runs))
+	)
+
+	for _, run := range j.runs {
+		if !pending.matches(run) {
+			finished = append(finished, run)
+		}
+	}
+
+	sort.SliceStable(finished, func(a, b int) bool {
+		return finished[a].QueuedAt.Before(finished[b].QueuedAt)
+	})
+
+	drop := make(map[TestId]struct{})
+	for _, run := range finished[:min(len(j.runs)-j.maxRuns, len(finished))] {
+		drop[run.TestId] = struct{}{}
+	}
+
+	kept := make([]RunRecord, 0, len(j.runs)-len(drop))
+
+	for _, run := range j.runs {
+		if _, ok := drop[run.TestId]; !ok {
+			kept = append(kept, run)
+		}
+	}
+
+	j.l.Debug("dropped oldest runs from run history", zap.Int("dropped", len(drop)), zap.Int("kept", len(kept)))
+
+	j.runs = kept
+}
+
+// write replaces the file atomically so a crash mid-write cannot corrupt the history.
+func (j *JsonStore) write() error {
+	data, err := json.MarshalIndent(j.runs, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal run history")
+	}
+
+	err = os.MkdirAll(filepath.Dir(j.path), 0755)
+	if err != nil {
+		return errors.Wrap(err, "create run history dir")
+	}
+
+	tmpPath := j.path + ".tmp"
+
+	err = os.WriteFile(tmpPath, data, _jsonStoreFilePerm)
+	if err != nil {
+		return errors.Wrap(err, "write run history")
+	}
+
+	err = os.Rename(tmpPath, j.path)
+	if err != nil {
+		return errors.Wrap(err, "rename run history")
+	}
+
+	return nil
+}
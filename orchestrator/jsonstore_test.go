@@ -0,0 +1,51 @@
+package orchestrator
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestJsonStoreKeepsMaxRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_history.json")
+	store := NewJsonStore(path, zap.NewNop(), WithMaxRuns(3))
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	runs := make([]RunRecord, 5)
+	for i := range runs {
+		runs[i] = RunRecord{TestId: uuid.New(), Status: Completed, QueuedAt: start.Add(time.Duration(i) * time.Minute)}
+	}
+
+	// The oldest run is still queued, so it must be kept.
+	runs[0].Status = Queued
+
+	for _, run := range runs {
+		require.NoError(t, store.SaveRun(run))
+	}
+
+	stored, err := store.ListRuns(RunQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []TestId{runs[4].TestId, runs[3].TestId, runs[0].TestId}, testIds(stored))
+
+	reopened := NewJsonStore(path, zap.NewNop(), WithMaxRuns(2))
+	require.NoError(t, reopened.Open(context.Background()))
+
+	stored, err = reopened.ListRuns(RunQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []TestId{runs[4].TestId, runs[0].TestId}, testIds(stored))
+}
+
+func testIds(runs []RunRecord) []TestId {
+	ret := make([]TestId, 0, len(runs))
+	for _, run := range runs {
+		ret = append(ret, run.TestId)
+	}
+
+	return ret
+}
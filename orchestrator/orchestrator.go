@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 const (
 	_loggerName                = "orchestrator"
 	_checkForStartSignalPeriod = 50 * time.Millisecond
+	_recoveryTimeout           = 5 * time.Minute
 )
 
 type Orchestrator struct {
@@ -30,6 +32,9 @@ type Orchestrator struct {
 	progCh   chan flow.Progress
 	progress flow.Progress
 
+	sampleSub event.Subscription
+	sampleCh  chan flow.Sample
+
 	testQueue []StartSignal
 
 	shutdownSig     chan ShutdownSignal
@@ -41,12 +46,23 @@ type Orchestrator struct {
 	statusFeed event.Feed
 	statusSubs []event.Subscription
 
+	measurementFeed event.Feed
+	measurementSubs []event.Subscription
+
 	cancelCurrentTest chan struct{}
 
 	testQueueMtx sync.Mutex
 	progressMtx  sync.Mutex
 
-	fatalErr *utils.ResettableError
+	fatalErr    *utils.ResettableError
+	recoveryErr *utils.ResettableError
+
+	healthChecks []HealthCheckIface
+
+	store     StoreIface
+	sequences map[string]flow.Sequence
+	runs      map[TestId]RunRecord
+	runsMtx   sync.Mutex
 }
 
 func NewOrchestrator(s SequencerIface, l *zap.Logger, dispatchers ...DispatcherIface) *Orchestrator {
@@ -60,22 +76,62 @@ func NewOrchestrator(s SequencerIface, l *zap.Logger, dispatchers ...DispatcherI
 		shutdownSig:       make(chan ShutdownSignal),
 		recoverFatalSig:   make(chan RecoverFromFatalSignal),
 		progCh:            make(chan flow.Progress),
-		cancelCurrentTest: make(chan struct{}),
+		sampleCh:          make(chan flow.Sample),
+		cancelCurrentTest: make(chan struct{}, 1),
 		testQueueMtx:      sync.Mutex{},
 		progressMtx:       sync.Mutex{},
 		fatalErr:          utils.NewResettaleError(),
+		recoveryErr:       utils.NewResettaleError(),
 		dispatchers:       dispatchers,
+		sequences:         make(map[string]flow.Sequence),
+		runs:              make(map[TestId]RunRecord),
 	}
 
 	return ret
 }
 
+// SetStore persists the test queue and run history to store. Sequences are used to look up the sequences of
+// pending runs by name when they are resumed on Open. It must be called before Open.
+func (o *Orchestrator) SetStore(store StoreIface, sequences []flow.Sequence) {
+	o.store = store
+
+	for _, seq := range sequences {
+		o.sequences[seq.Name] = seq
+	}
+}
+
+// ListRuns returns the past and pending runs matching the query from the store.
+func (o *Orchestrator) ListRuns(query RunQuery) ([]RunRecord, error) {
+	if o.store == nil {
+		return nil, errors.New("orchestrator has no store")
+	}
+
+	runs, err := o.store.ListRuns(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "list runs")
+	}
+
+	return runs, nil
+}
+
 func (o *Orchestrator) Open(ctx context.Context) error {
 	o.l.Info("orchestrator open")
 	if len(o.dispatchers) == 0 {
 		return errors.Errorf("orchestrator requires at least one dispatcher")
 	}
 
+	if o.store != nil {
+		err := o.store.Open(ctx)
+		if err != nil {
+			return errors.Wrap(err, "store open")
+		}
+
+		err = o.resumePendingRuns()
+		if err != nil {
+			return errors.Wrap(err, "resume pending runs")
+		}
+	}
+
 	err := o.sequencer.Open(ctx)
 	if err != nil {
 		return errors.Wrap(err, "sequencer open")
@@ -86,8 +142,14 @@ func (o *Orchestrator) Open(ctx context.Context) error {
 
 	go o.monitorProgress(ctx)
 
+	// Subscribe to live measurements of the running test
+	o.sampleSub = o.sequencer.SubscribeToMeasurements(o.sampleCh)
+
+	go o.monitorMeasurements(ctx)
+
 	o.resultSubs = make([]event.Subscription, len(o.dispatchers))
 	o.statusSubs = make([]event.Subscription, len(o.dispatchers))
+	o.measurementSubs = make([]event.Subscription, len(o.dispatchers))
 
 	// Setup dispatchers
 	for i, d := range o.dispatchers {
@@ -98,6 +160,7 @@ func (o *Orchestrator) Open(ctx context.Context) error {
 
 		o.resultSubs[i] = o.resultFeed.Subscribe(d.Results())
 		o.statusSubs[i] = o.statusFeed.Subscribe(d.Status())
+		o.measurementSubs[i] = o.measurementFeed.Subscribe(d.Measurements())
 
 		// Monitor DispatcherIface signals
 		go o.monitorDispatcher(ctx, d)
@@ -107,16 +170,16 @@ func (o *Orchestrator) Open(ctx context.Context) error {
 }
 
 func (o *Orchestrator) Run(ctx context.Context) error {
+	// Let dispatchers know the initial state, they do not receive a status until something changes otherwise.
+	o.statusUpdate()
+
 	for {
 		select {
 		case <-time.After(_checkForStartSignalPeriod):
 		case <-o.shutdownSig:
 			return nil
 		case <-o.recoverFatalSig:
-			o.sequencer.ResetFatalError()
-			o.fatalErr.Reset()
-			o.state = Idle
-			o.statusUpdate()
+			o.recoverFromFatal(ctx)
 		}
 
 		if o.state == FatalError {
@@ -127,7 +190,7 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 		startSig, ok := o.dequeueNextTest()
 		if !ok {
 			// We want to keep the running state until no more tests are in the queue.
-			if o.state == Running {
+			if o.state == Running || o.state == Paused {
 				o.state = Idle
 				o.statusUpdate()
 			}
@@ -135,25 +198,65 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 			continue
 		}
 
+		err := o.checkBenchHealth(ctx)
+		if err != nil {
+			o.failBenchCheck(startSig, err)
+
+			continue
+		}
+
+		// Drop a cancel that arrived after the previous test finished, it must not cancel this one.
+		o.drainCancel()
+
 		o.currentTest = startSig.TestId
 
+		o.sequencer.SetBreakpoints(startSig.Breakpoints)
+
 		o.state = Running
 		o.statusUpdate()
 
-		isPassing, failedTags, testErrors, err := o.sequencer.Run(ctx, startSig.Seq, o.cancelCurrentTest, o.currentTest)
+		o.updateRun(startSig.TestId, func(run *RunRecord) {
+			run.Status = Started
+			run.StartedAt = time.Now()
+		})
+
+		isPassing, failedTags, testErrors, err := o.sequencer.Run(
+			flow.WithMetadata(ctx, startSig.Metadata), startSig.Seq, o.cancelCurrentTest, startSig.TestId)
 		if err != nil {
 			o.l.Error("sequencer run", zap.Error(errors.Wrap(err, "run")))
 		}
 
-		o.l.Info("sending results")
+		// Cancels for the finished test are now handled as cancels of a test that is not queued.
+		o.currentTest = uuid.Nil
+
+		results := ResultsSignal{
+			TestId:         startSig.TestId,
+			IsPassing:      isPassing,
+			FailedTags:     failedTags,
+			TestErrors:     testErrors,
+			TeardownErrors: o.sequencer.TeardownErrors(),
+			ReportPaths:    o.sequencer.ReportPaths(),
+			FatalError:     o.sequencer.FatalError(),
+		}
 
-		o.resultFeed.Send(ResultsSignal{
-			TestId:     o.currentTest,
-			IsPassing:  isPassing,
-			FailedTags: failedTags,
-			TestErrors: testErrors,
+		o.updateRun(startSig.TestId, func(run *RunRecord) {
+			run.Status = Completed
+			run.EndedAt = time.Now()
+			run.IsPassing = results.IsPassing
+			run.FailedTags = results.FailedTags
+			run.TestErrors = errorStrings(results.TestErrors)
+			run.TeardownErrors = errorStrings(results.TeardownErrors)
+			run.ReportPaths = results.ReportPaths
+
+			if results.FatalError != nil {
+				run.FatalError = results.FatalError.Error()
+			}
 		})
 
+		o.l.Info("sending results")
+
+		o.resultFeed.Send(results)
+
 		o.resetProgress()
 
 		err = o.sequencer.FatalError()
@@ -167,6 +270,49 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 	}
 }
 
+// recoverFromFatal runs the recovery procedure of the fatal error, if it has one, and returns to Idle once the
+// health checks pass. The orchestrator stays in FatalError if either fails so the recovery can be retried.
+func (o *Orchestrator) recoverFromFatal(ctx context.Context) {
+	o.state = Recovering
+	o.recoveryErr.Reset()
+	o.statusUpdate()
+
+	err := o.runRecovery(ctx)
+	if err != nil {
+		o.l.Error("recovery failed", zap.Error(err))
+
+		o.recoveryErr.Set(err)
+		o.state = FatalError
+		o.statusUpdate()
+
+		return
+	}
+
+	o.sequencer.ResetFatalError()
+	o.fatalErr.Reset()
+	o.recoveryErr.Reset()
+	o.state = Idle
+	o.statusUpdate()
+}
+
+// runRecovery runs the recovery procedure of the fatal error, if it has one, then the health checks.
+func (o *Orchestrator) runRecovery(ctx context.Context) error {
+	procedure, ok := flow.Recovery(o.fatalErr.Err())
+	if ok {
+		o.l.Info("running recovery procedure", zap.Strings("actions", procedure.ActionNames()))
+
+		recoveryCtx, cancel := context.WithTimeout(ctx, _recoveryTimeout)
+		defer cancel()
+
+		err := procedure.Run(recoveryCtx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.checkBenchHealth(ctx)
+}
+
 func (o *Orchestrator) Close() error {
 	var resettableErr = utils.NewResettaleError()
 
@@ -185,9 +331,11 @@ func (o *Orchestrator) Close() error {
 
 		o.resultSubs[i].Unsubscribe()
 		o.statusSubs[i].Unsubscribe()
+		o.measurementSubs[i].Unsubscribe()
 	}
 
 	o.progSub.Unsubscribe()
+	o.sampleSub.Unsubscribe()
 
 	err := o.sequencer.Close()
 	if err != nil {
@@ -196,6 +344,15 @@ func (o *Orchestrator) Close() error {
 		resettableErr.Set(errors.Wrap(err, "close sequencer"))
 	}
 
+	if o.store != nil {
+		err = o.store.Close()
+		if err != nil {
+			o.l.Error("failed to close store", zap.Error(err))
+
+			resettableErr.Set(errors.Wrap(err, "close store"))
+		}
+	}
+
 	return resettableErr.Err()
 }
 
@@ -206,7 +363,7 @@ func (o *Orchestrator) monitorDispatcher(ctx context.Context, d DispatcherIface)
 			o.l.Info("recover from fatal signal received", zap.String("dispatcher", d.Name()))
 
 			switch o.state {
-			case Idle, Running, Unknown:
+			case Idle, Running, Paused, Recovering, Unknown:
 				o.l.Warn("commanded recover from fatal when orchestrator is not in fatal error state",
 					zap.String("state", o.state.String()),
 					zap.String("dispatcher", d.Name()))
@@ -216,12 +373,31 @@ func (o *Orchestrator) monitorDispatcher(ctx context.Context, d DispatcherIface)
 		case startSig := <-d.Start():
 			o.l.Info("start signal received",
 				zap.String("dispatcher", d.Name()),
-				zap.String("test id", startSig.TestId.String()))
+				zap.String("test id", startSig.TestId.String()),
+				zap.String("priority", startSig.Priority.String()),
+				zap.Time("scheduled at", startSig.ScheduledAt))
+
+			// Dispatchers check the required metadata before sending, this catches the ones that do not.
+			missing := startSig.Seq.MissingMetadata(startSig.Metadata)
+			if len(missing) > 0 {
+				o.l.Warn("start signal is missing required metadata",
+					zap.String("dispatcher", d.Name()),
+					zap.Strings("missing", missing))
+
+				o.resultFeed.Send(ResultsSignal{
+					TestId:     startSig.TestId,
+					IsPassing:  false,
+					FailedTags: make([]flow.Tag, 0),
+					TestErrors: []error{errors.Errorf("missing required metadata (%s)", strings.Join(missing, ", "))},
+				})
+
+				continue
+			}
 
 			switch o.state {
-			case Idle, Running:
+			case Idle, Running, Paused:
 				o.addTestToQueue(startSig)
-			case FatalError:
+			case FatalError, Recovering:
 				o.l.Warn("orchestrator is in fatal error state, must recover from fatal error",
 					zap.String("dispatcher", d.Name()))
 			case Unknown:
@@ -233,24 +409,76 @@ func (o *Orchestrator) monitorDispatcher(ctx context.Context, d DispatcherIface)
 				zap.String("test id", cancelTestSignal.TestId.String()))
 
 			if cancelTestSignal.TestId == o.currentTest {
-				o.cancelCurrentTest <- struct{}{}
+				o.cancelRunningTest()
 
 				continue
 			}
 
 			o.removeTestFromQueue(cancelTestSignal.TestId)
 
+			o.updateRun(cancelTestSignal.TestId, func(run *RunRecord) {
+				run.Status = Canceled
+				run.EndedAt = time.Now()
+			})
+
 			o.resultFeed.Send(ResultsSignal{
 				TestId:     cancelTestSignal.TestId,
 				IsPassing:  false,
 				FailedTags: make([]flow.Tag, 0),
 			})
+		case moveTestSignal := <-d.MoveTest():
+			o.l.Info("move test signal received",
+				zap.String("dispatcher", d.Name()),
+				zap.String("test id", moveTestSignal.TestId.String()),
+				zap.Int("position", moveTestSignal.Position))
+
+			if !o.moveTest(moveTestSignal.TestId, moveTestSignal.Position) {
+				o.l.Warn("commanded move of a test that is not queued",
+					zap.String("test id", moveTestSignal.TestId.String()),
+					zap.String("dispatcher", d.Name()))
+			}
+		case <-d.Pause():
+			o.l.Info("pause signal received", zap.String("dispatcher", d.Name()))
+
+			if o.state != Running {
+				o.l.Warn("commanded pause when orchestrator is not running a test",
+					zap.String("state", o.state.String()),
+					zap.String("dispatcher", d.Name()))
+
+				continue
+			}
+
+			o.sequencer.Pause()
+		case <-d.Resume():
+			o.l.Info("resume signal received", zap.String("dispatcher", d.Name()))
+
+			switch o.state {
+			case Running, Paused:
+				// Resuming while running drops a pause that has not taken effect yet.
+				o.sequencer.Resume()
+			default:
+				o.l.Warn("commanded resume when orchestrator is not running a test",
+					zap.String("state", o.state.String()),
+					zap.String("dispatcher", d.Name()))
+			}
+		case <-d.Step():
+			o.l.Info("step signal received", zap.String("dispatcher", d.Name()))
+
+			if o.state != Paused {
+				o.l.Warn("commanded step when orchestrator is not paused",
+					zap.String("state", o.state.String()),
+					zap.String("dispatcher", d.Name()))
+
+				continue
+			}
+
+			o.sequencer.Step()
 		case <-d.Shutdown():
 			o.l.Info("received shutdown signal",
 				zap.String("dispatcher", d.Name()))
 
-			if o.state == Running {
-				o.cancelCurrentTest <- struct{}{}
+			if o.state == Running || o.state == Paused {
+				o.cancelRunningTest()
 			}
 
 			o.shutdownSig <- ShutdownSignal{}
@@ -264,6 +492,25 @@ func (o *Orchestrator) monitorDispatcher(ctx context.Context, d DispatcherIface)
 	}
 }
 
+// cancelRunningTest asks the sequencer to cancel the running test. It never blocks, the channel holds one cancel
+// until the sequencer reads it and a second cancel of the same test is dropped.
+func (o *Orchestrator) cancelRunningTest() {
+	select {
+	case o.cancelCurrentTest <- struct{}{}:
+	default:
+		o.l.Info("cancel already pending for the running test")
+	}
+}
+
+// drainCancel drops a pending cancel that the sequencer did not read.
+func (o *Orchestrator) drainCancel() {
+	select {
+	case <-o.cancelCurrentTest:
+		o.l.Info("dropped a cancel of a test that already finished")
+	default:
+	}
+}
+
 func (o *Orchestrator) monitorProgress(ctx context.Context) {
 	for {
 		select {
@@ -272,6 +519,14 @@ func (o *Orchestrator) monitorProgress(ctx context.Context) {
 			o.progress = progress
 			o.progressMtx.Unlock()
 
+			// The sequencer only pauses between states, so the paused state follows its progress.
+			switch {
+			case progress.Paused && o.state == Running:
+				o.state = Paused
+			case !progress.Paused && o.state == Paused:
+				o.state = Running
+			}
+
 			o.statusUpdate()
 		case <-ctx.Done():
 			return
@@ -279,6 +534,21 @@ func (o *Orchestrator) monitorProgress(ctx context.Context) {
 	}
 }
 
+// monitorMeasurements forwards the live samples of the running test to the dispatchers.
+func (o *Orchestrator) monitorMeasurements(ctx context.Context) {
+	for {
+		select {
+		case sample := <-o.sampleCh:
+			o.measurementFeed.Send(MeasurementSignal{
+				TestId: o.currentTest,
+				Sample: sample,
+			})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (o *Orchestrator) resetProgress() {
 	o.progressMtx.Lock()
 	defer o.progressMtx.Unlock()
@@ -288,44 +558,230 @@ func (o *Orchestrator) resetProgress() {
 
 func (o *Orchestrator) addTestToQueue(startSig StartSignal) {
 	o.testQueueMtx.Lock()
-	defer o.testQueueMtx.Unlock()
+	o.insertTest(startSig)
+	o.testQueueMtx.Unlock()
+
+	o.saveRun(RunRecord{
+		TestId:       startSig.TestId,
+		SequenceName: startSig.Seq.Name,
+		Metadata:     startSig.Metadata,
+		Status:       Queued,
+		Priority:     startSig.Priority,
+		ScheduledAt:  startSig.ScheduledAt,
+		Breakpoints:  startSig.Breakpoints,
+		QueuedAt:     time.Now(),
+	})
+
+	o.statusUpdate()
+}
+
+// insertTest places the test behind all queued tests of the same or higher priority.
+// The caller must hold testQueueMtx.
+func (o *Orchestrator) insertTest(startSig StartSignal) {
+	idx := len(o.testQueue)
+
+	for i, queued := range o.testQueue {
+		if queued.Priority < startSig.Priority {
+			idx = i
+			break
+		}
+	}
 
-	o.testQueue = append(o.testQueue, startSig)
+	o.testQueue = append(o.testQueue, StartSignal{})
+	copy(o.testQueue[idx+1:], o.testQueue[idx:])
+	o.testQueue[idx] = startSig
+}
+
+// moveTest moves a queued test to the given position in the queue. It returns false if the test is not queued.
+func (o *Orchestrator) moveTest(testId TestId, position int) bool {
+	o.testQueueMtx.Lock()
+
+	idx := -1
+
+	for i, queued := range o.testQueue {
+		if queued.TestId == testId {
+			idx = i
+			break
+		}
+	}
+
+	if idx < 0 {
+		o.testQueueMtx.Unlock()
+		return false
+	}
+
+	startSig := o.testQueue[idx]
+	o.testQueue = append(o.testQueue[:idx], o.testQueue[idx+1:]...)
+
+	position = max(0, min(position, len(o.testQueue)))
+
+	o.testQueue = append(o.testQueue, StartSignal{})
+	copy(o.testQueue[position+1:], o.testQueue[position:])
+	o.testQueue[position] = startSig
+
+	o.testQueueMtx.Unlock()
 
 	o.statusUpdate()
+
+	return true
+}
+
+// resumePendingRuns queues the runs that were queued or running when the app last stopped.
+func (o *Orchestrator) resumePendingRuns() error {
+	pending, err := o.store.PendingRuns()
+	if err != nil {
+		return errors.Wrap(err, "pending runs")
+	}
+
+	for _, run := range pending {
+		seq, ok := o.sequences[run.SequenceName]
+		if !ok {
+			o.l.Warn("cannot resume run, sequence not found",
+				zap.String("test id", run.TestId.String()),
+				zap.String("sequence", run.SequenceName))
+
+			run.Status = Canceled
+			run.EndedAt = time.Now()
+			run.TestErrors = append(run.TestErrors, "sequence not found when resuming run")
+			o.saveRun(run)
+
+			continue
+		}
+
+		o.l.Info("resuming run",
+			zap.String("test id", run.TestId.String()),
+			zap.String("sequence", run.SequenceName),
+			zap.String("previous status", run.Status.String()))
+
+		run.Status = Queued
+		run.StartedAt = time.Time{}
+		o.saveRun(run)
+
+		o.testQueueMtx.Lock()
+		o.insertTest(StartSignal{
+			TestId:      run.TestId,
+			Seq:         seq,
+			Metadata:    run.Metadata,
+			Priority:    run.Priority,
+			ScheduledAt: run.ScheduledAt,
+			Breakpoints: run.Breakpoints,
+		})
+		o.testQueueMtx.Unlock()
+	}
+
+	return nil
+}
+
+// saveRun persists the run if the orchestrator has a store. Store errors are logged but never fail a test.
+func (o *Orchestrator) saveRun(run RunRecord) {
+	if o.store == nil {
+		return
+	}
+
+	o.runsMtx.Lock()
+	o.runs[run.TestId] = run
+	o.runsMtx.Unlock()
+
+	err := o.store.SaveRun(run)
+	if err != nil {
+		o.l.Error("failed to save run", zap.String("test id", run.TestId.String()), zap.Error(err))
+	}
+}
+
+// updateRun applies update to the latest record of the run and persists it.
+func (o *Orchestrator) updateRun(testId TestId, update func(run *RunRecord)) {
+	if o.store == nil {
+		return
+	}
+
+	o.runsMtx.Lock()
+	run, ok := o.runs[testId]
+	o.runsMtx.Unlock()
+
+	if !ok {
+		return
+	}
+
+	update(&run)
+
+	if run.Status == Completed || run.Status == Canceled {
+		o.runsMtx.Lock()
+		delete(o.runs, testId)
+		o.runsMtx.Unlock()
+
+		err := o.store.SaveRun(run)
+		if err != nil {
+			o.l.Error("failed to save run", zap.String("test id", testId.String()), zap.Error(err))
+		}
+
+		return
+	}
+
+	o.saveRun(run)
 }
 
 func (o *Orchestrator) removeTestFromQueue(testId TestId) {
 	o.testQueueMtx.Lock()
-	defer o.testQueueMtx.Unlock()
 
 	for i := 0; i < len(o.testQueue); i++ {
 		if o.testQueue[i].TestId == testId {
 			o.testQueue = append(o.testQueue[:i], o.testQueue[i+1:]...)
-			return
+			break
 		}
 	}
 
+	o.testQueueMtx.Unlock()
+
 	o.statusUpdate()
 }
 
+// dequeueNextTest takes the first test in the queue whose scheduled start time has passed.
 func (o *Orchestrator) dequeueNextTest() (StartSignal, bool) {
 	o.testQueueMtx.Lock()
-	defer o.testQueueMtx.Unlock()
 
-	if len(o.testQueue) == 0 {
-		return StartSignal{}, false
+	now := time.Now()
+
+	for i, queued := range o.testQueue {
+		if queued.ScheduledAt.After(now) {
+			continue
+		}
+
+		o.testQueue = append(o.testQueue[:i], o.testQueue[i+1:]...)
+		o.testQueueMtx.Unlock()
+
+		o.statusUpdate()
+
+		return queued, true
 	}
 
-	nextTest := o.testQueue[0]
-	o.testQueue = o.testQueue[1:]
+	o.testQueueMtx.Unlock()
 
-	o.statusUpdate()
+	return StartSignal{}, false
+}
+
+// queueSnapshot returns a copy of the test queue that is safe to send to dispatchers.
+func (o *Orchestrator) queueSnapshot() []QueuedTest {
+	o.testQueueMtx.Lock()
+	defer o.testQueueMtx.Unlock()
 
-	return nextTest, true
+	ret := make([]QueuedTest, len(o.testQueue))
+
+	for i, queued := range o.testQueue {
+		ret[i] = QueuedTest{
+			TestId:       queued.TestId,
+			SequenceName: queued.Seq.Name,
+			Metadata:     queued.Metadata,
+			Priority:     queued.Priority,
+			ScheduledAt:  queued.ScheduledAt,
+		}
+	}
+
+	return ret
 }
 
 func (o *Orchestrator) statusUpdate() {
+	queue := o.queueSnapshot()
+
 	o.progressMtx.Lock()
 	defer o.progressMtx.Unlock()
 
@@ -333,7 +789,8 @@ func (o *Orchestrator) statusUpdate() {
 		OrchestratorState: o.state,
 		TestId:            o.currentTest,
 		Progress:          o.progress,
-		QueueLength:       len(o.testQueue),
+		Queue:             queue,
 		FatalError:        o.fatalErr.Err(),
+		RecoveryError:     o.recoveryErr.Err(),
 	})
 }
@@ -0,0 +1,15 @@
+package orchestrator
+
+//go:generate enumer -type=Priority -trimprefix=Priority -json priority.go
+
+// Priority orders tests in the test queue. Higher priority tests are queued ahead of lower priority tests.
+type Priority int
+
+const (
+	// PriorityLow tests run after all other queued tests, for example bulk regression runs.
+	PriorityLow Priority = iota - 1
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityHigh tests jump ahead of normal and low priority tests, for example a debug run from the bench.
+	PriorityHigh
+)
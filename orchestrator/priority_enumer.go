@@ -0,0 +1,101 @@
+// Code generated by "enumer -type=Priority -trimprefix=Priority -json priority.go"; DO NOT EDIT.
+
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _PriorityName = "LowNormalHigh"
+
+var _PriorityIndex = [...]uint8{0, 3, 9, 13}
+
+const _PriorityLowerName = "lownormalhigh"
+
+func (i Priority) String() string {
+	i -= -1
+	if i < 0 || i >= Priority(len(_PriorityIndex)-1) {
+		return fmt.Sprintf("Priority(%d)", i+-1)
+	}
+	return _PriorityName[_PriorityIndex[i]:_PriorityIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _PriorityNoOp() {
+	var x [1]struct{}
+	_ = x[PriorityLow-(-1)]
+	_ = x[PriorityNormal-(0)]
+	_ = x[PriorityHigh-(1)]
+}
+
+var _PriorityValues = []Priority{PriorityLow, PriorityNormal, PriorityHigh}
+
+var _PriorityNameToValueMap = map[string]Priority{
+	_PriorityName[0:3]:       PriorityLow,
+	_PriorityLowerName[0:3]:  PriorityLow,
+	_PriorityName[3:9]:       PriorityNormal,
+	_PriorityLowerName[3:9]:  PriorityNormal,
+	_PriorityName[9:13]:      PriorityHigh,
+	_PriorityLowerName[9:13]: PriorityHigh,
+}
+
+var _PriorityNames = []string{
+	_PriorityName[0:3],
+	_PriorityName[3:9],
+	_PriorityName[9:13],
+}
+
+// PriorityString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func PriorityString(s string) (Priority, error) {
+	if val, ok := _PriorityNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _PriorityNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to Priority values", s)
+}
+
+// PriorityValues returns all values of the enum
+func PriorityValues() []Priority {
+	return _PriorityValues
+}
+
+// PriorityStrings returns a slice of all String values of the enum
+func PriorityStrings() []string {
+	strs := make([]string, len(_PriorityNames))
+	copy(strs, _PriorityNames)
+	return strs
+}
+
+// IsAPriority returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i Priority) IsAPriority() bool {
+	for _, v := range _PriorityValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for Priority
+func (i Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Priority
+func (i *Priority) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Priority should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = PriorityString(s)
+	return err
+}
@@ -0,0 +1,19 @@
+package orchestrator
+
+//go:generate enumer -type=RunStatus -json runstatus.go
+
+// RunStatus is the status of a test run recorded in a StoreIface.
+type RunStatus int
+
+const (
+	// Queued runs are waiting in the test queue.
+	Queued RunStatus = iota
+	// Started runs were dequeued and handed to the sequencer.
+	Started
+	// Completed runs finished and have results.
+	Completed
+	// Canceled runs were canceled while queued or could not be resumed.
+	Canceled
+	// BenchError runs were dequeued but did not start because a bench health check failed.
+	BenchError
+)
@@ -0,0 +1,108 @@
+// Code generated by "enumer -type=RunStatus -json runstatus.go"; DO NOT EDIT.
+
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _RunStatusName = "QueuedStartedCompletedCanceledBenchError"
+
+var _RunStatusIndex = [...]uint8{0, 6, 13, 22, 30, 40}
+
+const _RunStatusLowerName = "queuedstartedcompletedcanceledbencherror"
+
+func (i RunStatus) String() string {
+	if i < 0 || i >= RunStatus(len(_RunStatusIndex)-1) {
+		return fmt.Sprintf("RunStatus(%d)", i)
+	}
+	return _RunStatusName[_RunStatusIndex[i]:_RunStatusIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _RunStatusNoOp() {
+	var x [1]struct{}
+	_ = x[Queued-(0)]
+	_ = x[Started-(1)]
+	_ = x[Completed-(2)]
+	_ = x[Canceled-(3)]
+	_ = x[BenchError-(4)]
+}
+
+var _RunStatusValues = []RunStatus{Queued, Started, Completed, Canceled, BenchError}
+
+var _RunStatusNameToValueMap = map[string]RunStatus{
+	_RunStatusName[0:6]:        Queued,
+	_RunStatusLowerName[0:6]:   Queued,
+	_RunStatusName[6:13]:       Started,
+	_RunStatusLowerName[6:13]:  Started,
+	_RunStatusName[13:22]:      Completed,
+	_RunStatusLowerName[13:22]: Completed,
+	_RunStatusName[22:30]:      Canceled,
+	_RunStatusLowerName[22:30]: Canceled,
+	_RunStatusName[30:40]:      BenchError,
+	_RunStatusLowerName[30:40]: BenchError,
+}
+
+var _RunStatusNames = []string{
+	_RunStatusName[0:6],
+	_RunStatusName[6:13],
+	_RunStatusName[13:22],
+	_RunStatusName[22:30],
+	_RunStatusName[30:40],
+}
+
+// RunStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func RunStatusString(s string) (RunStatus, error) {
+	if val, ok := _RunStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _RunStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to RunStatus values", s)
+}
+
+// RunStatusValues returns all values of the enum
+func RunStatusValues() []RunStatus {
+	return _RunStatusValues
+}
+
+// RunStatusStrings returns a slice of all String values of the enum
+func RunStatusStrings() []string {
+	strs := make([]string, len(_RunStatusNames))
+	copy(strs, _RunStatusNames)
+	return strs
+}
+
+// IsARunStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i RunStatus) IsARunStatus() bool {
+	for _, v := range _RunStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for RunStatus
+func (i RunStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for RunStatus
+func (i *RunStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("RunStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = RunStatusString(s)
+	return err
+}
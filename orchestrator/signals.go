@@ -1,6 +1,8 @@
 package orchestrator
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/macformula/hil/flow"
 )
@@ -14,10 +16,13 @@ type StatusSignal struct {
 	TestId TestId
 	// Progress is the current Progress of the flow.Sequencer.
 	Progress flow.Progress
-	// QueueLength is the current length of the test queue.
-	QueueLength int
-	// FatalError is the current fatal error. It is only valid if state is FatalError
+	// Queue is the contents of the test queue, in the order the tests will be considered to run.
+	Queue []QueuedTest
+	// FatalError is the current fatal error. It is only valid if state is FatalError or Recovering.
+	// Use flow.Recovery to get its recovery procedure.
 	FatalError error
+	// RecoveryError is why the last recovery from FatalError failed, if it did.
+	RecoveryError error
 }
 
 // StartSignal is the signal that is sent to the orchestrator from the dispatcher to start a test
@@ -25,6 +30,21 @@ type StartSignal struct {
 	TestId   TestId
 	Seq      flow.Sequence
 	Metadata map[string]string
+	// Priority places the test ahead of queued tests with a lower priority. Defaults to PriorityNormal.
+	Priority Priority
+	// ScheduledAt is the earliest time the test can start. The test can start right away if it is zero.
+	ScheduledAt time.Time
+	// Breakpoints are the names of the states the test pauses before, see flow.Sequencer.SetBreakpoints.
+	Breakpoints []string
+}
+
+// QueuedTest describes a test waiting in the test queue.
+type QueuedTest struct {
+	TestId       TestId
+	SequenceName string
+	Metadata     map[string]string
+	Priority     Priority
+	ScheduledAt  time.Time
 }
 
 type ResultsSignal struct {
@@ -33,12 +53,43 @@ type ResultsSignal struct {
 	// Should be of type Tag, will replace this later
 	FailedTags []flow.Tag
 	TestErrors []error
+	// TeardownErrors are errors from the sequence's teardown states, they do not affect IsPassing.
+	TeardownErrors []error
+	// ReportPaths are the paths of the reports generated for the test.
+	ReportPaths []string
+	// FatalError is the non-recoverable error the test ended with, if any.
+	FatalError error
+	// BenchError is set if the test did not start because a bench health check failed, it is also the FatalError.
+	// The test itself did not fail.
+	BenchError error
+}
+
+// MeasurementSignal is a live sample published by a state of the running test.
+type MeasurementSignal struct {
+	TestId TestId
+	Sample flow.Sample
 }
 
 type CancelTestSignal struct {
 	TestId TestId
 }
 
+// MoveTestSignal moves a queued test to the given position in the test queue, regardless of its priority.
+// Position 0 is the front of the queue, positions past the end of the queue move the test to the back.
+type MoveTestSignal struct {
+	TestId   TestId
+	Position int
+}
+
 type RecoverFromFatalSignal struct{}
 
+// PauseSignal halts the running test before its next state.
+type PauseSignal struct{}
+
+// ResumeSignal continues a paused test.
+type ResumeSignal struct{}
+
+// StepSignal runs the next state of a paused test, then pauses it again.
+type StepSignal struct{}
+
 type ShutdownSignal struct{}
@@ -8,4 +8,9 @@ const (
 	Idle
 	Running
 	FatalError
+	// Paused is set while the running test is halted between states by a pause, a step or a breakpoint.
+	Paused
+	// Recovering is set while the recovery procedure of a fatal error runs. The orchestrator returns to FatalError
+	// if it fails.
+	Recovering
 )
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _StateName = "UnknownIdleRunningFatalError"
+const _StateName = "UnknownIdleRunningFatalErrorPausedRecovering"
 
-var _StateIndex = [...]uint8{0, 7, 11, 18, 28}
+var _StateIndex = [...]uint8{0, 7, 11, 18, 28, 34, 44}
 
-const _StateLowerName = "unknownidlerunningfatalerror"
+const _StateLowerName = "unknownidlerunningfatalerrorpausedrecovering"
 
 func (i State) String() string {
 	if i < 0 || i >= State(len(_StateIndex)-1) {
@@ -28,9 +28,11 @@ func _StateNoOp() {
 	_ = x[Idle-(1)]
 	_ = x[Running-(2)]
 	_ = x[FatalError-(3)]
+	_ = x[Paused-(4)]
+	_ = x[Recovering-(5)]
 }
 
-var _StateValues = []State{Unknown, Idle, Running, FatalError}
+var _StateValues = []State{Unknown, Idle, Running, FatalError, Paused, Recovering}
 
 var _StateNameToValueMap = map[string]State{
 	_StateName[0:7]:        Unknown,
@@ -41,6 +43,10 @@ var _StateNameToValueMap = map[string]State{
 	_StateLowerName[11:18]: Running,
 	_StateName[18:28]:      FatalError,
 	_StateLowerName[18:28]: FatalError,
+	_StateName[28:34]:      Paused,
+	_StateLowerName[28:34]: Paused,
+	_StateName[34:44]:      Recovering,
+	_StateLowerName[34:44]: Recovering,
 }
 
 var _StateNames = []string{
@@ -48,6 +54,8 @@ var _StateNames = []string{
 	_StateName[7:11],
 	_StateName[11:18],
 	_StateName[18:28],
+	_StateName[28:34],
+	_StateName[34:44],
 }
 
 // StateString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/macformula/hil/flow"
+)
+
+// RunRecord is the persisted record of a single test run.
+type RunRecord struct {
+	TestId       TestId            `json:"testId"`
+	SequenceName string            `json:"sequenceName"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Status       RunStatus         `json:"status"`
+	Priority     Priority          `json:"priority"`
+	ScheduledAt  time.Time         `json:"scheduledAt,omitempty"`
+	Breakpoints  []string          `json:"breakpoints,omitempty"`
+	QueuedAt     time.Time         `json:"queuedAt"`
+	StartedAt    time.Time         `json:"startedAt,omitempty"`
+	EndedAt      time.Time         `json:"endedAt,omitempty"`
+	// The fields below are only valid once the run is Completed.
+	IsPassing      bool       `json:"isPassing"`
+	FailedTags     []flow.Tag `json:"failedTags,omitempty"`
+	TestErrors     []string   `json:"testErrors,omitempty"`
+	TeardownErrors []string   `json:"teardownErrors,omitempty"`
+	FatalError     string     `json:"fatalError,omitempty"`
+	BenchError     string     `json:"benchError,omitempty"`
+	ReportPaths    []string   `json:"reportPaths,omitempty"`
+}
+
+// RunQuery filters the runs returned by StoreIface.ListRuns.
+type RunQuery struct {
+	// SequenceName only returns runs of the given sequence if set.
+	SequenceName string
+	// Statuses only returns runs with one of the given statuses if set.
+	Statuses []RunStatus
+	// Since only returns runs queued at or after the given time if set.
+	Since time.Time
+	// Limit is the max number of runs returned, 0 returns all runs.
+	Limit int
+}
+
+// StoreIface persists the test queue and the history of test runs so they survive an app restart.
+type StoreIface interface {
+	io.Closer
+	// Open will be called on orchestrator open.
+	Open(ctx context.Context) error
+	// SaveRun inserts the run or updates the existing run with the same TestId.
+	SaveRun(run RunRecord) error
+	// PendingRuns returns the runs that are Queued or Started, in the order they were queued.
+	PendingRuns() ([]RunRecord, error)
+	// ListRuns returns the runs matching the query, most recently queued first.
+	ListRuns(query RunQuery) ([]RunRecord, error)
+}
+
+// matches returns true if the run passes all filters of the query.
+func (q RunQuery) matches(run RunRecord) bool {
+	if q.SequenceName != "" && run.SequenceName != q.SequenceName {
+		return false
+	}
+
+	if !q.Since.IsZero() && run.QueuedAt.Before(q.Since) {
+		return false
+	}
+
+	if len(q.Statuses) == 0 {
+		return true
+	}
+
+	for _, status := range q.Statuses {
+		if run.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func errorStrings(errs []error) []string {
+	ret := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		if err != nil {
+			ret = append(ret, err.Error())
+		}
+	}
+
+	return ret
+}
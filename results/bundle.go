@@ -0,0 +1,217 @@
+package results
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
+	"github.com/pkg/errors"
+)
+
+const (
+	_artifactsDirName = "artifacts"
+	_manifestFileName = "manifest.json"
+	_timeSeriesExt    = ".csv"
+)
+
+// BundledArtifact is an artifact that has been written to the bundle directory of a test.
+type BundledArtifact struct {
+	Name      string            `json:"name"`
+	Kind      flow.ArtifactKind `json:"kind"`
+	StateName string            `json:"stateName"`
+	// Path is relative to the bundle directory, so reports in the bundle can link to it.
+	Path       string    `json:"path"`
+	AttachedAt time.Time `json:"attachedAt"`
+}
+
+// manifest describes the contents of a bundle directory.
+type manifest struct {
+	TestID          uuid.UUID         `json:"testId"`
+	SequenceName    string            `json:"sequenceName"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	OverallPassFail bool              `json:"overallPassFail"`
+	CompletedAt     time.Time         `json:"completedAt"`
+	Reports         []string          `json:"reports"`
+	Artifacts       []BundledArtifact `json:"artifacts"`
+}
+
+// BundleDir returns the directory holding the reports and artifacts of a test.
+func BundleDir(bundlesDir string, testID uuid.UUID) string {
+	return filepath.Join(bundlesDir, testID.String())
+}
+
+// writeArtifact stores the artifact in the bundle directory and returns its path relative to bundleDir.
+// Files that are already in the bundle directory are left in place.
+func writeArtifact(bundleDir string, artifact flow.Artifact) (string, error) {
+	if filepath.Base(artifact.Name) != artifact.Name {
+		return "", errors.Errorf("artifact name (%s) must not contain a path", artifact.Name)
+	}
+
+	relPath := filepath.Join(_artifactsDirName, artifact.Name)
+
+	switch artifact.Kind {
+	case flow.ArtifactFile:
+		inBundle, err := relativeToBundle(bundleDir, artifact.Path)
+		if err != nil {
+			return "", errors.Wrap(err, "relative to bundle")
+		}
+
+		if inBundle != "" {
+			return inBundle, nil
+		}
+
+		if filepath.Ext(relPath) == "" {
+			relPath += filepath.Ext(artifact.Path)
+		}
+
+		err = copyFile(artifact.Path, filepath.Join(bundleDir, relPath))
+		if err != nil {
+			return "", errors.Wrap(err, "copy file")
+		}
+	case flow.ArtifactBytes:
+		err := os.WriteFile(filepath.Join(bundleDir, relPath), artifact.Data, 0644)
+		if err != nil {
+			return "", errors.Wrap(err, "write file")
+		}
+	case flow.ArtifactTimeSeries:
+		if filepath.Ext(relPath) != _timeSeriesExt {
+			relPath += _timeSeriesExt
+		}
+
+		err := writeTimeSeries(filepath.Join(bundleDir, relPath), artifact.Series)
+		if err != nil {
+			return "", errors.Wrap(err, "write time series")
+		}
+	default:
+		return "", errors.Errorf("unknown artifact kind (%s)", artifact.Kind)
+	}
+
+	return relPath, nil
+}
+
+// relativeToBundle returns the path of the file relative to bundleDir, or an empty string if it is not in bundleDir.
+func relativeToBundle(bundleDir, path string) (string, error) {
+	absBundle, err := filepath.Abs(bundleDir)
+	if err != nil {
+		return "", errors.Wrap(err, "abs bundle dir")
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", errors.Wrap(err, "abs path")
+	}
+
+	rel, err := filepath.Rel(absBundle, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", nil
+	}
+
+	return rel, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "open source")
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return errors.Wrap(err, "create destination")
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return errors.Wrap(err, "copy")
+	}
+
+	err = out.Close()
+	if err != nil {
+		return errors.Wrap(err, "close destination")
+	}
+
+	return nil
+}
+
+// writeTimeSeries writes the series as a csv file with RFC3339 timestamps.
+func writeTimeSeries(path string, series flow.TimeSeries) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create file")
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+
+	valueHeader := "value"
+	if series.Unit != "" {
+		valueHeader += " (" + series.Unit + ")"
+	}
+
+	err = w.Write([]string{"time", valueHeader})
+	if err != nil {
+		return errors.Wrap(err, "write header")
+	}
+
+	for _, point := range series.Points {
+		err = w.Write([]string{
+			point.Time.Format(time.RFC3339Nano),
+			strconv.FormatFloat(point.Value, 'g', -1, 64),
+		})
+		if err != nil {
+			return errors.Wrap(err, "write point")
+		}
+	}
+
+	w.Flush()
+
+	err = w.Error()
+	if err != nil {
+		return errors.Wrap(err, "flush")
+	}
+
+	return nil
+}
+
+// writeManifest writes the manifest of the bundle. Report paths are made relative to bundleDir.
+func writeManifest(bundleDir string, report Report, reportPaths []string) error {
+	m := manifest{
+		TestID:          report.TestID,
+		SequenceName:    report.SequenceName,
+		Metadata:        report.Metadata,
+		OverallPassFail: report.OverallPassFail,
+		CompletedAt:     report.CompletedAt,
+		Reports:         make([]string, 0, len(reportPaths)),
+		Artifacts:       report.Artifacts,
+	}
+
+	for _, reportPath := range reportPaths {
+		rel, err := relativeToBundle(bundleDir, reportPath)
+		if err != nil || rel == "" {
+			rel = reportPath
+		}
+
+		m.Reports = append(m.Reports, rel)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal manifest")
+	}
+
+	err = os.WriteFile(filepath.Join(bundleDir, _manifestFileName), data, 0644)
+	if err != nil {
+		return errors.Wrap(err, "write manifest")
+	}
+
+	return nil
+}
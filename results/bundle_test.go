@@ -0,0 +1,131 @@
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultAccumulatorBundle(t *testing.T) {
+	setup := setupTest(t)
+	err := setup.ra.Open(context.Background())
+	require.NoError(t, err)
+
+	bundlesDir := filepath.Join(setup.tempDir, "bundles")
+	setup.ra.SetBundlesDir(bundlesDir)
+
+	testID := uuid.New()
+	ctx := flow.WithTestId(context.Background(), testID)
+	bundleDir := BundleDir(bundlesDir, testID)
+
+	tracePath := filepath.Join(setup.tempDir, "trace.asc")
+	err = os.WriteFile(tracePath, []byte("trace"), 0644)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	artifacts := []flow.Artifact{
+		{Name: "veh_trace", Kind: flow.ArtifactFile, Path: tracePath, StateName: "lv_startup"},
+		{Name: "dump.bin", Kind: flow.ArtifactBytes, Data: []byte{1, 2, 3}, StateName: "lv_startup"},
+		{Name: "lv_voltage", Kind: flow.ArtifactTimeSeries, StateName: "lv_startup", Series: flow.TimeSeries{
+			Unit: "V",
+			Points: []flow.Point{
+				{Time: start, Value: 12.5},
+				{Time: start.Add(time.Second), Value: 12.25},
+			},
+		}},
+	}
+
+	for _, artifact := range artifacts {
+		err = setup.ra.SubmitArtifact(ctx, artifact)
+		require.NoError(t, err)
+	}
+
+	_, err = setup.ra.SubmitTag(ctx, "numericGt", 15)
+	require.NoError(t, err)
+
+	_, err = setup.ra.CompleteTest(ctx, testID, "TestSequence")
+	require.NoError(t, err)
+
+	t.Run("ArtifactsWritten", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(bundleDir, "artifacts", "veh_trace.asc"))
+		require.NoError(t, err)
+		assert.Equal(t, "trace", string(data))
+
+		data, err = os.ReadFile(filepath.Join(bundleDir, "artifacts", "dump.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, data)
+
+		data, err = os.ReadFile(filepath.Join(bundleDir, "artifacts", "lv_voltage.csv"))
+		require.NoError(t, err)
+		assert.Equal(t, "time,value (V)\n2024-01-01T00:00:00Z,12.5\n2024-01-01T00:00:01Z,12.25\n", string(data))
+	})
+
+	t.Run("ReportInBundle", func(t *testing.T) {
+		require.Len(t, setup.ra.ReportPaths(), 1)
+		assert.Equal(t, bundleDir, filepath.Dir(setup.ra.ReportPaths()[0]))
+
+		htmlContent, err := os.ReadFile(setup.ra.ReportPaths()[0])
+		require.NoError(t, err)
+		assert.Contains(t, string(htmlContent), `href="artifacts/lv_voltage.csv"`)
+	})
+
+	t.Run("Manifest", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(bundleDir, "manifest.json"))
+		require.NoError(t, err)
+
+		var m manifest
+		err = json.Unmarshal(data, &m)
+		require.NoError(t, err)
+
+		assert.Equal(t, testID, m.TestID)
+		assert.True(t, m.OverallPassFail)
+		assert.Len(t, m.Reports, 1)
+		require.Len(t, m.Artifacts, 3)
+		assert.Equal(t, flow.ArtifactTimeSeries, m.Artifacts[2].Kind)
+		assert.Equal(t, "lv_startup", m.Artifacts[0].StateName)
+	})
+
+	t.Run("ResetArtifacts", func(t *testing.T) {
+		assert.Empty(t, setup.ra.artifacts)
+	})
+}
+
+func TestResultAccumulatorBundleFileInBundle(t *testing.T) {
+	setup := setupTest(t)
+
+	bundlesDir := filepath.Join(setup.tempDir, "bundles")
+	setup.ra.SetBundlesDir(bundlesDir)
+
+	testID := uuid.New()
+	ctx := flow.WithTestId(context.Background(), testID)
+
+	bundleDir := BundleDir(bundlesDir, testID)
+	err := os.MkdirAll(bundleDir, 0755)
+	require.NoError(t, err)
+
+	// Files written straight into the bundle, such as CAN traces, are not copied.
+	tracePath := filepath.Join(bundleDir, "pt.asc")
+	err = os.WriteFile(tracePath, []byte("trace"), 0644)
+	require.NoError(t, err)
+
+	err = setup.ra.SubmitArtifact(ctx, flow.Artifact{Name: "pt_trace", Kind: flow.ArtifactFile, Path: tracePath})
+	require.NoError(t, err)
+
+	require.Len(t, setup.ra.artifacts, 1)
+	assert.Equal(t, "pt.asc", setup.ra.artifacts[0].Path)
+
+	err = setup.ra.SubmitArtifact(ctx, flow.Artifact{Name: "../escape", Kind: flow.ArtifactBytes})
+	assert.Error(t, err)
+
+	err = setup.ra.SubmitArtifact(context.Background(), flow.Artifact{Name: "no_test", Kind: flow.ArtifactBytes})
+	assert.Error(t, err)
+}
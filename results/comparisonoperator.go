@@ -20,4 +20,14 @@ const (
 	Le
 	// Log is logging the value without comparison
 	Log
+	// Ne is Not equal to
+	Ne
+	// Approx is Approximately equal to, within an absolute or percent tolerance
+	Approx
+	// Match is a regular expression match of a string
+	Match
+	// In is membership in a set of values
+	In
+	// Mask is equality of the masked bits of an integer
+	Mask
 )
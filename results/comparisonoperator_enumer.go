@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ComparisonOperatorName = "EqGeleGtltGtLtGeLeLog"
+const _ComparisonOperatorName = "EqGeleGtltGtLtGeLeLogNeApproxMatchInMask"
 
-var _ComparisonOperatorIndex = [...]uint8{0, 2, 6, 10, 12, 14, 16, 18, 21}
+var _ComparisonOperatorIndex = [...]uint8{0, 2, 6, 10, 12, 14, 16, 18, 21, 23, 29, 34, 36, 40}
 
-const _ComparisonOperatorLowerName = "eqgelegtltgtltgelelog"
+const _ComparisonOperatorLowerName = "eqgelegtltgtltgelelogneapproxmatchinmask"
 
 func (i ComparisonOperator) String() string {
 	if i < 0 || i >= ComparisonOperator(len(_ComparisonOperatorIndex)-1) {
@@ -32,9 +32,14 @@ func _ComparisonOperatorNoOp() {
 	_ = x[Ge-(5)]
 	_ = x[Le-(6)]
 	_ = x[Log-(7)]
+	_ = x[Ne-(8)]
+	_ = x[Approx-(9)]
+	_ = x[Match-(10)]
+	_ = x[In-(11)]
+	_ = x[Mask-(12)]
 }
 
-var _ComparisonOperatorValues = []ComparisonOperator{Eq, Gele, Gtlt, Gt, Lt, Ge, Le, Log}
+var _ComparisonOperatorValues = []ComparisonOperator{Eq, Gele, Gtlt, Gt, Lt, Ge, Le, Log, Ne, Approx, Match, In, Mask}
 
 var _ComparisonOperatorNameToValueMap = map[string]ComparisonOperator{
 	_ComparisonOperatorName[0:2]:        Eq,
@@ -53,6 +58,16 @@ var _ComparisonOperatorNameToValueMap = map[string]ComparisonOperator{
 	_ComparisonOperatorLowerName[16:18]: Le,
 	_ComparisonOperatorName[18:21]:      Log,
 	_ComparisonOperatorLowerName[18:21]: Log,
+	_ComparisonOperatorName[21:23]:      Ne,
+	_ComparisonOperatorLowerName[21:23]: Ne,
+	_ComparisonOperatorName[23:29]:      Approx,
+	_ComparisonOperatorLowerName[23:29]: Approx,
+	_ComparisonOperatorName[29:34]:      Match,
+	_ComparisonOperatorLowerName[29:34]: Match,
+	_ComparisonOperatorName[34:36]:      In,
+	_ComparisonOperatorLowerName[34:36]: In,
+	_ComparisonOperatorName[36:40]:      Mask,
+	_ComparisonOperatorLowerName[36:40]: Mask,
 }
 
 var _ComparisonOperatorNames = []string{
@@ -64,6 +79,11 @@ var _ComparisonOperatorNames = []string{
 	_ComparisonOperatorName[14:16],
 	_ComparisonOperatorName[16:18],
 	_ComparisonOperatorName[18:21],
+	_ComparisonOperatorName[21:23],
+	_ComparisonOperatorName[23:29],
+	_ComparisonOperatorName[29:34],
+	_ComparisonOperatorName[34:36],
+	_ComparisonOperatorName[36:40],
 }
 
 // ComparisonOperatorString retrieves an enum value from the enum constants string name.
@@ -76,7 +96,7 @@ func ComparisonOperatorString(s string) (ComparisonOperator, error) {
 	if val, ok := _ComparisonOperatorNameToValueMap[strings.ToLower(s)]; ok {
 		return val, nil
 	}
-	return 0, fmt.Errorf("(%s) does not belong to ComparisonOperator values", s)
+	return 0, fmt.Errorf("%s does not belong to ComparisonOperator values", s)
 }
 
 // ComparisonOperatorValues returns all values of the enum
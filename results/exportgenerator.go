@@ -0,0 +1,274 @@
+package results
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ExportSchemaVersion is the version of the JSON and CSV export schema. It changes when a field is removed or
+// changes meaning, fields can be added without changing it.
+const ExportSchemaVersion = 1
+
+const (
+	_csvTagRow           = "tag"
+	_csvErrorRow         = "error"
+	_csvTeardownErrorRow = "teardown_error"
+	_csvRunRow           = "run"
+)
+
+// _csvHeader is the header row of a CSV export. The run columns are repeated on every row so exports of many runs
+// can be concatenated.
+var _csvHeader = []string{
+	"schema_version", "test_id", "sequence_name", "started_at", "completed_at", "overall_pass",
+	"row_type", "tag_id", "description", "compare_op", "comparison", "lower_limit", "upper_limit",
+	"expected_value", "unit", "value", "is_passing", "state_path", "params", "error",
+}
+
+// Export is the machine-readable form of a Report written by the JsonExportGenerator.
+type Export struct {
+	SchemaVersion   int               `json:"schemaVersion"`
+	TestID          uuid.UUID         `json:"testId"`
+	SequenceName    string            `json:"sequenceName"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	StartedAt       time.Time         `json:"startedAt"`
+	CompletedAt     time.Time         `json:"completedAt"`
+	OverallPassFail bool              `json:"overallPassFail"`
+	Tags            []ExportedTag     `json:"tags"`
+	Errors          []string          `json:"errors"`
+	TeardownErrors  []string          `json:"teardownErrors"`
+}
+
+// ExportedTag is a single tag submission of an Export.
+type ExportedTag struct {
+	TagID         string `json:"tagId"`
+	Description   string `json:"description"`
+	CompareOp     string `json:"compareOp"`
+	Comparison    string `json:"comparison"`
+	LowerLimit    any    `json:"lowerLimit,omitempty"`
+	UpperLimit    any    `json:"upperLimit,omitempty"`
+	ExpectedValue any    `json:"expectedValue,omitempty"`
+	Unit          string `json:"unit"`
+	Value         any    `json:"value"`
+	IsPassing     bool   `json:"isPassing"`
+	StatePath     string `json:"statePath,omitempty"`
+	Params        string `json:"params,omitempty"`
+}
+
+// NewExport converts the report, tags are sorted by TagID then params.
+func NewExport(report Report) (Export, error) {
+	submissions, err := generateTagSubmissionsDisplay(report.TagSubmissions)
+	if err != nil {
+		return Export{}, errors.Wrap(err, "generate tag submissions display")
+	}
+
+	export := Export{
+		SchemaVersion:   ExportSchemaVersion,
+		TestID:          report.TestID,
+		SequenceName:    report.SequenceName,
+		Metadata:        report.Metadata,
+		StartedAt:       report.StartedAt,
+		CompletedAt:     report.CompletedAt,
+		OverallPassFail: report.OverallPassFail,
+		Tags:            make([]ExportedTag, len(submissions)),
+		Errors:          errorStrings(report.ErrorSubmissions),
+		TeardownErrors:  errorStrings(report.TeardownErrors),
+	}
+
+	for i, submission := range submissions {
+		export.Tags[i] = ExportedTag{
+			TagID:         submission.TagID,
+			Description:   submission.Tag.Description,
+			CompareOp:     submission.Tag.CompOp.String(),
+			Comparison:    submission.ComparisonDisplay,
+			LowerLimit:    submission.Tag.LowerLimit,
+			UpperLimit:    submission.Tag.UpperLimit,
+			ExpectedValue: submission.Tag.ExpectedValue,
+			Unit:          submission.Tag.Unit,
+			Value:         submission.Value,
+			IsPassing:     submission.IsPassing,
+			StatePath:     submission.StatePath,
+			Params:        submission.Params,
+		}
+	}
+
+	return export, nil
+}
+
+// ReadJsonExport reads an export written by the JsonExportGenerator.
+func ReadJsonExport(path string) (Export, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Export{}, errors.Wrapf(err, "read file (%s)", path)
+	}
+
+	var export Export
+
+	err = json.Unmarshal(data, &export)
+	if err != nil {
+		return Export{}, errors.Wrapf(err, "unmarshal export (%s)", path)
+	}
+
+	if export.SchemaVersion != ExportSchemaVersion {
+		return Export{}, errors.Errorf("unsupported export schema version (%d) in (%s), expected (%d)",
+			export.SchemaVersion, path, ExportSchemaVersion)
+	}
+
+	return export, nil
+}
+
+// JsonExportGenerator writes every tag submission and error of a test to a JSON file, see Export.
+type JsonExportGenerator struct{}
+
+// NewJsonExportGenerator creates a new JsonExportGenerator.
+func NewJsonExportGenerator() *JsonExportGenerator {
+	return &JsonExportGenerator{}
+}
+
+// Generate writes the JSON export in outputDir.
+func (g *JsonExportGenerator) Generate(report Report, outputDir string) (string, error) {
+	export, err := NewExport(report)
+	if err != nil {
+		return "", errors.Wrap(err, "new export")
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "marshal export")
+	}
+
+	filePath := filepath.Join(outputDir, exportFileName(report, "json"))
+
+	err = os.WriteFile(filePath, data, 0644)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write json export")
+	}
+
+	return filePath, nil
+}
+
+// CsvExportGenerator writes a row for the run, then a row for every tag submission and error of a test.
+type CsvExportGenerator struct{}
+
+// NewCsvExportGenerator creates a new CsvExportGenerator.
+func NewCsvExportGenerator() *CsvExportGenerator {
+	return &CsvExportGenerator{}
+}
+
+// Generate writes the CSV export in outputDir.
+func (g *CsvExportGenerator) Generate(report Report, outputDir string) (string, error) {
+	export, err := NewExport(report)
+	if err != nil {
+		return "", errors.Wrap(err, "new export")
+	}
+
+	filePath := filepath.Join(outputDir, exportFileName(report, "csv"))
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create csv export")
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+
+	err = w.WriteAll(csvRows(export))
+	if err != nil {
+		return "", errors.Wrap(err, "write csv")
+	}
+
+	return filePath, nil
+}
+
+func csvRows(export Export) [][]string {
+	run := []string{
+		strconv.Itoa(export.SchemaVersion),
+		export.TestID.String(),
+		export.SequenceName,
+		formatExportTime(export.StartedAt),
+		formatExportTime(export.CompletedAt),
+		strconv.FormatBool(export.OverallPassFail),
+	}
+
+	row := func(rowType string, fields ...string) []string {
+		ret := make([]string, 0, len(_csvHeader))
+		ret = append(ret, run...)
+		ret = append(ret, rowType)
+		ret = append(ret, fields...)
+
+		// Pad the columns the row type does not use.
+		for len(ret) < len(_csvHeader) {
+			ret = append(ret, "")
+		}
+
+		return ret
+	}
+
+	rows := [][]string{_csvHeader, row(_csvRunRow)}
+
+	for _, tag := range export.Tags {
+		rows = append(rows, row(_csvTagRow,
+			tag.TagID,
+			tag.Description,
+			tag.CompareOp,
+			tag.Comparison,
+			formatExportValue(tag.LowerLimit),
+			formatExportValue(tag.UpperLimit),
+			formatExportValue(tag.ExpectedValue),
+			tag.Unit,
+			formatExportValue(tag.Value),
+			strconv.FormatBool(tag.IsPassing),
+			tag.StatePath,
+			tag.Params,
+		))
+	}
+
+	// Errors only fill the error column, it is the last one.
+	errorColumn := len(_csvHeader) - len(run) - 2
+
+	for _, err := range export.Errors {
+		rows = append(rows, row(_csvErrorRow, append(make([]string, errorColumn), err)...))
+	}
+
+	for _, err := range export.TeardownErrors {
+		rows = append(rows, row(_csvTeardownErrorRow, append(make([]string, errorColumn), err)...))
+	}
+
+	return rows
+}
+
+func exportFileName(report Report, ext string) string {
+	return fmt.Sprintf("%s%s_%s.%s", _exportFilePrefix, report.SequenceName, report.TestID.String(), ext)
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatExportValue(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+func errorStrings(errs []error) []string {
+	ret := make([]string, len(errs))
+	for i, err := range errs {
+		ret[i] = err.Error()
+	}
+
+	return ret
+}
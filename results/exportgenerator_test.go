@@ -0,0 +1,107 @@
+package results
+
+import (
+	"encoding/csv"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestReport() Report {
+	startedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	return Report{
+		TestID:       uuid.New(),
+		SequenceName: "TestSequence",
+		Metadata:     map[string]string{"operator": "jane"},
+		StartedAt:    startedAt,
+		CompletedAt:  startedAt.Add(time.Minute),
+		TagSubmissions: map[string]TagSubmission{
+			"tsal_time": {
+				TagID:     "tsal_time",
+				Tag:       Tag{Description: "TSAL time to enable", CompOp: Le, UpperLimit: 300, Unit: "ms"},
+				Value:     250,
+				IsPassing: true,
+				StatePath: "lv_startup",
+				Params:    flow.Params{"pedal": 50},
+			},
+		},
+		ErrorSubmissions: []error{assert.AnError},
+	}
+}
+
+func TestJsonExportGeneratorGenerate(t *testing.T) {
+	report := exportTestReport()
+
+	path, err := NewJsonExportGenerator().Generate(report, t.TempDir())
+	require.NoError(t, err)
+
+	export, err := ReadJsonExport(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, ExportSchemaVersion, export.SchemaVersion)
+	assert.Equal(t, report.TestID, export.TestID)
+	assert.Equal(t, report.StartedAt, export.StartedAt)
+	assert.Equal(t, report.Metadata, export.Metadata)
+	assert.Equal(t, []string{assert.AnError.Error()}, export.Errors)
+
+	require.Len(t, export.Tags, 1)
+
+	tag := export.Tags[0]
+	assert.Equal(t, "tsal_time", tag.TagID)
+	assert.Equal(t, "Le", tag.CompareOp)
+	assert.Equal(t, "X ≤ 300", tag.Comparison)
+	assert.EqualValues(t, 300, tag.UpperLimit)
+	assert.EqualValues(t, 250, tag.Value)
+	assert.Equal(t, "ms", tag.Unit)
+	assert.True(t, tag.IsPassing)
+	assert.Equal(t, "pedal=50", tag.Params)
+}
+
+func TestCsvExportGeneratorGenerate(t *testing.T) {
+	report := exportTestReport()
+
+	path, err := NewCsvExportGenerator().Generate(report, t.TempDir())
+	require.NoError(t, err)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+
+	require.Len(t, rows, 4)
+	assert.Equal(t, _csvHeader, rows[0])
+
+	column := func(row []string, name string) string {
+		for i, header := range _csvHeader {
+			if header == name {
+				return row[i]
+			}
+		}
+
+		t.Fatalf("unknown column (%s)", name)
+
+		return ""
+	}
+
+	assert.Equal(t, _csvRunRow, column(rows[1], "row_type"))
+	assert.Equal(t, "false", column(rows[1], "overall_pass"))
+	assert.Equal(t, "2024-01-01T12:00:00Z", column(rows[1], "started_at"))
+
+	assert.Equal(t, _csvTagRow, column(rows[2], "row_type"))
+	assert.Equal(t, "tsal_time", column(rows[2], "tag_id"))
+	assert.Equal(t, "300", column(rows[2], "upper_limit"))
+	assert.Equal(t, "250", column(rows[2], "value"))
+	assert.Equal(t, "true", column(rows[2], "is_passing"))
+	assert.Equal(t, report.TestID.String(), column(rows[2], "test_id"))
+
+	assert.Equal(t, _csvErrorRow, column(rows[3], "row_type"))
+	assert.Equal(t, assert.AnError.Error(), column(rows[3], "error"))
+}
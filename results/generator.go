@@ -1,16 +1,39 @@
 package results
 
 import (
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
 )
 
+// Report contains everything submitted to the ResultAccumulator during a single test.
+type Report struct {
+	TestID       uuid.UUID
+	SequenceName string
+	// Metadata is the metadata the test was started with, such as the firmware commit or operator.
+	Metadata map[string]string
+	// StartedAt is when the Sequencer started the test, it is zero if unknown.
+	StartedAt        time.Time
+	CompletedAt      time.Time
+	TagSubmissions   map[string]TagSubmission
+	ErrorSubmissions []error
+	OverallPassFail  bool
+	// TeardownErrors are errors from teardown states, they do not affect OverallPassFail.
+	TeardownErrors []error
+	// RetriedStates are the states that needed more than one attempt, in the order they ran.
+	RetriedStates []RetriedState
+	// Artifacts are the artifacts attached during the test. Their paths are relative to the report's output dir.
+	Artifacts []BundledArtifact
+}
+
+// RetriedState holds the failed attempts of a state that was retried. These are diagnostic only.
+type RetriedState struct {
+	StateName string
+	Attempts  []flow.Attempt
+}
+
 type Generator interface {
-	Generate(
-		testID uuid.UUID,
-		sequenceName string,
-		tagSubmissions map[string]TagSubmission,
-		errorSubmissions []error,
-		overallPassFail bool,
-		outputDir string,
-	) error
+	// Generate creates a report for the given test in outputDir and returns the path of the report.
+	Generate(report Report, outputDir string) (string, error)
 }
@@ -0,0 +1,67 @@
+package results
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	_exportFilePrefix = "results_"
+	_exportFileExt    = ".json"
+)
+
+// History reads the JSON exports of previous runs, see JsonExportGenerator. Exports are found anywhere under dir,
+// so both a bundles dir and a flat reports dir can be used.
+type History struct {
+	dir string
+}
+
+// NewHistory creates a History reading the exports under dir.
+func NewHistory(dir string) *History {
+	return &History{
+		dir: dir,
+	}
+}
+
+// Load returns the exports of the given sequence, oldest first. All sequences are returned if sequenceName is empty.
+func (h *History) Load(sequenceName string) ([]Export, error) {
+	var exports []Export
+
+	err := filepath.WalkDir(h.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !isExportFile(d.Name()) {
+			return nil
+		}
+
+		export, err := ReadJsonExport(path)
+		if err != nil {
+			return errors.Wrap(err, "read json export")
+		}
+
+		if sequenceName == "" || export.SequenceName == sequenceName {
+			exports = append(exports, export)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "walk dir (%s)", h.dir)
+	}
+
+	sort.SliceStable(exports, func(i, j int) bool {
+		return exports[i].CompletedAt.Before(exports[j].CompletedAt)
+	})
+
+	return exports, nil
+}
+
+func isExportFile(name string) bool {
+	return strings.HasPrefix(name, _exportFilePrefix) && filepath.Ext(name) == _exportFileExt
+}
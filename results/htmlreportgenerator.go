@@ -6,9 +6,10 @@ import (
 	"html/template"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -18,6 +19,8 @@ var templateString string
 // TagSubmissionDisplay includes a pre-formatted comparison string for display purposes.
 type TagSubmissionDisplay struct {
 	TagID             string
+	StatePath         string
+	Params            string
 	Tag               Tag
 	Value             any
 	IsPassing         bool
@@ -28,69 +31,115 @@ type TagSubmissionDisplay struct {
 type TemplateData struct {
 	TestID           string
 	SequenceName     string
+	Metadata         map[string]string
 	TagSubmissions   []TagSubmissionDisplay
 	ErrorSubmissions []error
 	OverallPassFail  bool
-	Timestamp        string
+	TeardownErrors   []error
+	RetriedStates    []RetriedState
+	Artifacts        []BundledArtifact
+	// Trends are only set if the generator was created WithTrends.
+	Trends      []TagTrend
+	TrendsError string
+	Timestamp   string
 }
 
+// HtmlReportOption is a type for functions operating on HtmlReportGenerator
+type HtmlReportOption func(*HtmlReportGenerator)
+
 // HtmlReportGenerator generates HTML reports.
 type HtmlReportGenerator struct {
 	templateString string
+
+	history  *History
+	analyzer *TrendAnalyzer
 }
 
 // NewHtmlReportGenerator creates a new HtmlReportGenerator with the default template.
-func NewHtmlReportGenerator() *HtmlReportGenerator {
-	return &HtmlReportGenerator{
+func NewHtmlReportGenerator(opts ...HtmlReportOption) *HtmlReportGenerator {
+	generator := &HtmlReportGenerator{
 		templateString: "",
 	}
+
+	for _, o := range opts {
+		o(generator)
+	}
+
+	return generator
+}
+
+// WithTrends adds a trends section comparing each tag against previous runs of the sequence in history.
+func WithTrends(history *History, analyzer *TrendAnalyzer) HtmlReportOption {
+	return func(g *HtmlReportGenerator) {
+		g.history = history
+		g.analyzer = analyzer
+	}
 }
 
 // Generate creates an HTML report based on the provided data.
-func (g *HtmlReportGenerator) Generate(
-	testID uuid.UUID,
-	sequenceName string,
-	tagSubmissions map[string]TagSubmission,
-	errorSubmissions []error,
-	overallPassFail bool,
-	outputDir string,
-) error {
+func (g *HtmlReportGenerator) Generate(report Report, outputDir string) (string, error) {
 	// Prepare the display-friendly tag submissions.
-	displaySubmissions := make([]TagSubmissionDisplay, 0, len(tagSubmissions))
-	generated, err := generateTagSubmissionsDisplay(tagSubmissions)
+	displaySubmissions := make([]TagSubmissionDisplay, 0, len(report.TagSubmissions))
+	generated, err := generateTagSubmissionsDisplay(report.TagSubmissions)
 	if err != nil {
-		return errors.Wrap(err, "failed to generate submission tags")
+		return "", errors.Wrap(err, "failed to generate submission tags")
 	}
 	displaySubmissions = append(displaySubmissions, generated...)
 
 	data := TemplateData{
-		TestID:           testID.String(),
-		SequenceName:     sequenceName,
+		TestID:           report.TestID.String(),
+		SequenceName:     report.SequenceName,
+		Metadata:         report.Metadata,
 		TagSubmissions:   displaySubmissions,
-		ErrorSubmissions: errorSubmissions,
-		OverallPassFail:  overallPassFail,
+		ErrorSubmissions: report.ErrorSubmissions,
+		OverallPassFail:  report.OverallPassFail,
+		TeardownErrors:   report.TeardownErrors,
+		RetriedStates:    report.RetriedStates,
+		Artifacts:        report.Artifacts,
 		Timestamp:        time.Now().Format("2006-01-02 15:04:05"),
 	}
 
+	if g.history != nil {
+		// An unreadable history should not cost us the report, the error is shown in the trends section instead.
+		data.Trends, err = g.trends(report)
+		if err != nil {
+			data.TrendsError = err.Error()
+		}
+	}
+
 	tmpl, err := template.New("report").Parse(templateString)
 	if err != nil {
-		return errors.Wrap(err, "failed to parse HTML template")
+		return "", errors.Wrap(err, "failed to parse HTML template")
 	}
 
-	fileName := fmt.Sprintf("report_%s_%s.html", sequenceName, testID.String())
+	fileName := fmt.Sprintf("report_%s_%s.html", report.SequenceName, report.TestID.String())
 	filePath := filepath.Join(outputDir, fileName)
 
 	file, err := os.Create(filePath)
 	if err != nil {
-		return errors.Wrap(err, "failed to create report file")
+		return "", errors.Wrap(err, "failed to create report file")
 	}
 	defer file.Close()
 
 	if err := tmpl.Execute(file, data); err != nil {
-		return errors.Wrap(err, "failed to execute template")
+		return "", errors.Wrap(err, "failed to execute template")
+	}
+
+	return filePath, nil
+}
+
+func (g *HtmlReportGenerator) trends(report Report) ([]TagTrend, error) {
+	current, err := NewExport(report)
+	if err != nil {
+		return nil, errors.Wrap(err, "new export")
+	}
+
+	history, err := g.history.Load(report.SequenceName)
+	if err != nil {
+		return nil, errors.Wrap(err, "load history")
 	}
 
-	return nil
+	return g.analyzer.Analyze(history, current), nil
 }
 
 // formatComparison generates a display-friendly comparison string based on the ComparisonOperator.
@@ -110,6 +159,23 @@ func formatComparison(tag Tag) (string, error) {
 		return fmt.Sprintf("X < %v", tag.UpperLimit), nil
 	case Eq:
 		return fmt.Sprintf("X == %v", tag.ExpectedValue), nil
+	case Ne:
+		return fmt.Sprintf("X ≠ %v", tag.ExpectedValue), nil
+	case Approx:
+		if tag.TolerancePercent != nil {
+			return fmt.Sprintf("X ≈ %v ± %v%%", tag.ExpectedValue, tag.TolerancePercent), nil
+		}
+		return fmt.Sprintf("X ≈ %v ± %v", tag.ExpectedValue, tag.Tolerance), nil
+	case Match:
+		return fmt.Sprintf("X matches /%s/", tag.Pattern), nil
+	case In:
+		values := make([]string, len(tag.Values))
+		for i, value := range tag.Values {
+			values[i] = fmt.Sprint(value)
+		}
+		return fmt.Sprintf("X ∈ {%s}", strings.Join(values, ", ")), nil
+	case Mask:
+		return fmt.Sprintf("X & %#x == %#x", tag.Mask, tag.ExpectedValue), nil
 	case Log:
 		return "LOG", nil // Adjust as needed for the LOG operator
 	default:
@@ -120,13 +186,21 @@ func formatComparison(tag Tag) (string, error) {
 // generateTagSubmissionsDisplay generates the submission displays for each submission tag.
 func generateTagSubmissionsDisplay(tagSubmissions map[string]TagSubmission) ([]TagSubmissionDisplay, error) {
 	generated := make([]TagSubmissionDisplay, 0, len(tagSubmissions))
-	for tagID, submission := range tagSubmissions {
+	for key, submission := range tagSubmissions {
 		comparison, err := formatComparison(submission.Tag)
 		if err != nil {
-			return generated, errors.Wrapf(err, "failed to format comparison for tag %s", tagID)
+			return generated, errors.Wrapf(err, "failed to format comparison for tag %s", key)
 		}
+
+		tagID := submission.TagID
+		if tagID == "" {
+			tagID = key
+		}
+
 		generated = append(generated, TagSubmissionDisplay{
 			TagID:             tagID,
+			StatePath:         submission.StatePath,
+			Params:            submission.Params.String(),
 			Tag:               submission.Tag,
 			Value:             submission.Value,
 			IsPassing:         submission.IsPassing,
@@ -134,5 +208,18 @@ func generateTagSubmissionsDisplay(tagSubmissions map[string]TagSubmission) ([]T
 		})
 	}
 
+	// Keep the iterations of a tag, and the states that submitted it, next to each other.
+	sort.Slice(generated, func(i, j int) bool {
+		if generated[i].TagID != generated[j].TagID {
+			return generated[i].TagID < generated[j].TagID
+		}
+
+		if generated[i].StatePath != generated[j].StatePath {
+			return generated[i].StatePath < generated[j].StatePath
+		}
+
+		return generated[i].Params < generated[j].Params
+	})
+
 	return generated, nil
 }
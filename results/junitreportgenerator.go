@@ -0,0 +1,233 @@
+package results
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	_junitSuitesName     = "hil"
+	_junitTimestampFmt   = "2006-01-02T15:04:05"
+	_junitErrorClassName = "errors"
+)
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Id         string          `xml:"id,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+	SystemErr  string          `xml:"system-err,omitempty"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	Error      *junitFailure   `xml:"error,omitempty"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitFailure is used for both failure and error elements.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JunitReportGenerator generates JUnit XML reports so CI can show each tag as a test case.
+// The sequence is a test suite, each tag submission is a test case and each error submission is a test case with
+// an error.
+type JunitReportGenerator struct{}
+
+// NewJunitReportGenerator creates a new JunitReportGenerator.
+func NewJunitReportGenerator() *JunitReportGenerator {
+	return &JunitReportGenerator{}
+}
+
+// Generate creates a JUnit XML report in outputDir.
+func (g *JunitReportGenerator) Generate(report Report, outputDir string) (string, error) {
+	suite, err := junitSuite(report)
+	if err != nil {
+		return "", errors.Wrap(err, "junit suite")
+	}
+
+	suites := junitTestSuites{
+		Name:     _junitSuitesName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "xml marshal")
+	}
+
+	fileName := fmt.Sprintf("junit_%s_%s.xml", report.SequenceName, report.TestID.String())
+	filePath := filepath.Join(outputDir, fileName)
+
+	err = os.WriteFile(filePath, append([]byte(xml.Header), data...), 0644)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write junit report")
+	}
+
+	return filePath, nil
+}
+
+func junitSuite(report Report) (junitTestSuite, error) {
+	suite := junitTestSuite{
+		Name:       report.SequenceName,
+		Id:         report.TestID.String(),
+		Timestamp:  time.Now().Format(_junitTimestampFmt),
+		Properties: junitSuiteProperties(report),
+		TestCases:  make([]junitTestCase, 0, len(report.TagSubmissions)+len(report.ErrorSubmissions)),
+	}
+
+	submissions, err := generateTagSubmissionsDisplay(report.TagSubmissions)
+	if err != nil {
+		return junitTestSuite{}, errors.Wrap(err, "generate tag submissions display")
+	}
+
+	for _, submission := range submissions {
+		testCase := junitTagTestCase(report.SequenceName, submission)
+		if testCase.Failure != nil {
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for i, err := range report.ErrorSubmissions {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      fmt.Sprintf("error %d", i+1),
+			ClassName: report.SequenceName + "." + _junitErrorClassName,
+			Error: &junitFailure{
+				Message: err.Error(),
+				Type:    "error",
+				Text:    fmt.Sprintf("%+v", err),
+			},
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	suite.Errors = len(report.ErrorSubmissions)
+
+	// Teardown errors do not fail the test, but CI should still show them.
+	if len(report.TeardownErrors) > 0 {
+		teardownErrs := make([]string, len(report.TeardownErrors))
+		for i, err := range report.TeardownErrors {
+			teardownErrs[i] = "teardown error: " + err.Error()
+		}
+
+		suite.SystemErr = strings.Join(teardownErrs, "\n")
+	}
+
+	return suite, nil
+}
+
+// junitSuiteProperties lists the test id and metadata of the report, sorted by name.
+func junitSuiteProperties(report Report) []junitProperty {
+	properties := []junitProperty{{Name: "testId", Value: report.TestID.String()}}
+
+	keys := make([]string, 0, len(report.Metadata))
+	for key := range report.Metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		properties = append(properties, junitProperty{Name: "metadata." + key, Value: report.Metadata[key]})
+	}
+
+	return properties
+}
+
+// junitTagTestCase creates the test case of a tag submission, it has a failure if the tag did not pass.
+func junitTagTestCase(sequenceName string, submission TagSubmissionDisplay) junitTestCase {
+	tag := submission.Tag
+
+	name := submission.TagID
+	if submission.Params != "" {
+		name = fmt.Sprintf("%s [%s]", submission.TagID, submission.Params)
+	}
+
+	className := sequenceName
+	if submission.StatePath != "" {
+		className = sequenceName + "." + submission.StatePath
+	}
+
+	properties := []junitProperty{
+		{Name: "description", Value: tag.Description},
+		{Name: "value", Value: fmt.Sprint(submission.Value)},
+		{Name: "compareOp", Value: tag.CompOp.String()},
+		{Name: "comparison", Value: submission.ComparisonDisplay},
+		{Name: "unit", Value: tag.Unit},
+	}
+
+	if tag.LowerLimit != nil {
+		properties = append(properties, junitProperty{Name: "lowerLimit", Value: fmt.Sprint(tag.LowerLimit)})
+	}
+
+	if tag.UpperLimit != nil {
+		properties = append(properties, junitProperty{Name: "upperLimit", Value: fmt.Sprint(tag.UpperLimit)})
+	}
+
+	if tag.ExpectedValue != nil {
+		properties = append(properties, junitProperty{Name: "expectedValue", Value: fmt.Sprint(tag.ExpectedValue)})
+	}
+
+	if submission.Params != "" {
+		properties = append(properties, junitProperty{Name: "params", Value: submission.Params})
+	}
+
+	value := strings.TrimSpace(fmt.Sprintf("%v %s", submission.Value, tag.Unit))
+
+	testCase := junitTestCase{
+		Name:       name,
+		ClassName:  className,
+		Properties: properties,
+		SystemOut: fmt.Sprintf("%s\nvalue: %s\ncomparison: %s",
+			tag.Description, value, submission.ComparisonDisplay),
+	}
+
+	if !submission.IsPassing {
+		message := fmt.Sprintf("%s does not satisfy %s", value, submission.ComparisonDisplay)
+
+		testCase.Failure = &junitFailure{
+			Message: message,
+			Type:    tag.CompOp.String(),
+			Text:    message,
+		}
+	}
+
+	return testCase
+}
@@ -0,0 +1,71 @@
+package results
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJunitReportGeneratorGenerate(t *testing.T) {
+	report := Report{
+		TestID:       uuid.New(),
+		SequenceName: "TestSequence",
+		Metadata:     map[string]string{"operator": "jane"},
+		TagSubmissions: map[string]TagSubmission{
+			"voltage": {
+				TagID:     "voltage",
+				Tag:       Tag{Description: "Pack voltage", CompOp: Gele, LowerLimit: 10.0, UpperLimit: 12.0, Unit: "V"},
+				Value:     13.5,
+				IsPassing: false,
+				StatePath: "lv_startup",
+			},
+			"flashed": {
+				TagID:     "flashed",
+				Tag:       Tag{Description: "Flashed", CompOp: Eq, ExpectedValue: true},
+				Value:     true,
+				IsPassing: true,
+			},
+		},
+		ErrorSubmissions: []error{assert.AnError},
+	}
+
+	path, err := NewJunitReportGenerator().Generate(report, t.TempDir())
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var suites junitTestSuites
+
+	err = xml.Unmarshal(data, &suites)
+	require.NoError(t, err)
+
+	require.Len(t, suites.Suites, 1)
+
+	suite := suites.Suites[0]
+	assert.Equal(t, "TestSequence", suite.Name)
+	assert.Equal(t, 3, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 1, suite.Errors)
+	assert.Contains(t, suite.Properties, junitProperty{Name: "metadata.operator", Value: "jane"})
+
+	require.Len(t, suite.TestCases, 3)
+
+	flashed := suite.TestCases[0]
+	assert.Equal(t, "flashed", flashed.Name)
+	assert.Nil(t, flashed.Failure)
+
+	voltage := suite.TestCases[1]
+	assert.Equal(t, "TestSequence.lv_startup", voltage.ClassName)
+	require.NotNil(t, voltage.Failure)
+	assert.Equal(t, "13.5 V does not satisfy 10 ≤ X ≤ 12", voltage.Failure.Message)
+	assert.Contains(t, voltage.Properties, junitProperty{Name: "unit", Value: "V"})
+	assert.Contains(t, voltage.SystemOut, "value: 13.5 V")
+
+	require.NotNil(t, suite.TestCases[2].Error)
+	assert.Equal(t, assert.AnError.Error(), suite.TestCases[2].Error.Message)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
@@ -22,16 +25,26 @@ type ResultAccumulator struct {
 	tagDB            map[string]Tag
 	tagSubmissions   map[string]TagSubmission
 	errorSubmissions []error
+	retriedStates    []RetriedState
+	teardownErrors   []error
+	reportPaths      []string
 	tagsFP           string
 	reportsDir       string
+	bundlesDir       string
+	artifacts        []BundledArtifact
 	allTagsPassing   bool
 	generators       []Generator
 }
 
 type TagSubmission struct {
+	TagID     string
 	Tag       Tag
 	Value     any
 	IsPassing bool
+	// Params are the params of the sequence iteration the tag was submitted in, nil if not parameterized.
+	Params flow.Params
+	// StatePath is the path of the state that submitted the tag, including any sub-sequences it is nested in.
+	StatePath string
 }
 
 func NewResultAccumulator(l *zap.Logger, tagsFP string, generators ...Generator) *ResultAccumulator {
@@ -39,8 +52,12 @@ func NewResultAccumulator(l *zap.Logger, tagsFP string, generators ...Generator)
 		l:                l.Named(_loggerName),
 		tagSubmissions:   make(map[string]TagSubmission),
 		errorSubmissions: []error{},
+		retriedStates:    []RetriedState{},
+		teardownErrors:   []error{},
+		reportPaths:      []string{},
 		tagsFP:           tagsFP,
 		reportsDir:       "",
+		artifacts:        []BundledArtifact{},
 		allTagsPassing:   true,
 		generators:       generators,
 	}
@@ -58,48 +75,98 @@ func (r *ResultAccumulator) Close() error {
 }
 
 func (r *ResultAccumulator) loadTags(_ context.Context) error {
-	r.tagDB = make(map[string]Tag)
+	tagDB, err := LoadTags(r.tagsFP)
+	if err != nil {
+		return errors.Wrap(err, "load tags")
+	}
+
+	r.tagDB = tagDB
 
-	data, err := os.ReadFile(r.tagsFP)
+	return nil
+}
+
+// LoadTags reads the tag database from the tags file, keyed by tag id.
+func LoadTags(tagsFP string) (map[string]Tag, error) {
+	data, err := os.ReadFile(tagsFP)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read tags file (%v)", r.tagsFP)
+		return nil, errors.Wrapf(err, "failed to read tags file (%v)", tagsFP)
 	}
 
 	var tagsMap map[string]Tag
 	err = yaml.Unmarshal(data, &tagsMap)
 	if err != nil {
-		return errors.Wrap(err, "failed to unmarshal tags YAML")
+		return nil, errors.Wrap(err, "failed to unmarshal tags YAML")
 	}
 
+	tagDB := make(map[string]Tag, len(tagsMap))
+
 	for key, tag := range tagsMap {
 		// Convert CompOpString to ComparisonOperator
 		compOp, err := ComparisonOperatorString(strings.ToLower(tag.CompOpString))
 		if err != nil {
-			return errors.Wrapf(err, "invalid comparison operator for tag %s", key)
+			return nil, errors.Wrapf(err, "invalid comparison operator for tag %s", key)
 		}
 		tag.CompOp = compOp
-		r.tagDB[key] = tag
+
+		if tag.TypeString != "" {
+			tagType, err := TagTypeString(strings.ToLower(tag.TypeString))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid type for tag %s", key)
+			}
+
+			tag.Type = tagType
+		}
+
+		err = tag.Validate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tag %s", key)
+		}
+
+		// Limits are converted once here so a tag that can never be compared fails before any test runs.
+		err = tag.Coerce()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tag %s", key)
+		}
+
+		tagDB[key] = tag
 	}
 
-	return nil
+	return tagDB, nil
 }
 
-func (r *ResultAccumulator) SubmitTag(_ context.Context, tagID string, value any) (bool, error) {
+// SubmitTag checks the value against the tag. Tags submitted during a parameterized sequence are stored once per
+// iteration so that each set of params gets its own entry, and tags submitted by a group of states used more than
+// once are stored once per state path.
+func (r *ResultAccumulator) SubmitTag(ctx context.Context, tagID string, value any) (bool, error) {
 	tag, ok := r.tagDB[tagID]
 	if !ok {
 		return false, errors.Errorf("tag not found: %s", tagID)
 	}
 
 	r.l.Info(fmt.Sprintf("Tag Id: %s, val is %t, expected val is %t, comp op %s, desc is %s", tagID, value, tag.ExpectedValue, tag.CompOpString, tag.Description))
+	// Store the normalized value, so a uint8 or time.Duration is reported like an int or float.
+	value, err := tag.Normalize(value)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to validate tag %s", tagID)
+	}
+
 	isPassing, err := tag.IsPassing(value)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to validate tag %s", tagID)
 	}
 
-	r.tagSubmissions[tagID] = TagSubmission{
+	var (
+		params    = flow.IterationParams(ctx)
+		statePath = flow.StatePath(ctx)
+	)
+
+	r.tagSubmissions[submissionKey(tagID, statePath, params)] = TagSubmission{
+		TagID:     tagID,
 		Tag:       tag,
 		Value:     value,
 		IsPassing: isPassing,
+		Params:    params,
+		StatePath: statePath,
 	}
 
 	if !isPassing {
@@ -115,31 +182,145 @@ func (r *ResultAccumulator) SubmitError(_ context.Context, err error) error {
 	return nil
 }
 
-func (r *ResultAccumulator) CompleteTest(_ context.Context, testID uuid.UUID, sequenceName string) (bool, error) {
+// SubmitTeardownError stores an error from a teardown state. It does not fail the test.
+func (r *ResultAccumulator) SubmitTeardownError(_ context.Context, err error) error {
+	r.teardownErrors = append(r.teardownErrors, err)
+	return nil
+}
+
+// SubmitAttempts stores the failed attempts of a retried state so they can be shown in reports.
+func (r *ResultAccumulator) SubmitAttempts(_ context.Context, stateName string, attempts []flow.Attempt) error {
+	r.retriedStates = append(r.retriedStates, RetriedState{
+		StateName: stateName,
+		Attempts:  attempts,
+	})
+
+	return nil
+}
+
+// SubmitArtifact writes the artifact to the bundle directory of the current test so it survives the test's cleanup.
+func (r *ResultAccumulator) SubmitArtifact(ctx context.Context, artifact flow.Artifact) error {
+	if r.bundlesDir == "" {
+		return errors.New("bundles dir is not set")
+	}
+
+	testID := flow.CurrentTestId(ctx)
+	if testID == uuid.Nil {
+		return errors.New("no test id in context")
+	}
+
+	bundleDir := BundleDir(r.bundlesDir, testID)
+
+	err := os.MkdirAll(filepath.Join(bundleDir, _artifactsDirName), 0755)
+	if err != nil {
+		return errors.Wrap(err, "create bundle dir")
+	}
+
+	path, err := writeArtifact(bundleDir, artifact)
+	if err != nil {
+		return errors.Wrap(err, "write artifact")
+	}
+
+	r.l.Info("artifact attached",
+		zap.String("artifact", artifact.Name),
+		zap.String("state", artifact.StateName),
+		zap.String("path", path))
+
+	r.artifacts = append(r.artifacts, BundledArtifact{
+		Name:       artifact.Name,
+		Kind:       artifact.Kind,
+		StateName:  artifact.StateName,
+		Path:       path,
+		AttachedAt: artifact.AttachedAt,
+	})
+
+	return nil
+}
+
+func (r *ResultAccumulator) CompleteTest(ctx context.Context, testID uuid.UUID, sequenceName string) (bool, error) {
 	overallPassFail := r.allTagsPassing && len(r.errorSubmissions) == 0
 
+	report := Report{
+		TestID:           testID,
+		SequenceName:     sequenceName,
+		Metadata:         flow.Metadata(ctx),
+		StartedAt:        flow.TestStartedAt(ctx),
+		CompletedAt:      time.Now(),
+		TagSubmissions:   r.tagSubmissions,
+		ErrorSubmissions: r.errorSubmissions,
+		OverallPassFail:  overallPassFail,
+		TeardownErrors:   r.teardownErrors,
+		RetriedStates:    r.retriedStates,
+		Artifacts:        r.artifacts,
+	}
+
+	// Reports go in the test's bundle with its artifacts if bundles are enabled.
+	outputDir := r.reportsDir
+
+	if r.bundlesDir != "" {
+		outputDir = BundleDir(r.bundlesDir, testID)
+
+		err := os.MkdirAll(outputDir, 0755)
+		if err != nil {
+			return false, errors.Wrap(err, "create bundle dir")
+		}
+	}
+
+	r.reportPaths = make([]string, 0, len(r.generators))
+
 	for _, generator := range r.generators {
-		err := generator.Generate(
-			testID,
-			sequenceName,
-			r.tagSubmissions,
-			r.errorSubmissions,
-			overallPassFail,
-			r.reportsDir,
-		)
+		reportPath, err := generator.Generate(report, outputDir)
 		if err != nil {
 			return false, errors.Wrap(err, "failed to generate report")
 		}
+
+		r.reportPaths = append(r.reportPaths, reportPath)
+	}
+
+	if r.bundlesDir != "" {
+		err := writeManifest(outputDir, report, r.reportPaths)
+		if err != nil {
+			return false, errors.Wrap(err, "write manifest")
+		}
 	}
 
 	// Reset cached submissions
 	r.tagSubmissions = make(map[string]TagSubmission)
 	r.errorSubmissions = []error{}
+	r.retriedStates = []RetriedState{}
+	r.teardownErrors = []error{}
+	r.artifacts = []BundledArtifact{}
 	r.allTagsPassing = true
 
 	return overallPassFail, nil
 }
 
+// submissionKey is the key of a tag submission, it includes the path of the state that submitted it and the
+// iteration params if there are any.
+func submissionKey(tagID, statePath string, params flow.Params) string {
+	key := tagID
+	if statePath != "" {
+		key = statePath + ":" + tagID
+	}
+
+	if len(params) == 0 {
+		return key
+	}
+
+	return fmt.Sprintf("%s[%s]", key, params.String())
+}
+
+// ReportPaths returns the paths of the reports generated for the last completed test.
+func (r *ResultAccumulator) ReportPaths() []string {
+	return r.reportPaths
+}
+
 func (r *ResultAccumulator) SetReportsDir(reportsDir string) {
 	r.reportsDir = reportsDir
 }
+
+// SetBundlesDir enables test bundles. The reports and artifacts of each test are written to their own directory
+// in bundlesDir named after the TestId, see BundleDir. Reports are no longer written to the reports dir.
+func (r *ResultAccumulator) SetBundlesDir(bundlesDir string) {
+	r.bundlesDir = bundlesDir
+}
@@ -2,6 +2,7 @@ package results
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +102,23 @@ func TestResultAccumulatorSubmitTag(t *testing.T) {
 		{"stringEq", "unexpected", false},
 		{"boolEq", true, true},
 		{"boolEq", false, false},
+		{"numericNe", 1, true},
+		{"numericNe", 0, false},
+		{"floatApprox", 12.4, true},
+		{"floatApprox", 11.4, false},
+		{"floatApproxPercent", 209.9, true},
+		{"floatApproxPercent", 189, false},
+		{"stringMatch", "v1.12.3", true},
+		{"stringMatch", "v1.12.3-dirty", false},
+		{"stringIn", "Idle", true},
+		{"stringIn", "Fault", false},
+		{"statusMask", 0xA5, true},
+		{"statusMask", 0xA4, false},
+		{"typedIntGele", uint8(250), true},
+		{"typedIntGele", 250 * time.Millisecond, true},
+		{"typedIntGele", int64(301), false},
+		{"typedFloatLe", 2500 * time.Millisecond, true},
+		{"typedFloatLe", float32(3.5), false},
 		{"logTag", "any value", true},
 		{"logNumber", 10, true},
 	}
@@ -124,6 +143,11 @@ func TestResultAccumulatorSubmitTag(t *testing.T) {
 	_, err = setup.ra.SubmitTag(ctx, "numericGt", "not a number")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to validate tag")
+
+	// Submitted values are stored normalized to the tag type
+	_, err = setup.ra.SubmitTag(ctx, "typedIntGele", 250*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 250, setup.ra.tagSubmissions[submissionKey("typedIntGele", "", nil)].Value)
 }
 
 func TestResultAccumulatorSubmitError(t *testing.T) {
@@ -198,6 +222,30 @@ func TestResultAccumulatorEdgeCases(t *testing.T) {
 		err = ra.Open(context.Background())
 		assert.Error(t, err)
 	})
+
+	t.Run("IncompatibleTagType", func(t *testing.T) {
+		setup := setupTest(t)
+
+		invalidTagsFile := filepath.Join(setup.tempDir, "incompatible_tag.yaml")
+		err := os.WriteFile(invalidTagsFile, []byte("badLimit:\n  compareOp: le\n  upperLimit: 1.5\n  type: int\n"), 0644)
+		require.NoError(t, err)
+
+		ra := NewResultAccumulator(zap.NewNop(), invalidTagsFile, setup.ra.generators...)
+		err = ra.Open(context.Background())
+		assert.ErrorContains(t, err, "invalid tag badLimit")
+	})
+
+	t.Run("InvalidTag", func(t *testing.T) {
+		setup := setupTest(t)
+
+		invalidTagsFile := filepath.Join(setup.tempDir, "invalid_tag.yaml")
+		err := os.WriteFile(invalidTagsFile, []byte("badMatch:\n  compareOp: match\n  pattern: 'v('\n"), 0644)
+		require.NoError(t, err)
+
+		ra := NewResultAccumulator(zap.NewNop(), invalidTagsFile, setup.ra.generators...)
+		err = ra.Open(context.Background())
+		assert.ErrorContains(t, err, "invalid tag badMatch")
+	})
 }
 
 func TestResultAccumulatorSubmitTagAndCompleteTestPass(t *testing.T) {
@@ -281,6 +329,23 @@ func TestResultAccumulatorSubmitTagAndCompleteTestFail(t *testing.T) {
 		{"numericGtlt", 20, false},
 		{"stringEq", "unexpected", false},
 		{"boolEq", false, false},
+		{"numericNe", 1, true},
+		{"numericNe", 0, false},
+		{"floatApprox", 12.4, true},
+		{"floatApprox", 11.4, false},
+		{"floatApproxPercent", 209.9, true},
+		{"floatApproxPercent", 189, false},
+		{"stringMatch", "v1.12.3", true},
+		{"stringMatch", "v1.12.3-dirty", false},
+		{"stringIn", "Idle", true},
+		{"stringIn", "Fault", false},
+		{"statusMask", 0xA5, true},
+		{"statusMask", 0xA4, false},
+		{"typedIntGele", uint8(250), true},
+		{"typedIntGele", 250 * time.Millisecond, true},
+		{"typedIntGele", int64(301), false},
+		{"typedFloatLe", 2500 * time.Millisecond, true},
+		{"typedFloatLe", float32(3.5), false},
 		{"logTag", "any value", true},
 		{"logNumber", 10, true},
 	}
@@ -323,3 +388,149 @@ func TestResultAccumulatorSubmitTagAndCompleteTestFail(t *testing.T) {
 		assert.True(t, setup.ra.allTagsPassing)
 	})
 }
+
+func TestResultAccumulatorSubmitAttempts(t *testing.T) {
+	setup := setupTest(t)
+	ctx := context.Background()
+
+	err := setup.ra.Open(ctx)
+	require.NoError(t, err)
+
+	attempts := []flow.Attempt{
+		{Number: 1, Duration: time.Second, Err: errors.New("power cycle failed")},
+	}
+
+	err = setup.ra.SubmitAttempts(ctx, "lv_startup", attempts)
+	require.NoError(t, err)
+
+	_, err = setup.ra.SubmitTag(ctx, "numericGt", 15)
+	require.NoError(t, err)
+
+	testID := uuid.New()
+	sequenceName := "TestSequenceRetried"
+
+	overallPassFail, err := setup.ra.CompleteTest(ctx, testID, sequenceName)
+	assert.NoError(t, err)
+	assert.True(t, overallPassFail, "retried attempts should not fail the test")
+
+	t.Run("HtmlReportGeneration", func(t *testing.T) {
+		htmlReportPath := filepath.Join(setup.resultsDir, fmt.Sprintf("report_%s_%s.html", sequenceName, testID.String()))
+
+		htmlContent, err := os.ReadFile(htmlReportPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(htmlContent), "Retried States")
+		assert.Contains(t, string(htmlContent), "lv_startup")
+		assert.Contains(t, string(htmlContent), "power cycle failed")
+	})
+
+	t.Run("ResetSubmissions", func(t *testing.T) {
+		assert.Empty(t, setup.ra.retriedStates)
+	})
+}
+
+func TestResultAccumulatorSubmitTagWithParams(t *testing.T) {
+	setup := setupTest(t)
+	ctx := context.Background()
+
+	err := setup.ra.Open(ctx)
+	require.NoError(t, err)
+
+	// No params are keyed by the tag id alone.
+	_, err = setup.ra.SubmitTag(ctx, "numericGt", 15)
+	require.NoError(t, err)
+
+	// The same tag submitted in separate iterations should not overwrite each other.
+	for _, pedal := range []int{0, 50, 100} {
+		iterationCtx := flow.WithIterationParams(ctx, flow.Params{"pedal": pedal})
+
+		_, err = setup.ra.SubmitTag(iterationCtx, "numericGt", pedal)
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, setup.ra.tagSubmissions, 4)
+	assert.Contains(t, setup.ra.tagSubmissions, "numericGt")
+	assert.Contains(t, setup.ra.tagSubmissions, "numericGt[pedal=50]")
+	assert.Equal(t, flow.Params{"pedal": 50}, setup.ra.tagSubmissions["numericGt[pedal=50]"].Params)
+	assert.False(t, setup.ra.allTagsPassing)
+	assert.Equal(t, "numericGt", submissionKey("numericGt", "", nil))
+	assert.Equal(t, "numericGt[pedal=50,rev=sil]",
+		submissionKey("numericGt", "", flow.Params{"rev": "sil", "pedal": 50}))
+	assert.Equal(t, "lv_sequence/poll_tsal:numericGt[pedal=50]",
+		submissionKey("numericGt", "lv_sequence/poll_tsal", flow.Params{"pedal": 50}))
+}
+
+func TestResultAccumulatorSubmitTagWithStatePath(t *testing.T) {
+	setup := setupTest(t)
+	ctx := context.Background()
+
+	err := setup.ra.Open(ctx)
+	require.NoError(t, err)
+
+	// The same group of states is used twice, so the tag is submitted from two paths.
+	paths := map[string]float64{
+		"lv_sequence/lv_startup/poll_tsal": 15,
+		"lv_sequence/lv_restart/poll_tsal": 5,
+	}
+
+	for path, value := range paths {
+		_, err = setup.ra.SubmitTag(flow.WithStatePath(ctx, path), "numericGt", value)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, setup.ra.tagSubmissions, 2)
+
+	for path, value := range paths {
+		submission, ok := setup.ra.tagSubmissions[submissionKey("numericGt", path, nil)]
+		require.True(t, ok, path)
+		assert.Equal(t, path, submission.StatePath)
+		assert.Equal(t, value, submission.Value)
+	}
+
+	assert.False(t, setup.ra.allTagsPassing)
+
+	reportPath, err := setup.ra.generators[0].Generate(Report{
+		TestID:         uuid.New(),
+		SequenceName:   "lv_sequence",
+		TagSubmissions: setup.ra.tagSubmissions,
+	}, setup.resultsDir)
+	require.NoError(t, err)
+
+	htmlContent, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(htmlContent), "lv_sequence/lv_startup/poll_tsal")
+	assert.Contains(t, string(htmlContent), "lv_sequence/lv_restart/poll_tsal")
+}
+
+func TestResultAccumulatorCompleteTestWithMetadata(t *testing.T) {
+	setup := setupTest(t)
+	ctx := context.Background()
+
+	err := setup.ra.Open(ctx)
+	require.NoError(t, err)
+
+	setup.ra.SetBundlesDir(setup.resultsDir)
+
+	testID := uuid.New()
+	metadataCtx := flow.WithMetadata(ctx, map[string]string{
+		"firmware_commit": "abc123",
+		"operator":        "bench-ci",
+	})
+
+	_, err = setup.ra.SubmitTag(metadataCtx, "numericGt", 15)
+	require.NoError(t, err)
+
+	_, err = setup.ra.CompleteTest(metadataCtx, testID, "TestSequence")
+	require.NoError(t, err)
+
+	require.Len(t, setup.ra.ReportPaths(), 1)
+
+	htmlContent, err := os.ReadFile(setup.ra.ReportPaths()[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(htmlContent), "firmware_commit")
+	assert.Contains(t, string(htmlContent), "abc123")
+	assert.Contains(t, string(htmlContent), "bench-ci")
+
+	manifestContent, err := os.ReadFile(filepath.Join(BundleDir(setup.resultsDir, testID), _manifestFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(manifestContent), `"firmware_commit": "abc123"`)
+}
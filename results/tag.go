@@ -1,85 +1,446 @@
 package results
 
 import (
+	"fmt"
+	"math"
+	"regexp"
+
 	"github.com/pkg/errors"
-	"golang.org/x/exp/constraints"
 )
 
 // Tag is a single test tag
 type Tag struct {
-	Description   string `yaml:"description"`
-	CompOpString  string `yaml:"compareOp"`
-	CompOp        ComparisonOperator
-	UpperLimit    any    `yaml:"upperLimit,omitempty"`
-	LowerLimit    any    `yaml:"lowerLimit,omitempty"`
-	ExpectedValue any    `yaml:"expectedValue,omitempty"`
-	Unit          string `yaml:"unit"`
+	Description  string `yaml:"description"`
+	CompOpString string `yaml:"compareOp"`
+	CompOp       ComparisonOperator
+	// TypeString is the declared type of the tag's values, see TagType. Leave it empty to infer the type.
+	TypeString    string  `yaml:"type,omitempty"`
+	Type          TagType `yaml:"-"`
+	UpperLimit    any     `yaml:"upperLimit,omitempty"`
+	LowerLimit    any     `yaml:"lowerLimit,omitempty"`
+	ExpectedValue any     `yaml:"expectedValue,omitempty"`
+	// Tolerance is the absolute tolerance of an Approx comparison, only one of Tolerance and TolerancePercent is set.
+	Tolerance any `yaml:"tolerance,omitempty"`
+	// TolerancePercent is the tolerance of an Approx comparison relative to the expected value.
+	TolerancePercent any `yaml:"tolerancePercent,omitempty"`
+	// Pattern is the regular expression of a Match comparison, it must match the whole string.
+	Pattern string `yaml:"pattern,omitempty"`
+	// Values are the allowed values of an In comparison.
+	Values []any `yaml:"values,omitempty"`
+	// Mask selects the bits of a Mask comparison, they must equal ExpectedValue.
+	Mask any    `yaml:"mask,omitempty"`
+	Unit string `yaml:"unit"`
 }
 
-// IsPassing checks if the value passes the tag
+// Validate checks that the tag has the limits or expected value its comparison operator needs, and that its limits
+// can be met by some value.
+func (t *Tag) Validate() error {
+	switch t.CompOp {
+	case Log:
+		return nil
+	case Eq, Ne:
+		if t.ExpectedValue == nil {
+			return errors.Errorf("comparison (%s) requires an expected value", t.CompOp)
+		}
+	case Approx:
+		err := t.validateApprox()
+		if err != nil {
+			return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+		}
+	case Match:
+		if t.Pattern == "" {
+			return errors.Errorf("comparison (%s) requires a pattern", t.CompOp)
+		}
+
+		_, err := compilePattern(t.Pattern)
+		if err != nil {
+			return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+		}
+	case In:
+		if len(t.Values) == 0 {
+			return errors.Errorf("comparison (%s) requires at least one value", t.CompOp)
+		}
+	case Mask:
+		err := t.validateMask()
+		if err != nil {
+			return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+		}
+	case Gt, Ge:
+		_, err := numericLimit("lower", t.LowerLimit)
+		if err != nil {
+			return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+		}
+	case Lt, Le:
+		_, err := numericLimit("upper", t.UpperLimit)
+		if err != nil {
+			return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+		}
+	case Gele, Gtlt:
+		lower, err := numericLimit("lower", t.LowerLimit)
+		if err != nil {
+			return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+		}
+
+		upper, err := numericLimit("upper", t.UpperLimit)
+		if err != nil {
+			return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+		}
+
+		if lower > upper || (t.CompOp == Gtlt && lower == upper) {
+			return errors.Errorf("comparison (%s) can never pass with lower limit (%v) and upper limit (%v)",
+				t.CompOp, t.LowerLimit, t.UpperLimit)
+		}
+	default:
+		return errors.Errorf("unknown comparison operator (%v)", t.CompOp.String())
+	}
+
+	err := t.checkValueType()
+	if err != nil {
+		return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+	}
+
+	return nil
+}
+
+func (t *Tag) validateApprox() error {
+	_, err := numericLimit("expected", t.ExpectedValue)
+	if err != nil {
+		return errors.Wrap(err, "expected value")
+	}
+
+	if (t.Tolerance == nil) == (t.TolerancePercent == nil) {
+		return errors.New("exactly one of tolerance and tolerance percent is required")
+	}
+
+	name, tolerance := "tolerance", t.Tolerance
+	if t.TolerancePercent != nil {
+		name, tolerance = "tolerance percent", t.TolerancePercent
+	}
+
+	limit, err := numericLimit(name, tolerance)
+	if err != nil {
+		return errors.Wrap(err, "tolerance")
+	}
+
+	if limit < 0 {
+		return errors.Errorf("%s (%v) must not be negative", name, tolerance)
+	}
+
+	return nil
+}
+
+func (t *Tag) validateMask() error {
+	mask, err := coerceValue(TypeInt, t.Mask, "")
+	if err != nil {
+		return errors.Wrap(err, "mask")
+	}
+
+	expected, err := coerceValue(TypeInt, t.ExpectedValue, "")
+	if err != nil {
+		return errors.Wrap(err, "expected value")
+	}
+
+	if expected.(int)&^mask.(int) != 0 {
+		return errors.Errorf("can never pass with expected value (%#x) outside of mask (%#x)", expected, mask)
+	}
+
+	return nil
+}
+
+// ValueType returns the declared type of the tag. Tags without a declared type infer it from their comparison, and
+// the first operand it uses: booleans and strings keep their type and numbers are compared as floats.
+func (t *Tag) ValueType() TagType {
+	if t.Type != TypeAny {
+		return t.Type
+	}
+
+	switch t.CompOp {
+	case Match:
+		return TypeString
+	case Mask:
+		return TypeInt
+	}
+
+	operands := t.operands()
+	if len(operands) == 0 {
+		return TypeAny
+	}
+
+	switch operands[0].(type) {
+	case bool:
+		return TypeBool
+	case string:
+		return TypeString
+	}
+
+	_, err := coerceValue(TypeFloat, operands[0], "")
+	if err != nil {
+		return TypeAny
+	}
+
+	return TypeFloat
+}
+
+// Coerce converts the expected value, limits, values and mask of the tag to its ValueType. It returns an error if the
+// type can not be compared with the tag's comparison operator, or an operand is not a valid value of the type.
+func (t *Tag) Coerce() error {
+	err := t.checkValueType()
+	if err != nil {
+		return errors.Wrapf(err, "comparison (%s)", t.CompOp)
+	}
+
+	tagType := t.ValueType()
+	if tagType == TypeAny {
+		return nil
+	}
+
+	coerce := func(name string, operand *any) error {
+		if *operand == nil {
+			return nil
+		}
+
+		coerced, err := coerceValue(tagType, *operand, "")
+		if err != nil {
+			return errors.Wrap(err, name)
+		}
+
+		*operand = coerced
+
+		return nil
+	}
+
+	switch t.CompOp {
+	case Eq, Ne, Approx:
+		err = coerce("expected value", &t.ExpectedValue)
+	case Gt, Ge:
+		err = coerce("lower limit", &t.LowerLimit)
+	case Lt, Le:
+		err = coerce("upper limit", &t.UpperLimit)
+	case Gele, Gtlt:
+		err = coerce("lower limit", &t.LowerLimit)
+		if err == nil {
+			err = coerce("upper limit", &t.UpperLimit)
+		}
+	case Mask:
+		// Mask tags are always integers, see ValueType.
+		err = coerce("expected value", &t.ExpectedValue)
+		if err == nil {
+			err = coerce("mask", &t.Mask)
+		}
+	case In:
+		for i := range t.Values {
+			err = coerce(fmt.Sprintf("value %d", i), &t.Values[i])
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	return err
+}
+
+// Normalize converts a submitted value to the tag's ValueType, see TagType. Durations are converted to the tag's unit.
+func (t *Tag) Normalize(value any) (any, error) {
+	return coerceValue(t.ValueType(), value, t.Unit)
+}
+
+// checkValueType returns an error if the comparison operator does not support the tag's ValueType.
+func (t *Tag) checkValueType() error {
+	var supported bool
+
+	switch t.ValueType() {
+	case TypeAny:
+		supported = t.CompOp == Log
+	case TypeBool:
+		supported = t.CompOp == Eq || t.CompOp == Ne || t.CompOp == In || t.CompOp == Log
+	case TypeString:
+		supported = t.CompOp == Eq || t.CompOp == Ne || t.CompOp == Match || t.CompOp == In || t.CompOp == Log
+	case TypeInt:
+		supported = t.CompOp != Match
+	case TypeFloat:
+		supported = t.CompOp != Match && t.CompOp != Mask
+	}
+
+	if !supported {
+		if t.ValueType() == TypeAny {
+			return errors.New("can not infer the value type, declare a type")
+		}
+
+		return errors.Errorf("does not support %s values", t.ValueType())
+	}
+
+	return nil
+}
+
+// operands returns the expected value, limits or values the comparison operator uses.
+func (t *Tag) operands() []any {
+	var operands []any
+
+	switch t.CompOp {
+	case Eq, Ne, Approx, Mask:
+		operands = []any{t.ExpectedValue}
+	case Gt, Ge:
+		operands = []any{t.LowerLimit}
+	case Lt, Le:
+		operands = []any{t.UpperLimit}
+	case Gele, Gtlt:
+		operands = []any{t.LowerLimit, t.UpperLimit}
+	case In:
+		operands = t.Values
+	}
+
+	ret := make([]any, 0, len(operands))
+	for _, operand := range operands {
+		if operand != nil {
+			ret = append(ret, operand)
+		}
+	}
+
+	return ret
+}
+
+// IsPassing checks if the value passes the tag. The value is normalized to the tag's ValueType first, see Normalize.
 func (t *Tag) IsPassing(value any) (bool, error) {
 	if t.CompOp == Log {
 		return true, nil
 	}
 
-	switch v := value.(type) {
+	v, err := t.Normalize(value)
+	if err != nil {
+		return false, errors.Wrap(err, "normalize value")
+	}
+
+	if t.CompOp == In {
+		return isPassingIn(v, t.ValueType(), t.Values)
+	}
+
+	switch v := v.(type) {
 	case bool:
 		return isPassingBool(v, t.CompOp, t.ExpectedValue)
 	case int:
-		return isPassingNumeric(v, t.CompOp, t.ExpectedValue, t.UpperLimit, t.LowerLimit)
+		if t.CompOp == Mask {
+			return isPassingMask(v, t.Mask, t.ExpectedValue)
+		}
+
+		return isPassingNumeric(v, TypeInt, t)
 	case float64:
-		return isPassingNumeric(v, t.CompOp, t.ExpectedValue, t.UpperLimit, t.LowerLimit)
+		return isPassingNumeric(v, TypeFloat, t)
 	case string:
-		return isPassingString(v, t.CompOp, t.ExpectedValue)
+		return isPassingString(v, t.CompOp, t.ExpectedValue, t.Pattern)
 	default:
 		return false, errors.Errorf("unsupported type (%T)", value)
 	}
 }
 
 func isPassingBool(value bool, compOp ComparisonOperator, expectedValue any) (bool, error) {
-	if compOp != Eq {
+	if compOp != Eq && compOp != Ne {
 		return false, errors.New("boolean values only support equality comparison")
 	}
 
-	expected, ok := expectedValue.(bool)
-	if !ok {
-		return false, errors.Errorf("expected value must be boolean for equality comparison (ev: %T)", expectedValue)
+	expected, err := coerceValue(TypeBool, expectedValue, "")
+	if err != nil {
+		return false, errors.Wrap(err, "expected value")
+	}
+
+	return (value == expected) == (compOp == Eq), nil
+}
+
+func isPassingString(value string, compOp ComparisonOperator, expectedValue any, pattern string) (bool, error) {
+	if compOp == Match {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return false, errors.Wrap(err, "compile pattern")
+		}
+
+		return re.MatchString(value), nil
+	}
+
+	if compOp != Eq && compOp != Ne {
+		return false, errors.New("string values only support equality and match comparison")
+	}
+
+	expected, err := coerceValue(TypeString, expectedValue, "")
+	if err != nil {
+		return false, errors.Wrap(err, "expected value")
+	}
+
+	return (value == expected) == (compOp == Eq), nil
+}
+
+// isPassingIn checks that the value is one of values, after converting them to tagType.
+func isPassingIn(value any, tagType TagType, values []any) (bool, error) {
+	for i, v := range values {
+		coerced, err := coerceValue(tagType, v, "")
+		if err != nil {
+			return false, errors.Wrapf(err, "value %d", i)
+		}
+
+		if coerced == value {
+			return true, nil
+		}
 	}
 
-	return value == expected, nil
+	return false, nil
 }
 
-func isPassingString(value string, compOp ComparisonOperator, expectedValue any) (bool, error) {
-	if compOp != Eq {
-		return false, errors.New("string values only support equality comparison")
+func isPassingMask(value int, mask, expectedValue any) (bool, error) {
+	m, err := coerceValue(TypeInt, mask, "")
+	if err != nil {
+		return false, errors.Wrap(err, "mask")
 	}
 
-	expected, ok := expectedValue.(string)
-	if !ok {
-		return false, errors.New("expectedValue must be string for string comparison")
+	expected, err := coerceValue(TypeInt, expectedValue, "")
+	if err != nil {
+		return false, errors.Wrap(err, "expected value")
 	}
 
-	return value == expected, nil
+	return value&m.(int) == expected.(int), nil
 }
 
-func isPassingNumeric[T constraints.Ordered](
-	value T,
-	compOp ComparisonOperator,
-	expectedValue, upperLimit, lowerLimit any,
-) (bool, error) {
+// compilePattern anchors the pattern so it must match the whole value.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern (%s)", pattern)
+	}
+
+	return re, nil
+}
+
+func isPassingNumeric[T int | float64](value T, tagType TagType, tag *Tag) (bool, error) {
+	compOp := tag.CompOp
+
+	operand := func(name string, v any) (T, error) {
+		coerced, err := coerceValue(tagType, v, "")
+		if err != nil {
+			return 0, errors.Wrap(err, name)
+		}
+
+		return coerced.(T), nil
+	}
+
 	switch compOp {
-	case Eq:
-		expected, ok := expectedValue.(T)
-		if !ok {
-			return false, errors.New("expectedValue type mismatch")
+	case Eq, Ne:
+		expected, err := operand("expected value", tag.ExpectedValue)
+		if err != nil {
+			return false, err
 		}
-		return value == expected, nil
+		return (value == expected) == (compOp == Eq), nil
+
+	case Approx:
+		return isPassingApprox(float64(value), tag)
+
+	case Mask:
+		return false, errors.New("bitmask comparison requires an integer value")
 
 	case Gele, Gtlt:
-		upper, ok1 := upperLimit.(T)
-		lower, ok2 := lowerLimit.(T)
-		if !ok1 || !ok2 {
-			return false, errors.New("limit values type mismatch")
+		upper, err := operand("upper limit", tag.UpperLimit)
+		if err != nil {
+			return false, err
+		}
+		lower, err := operand("lower limit", tag.LowerLimit)
+		if err != nil {
+			return false, err
 		}
 		if compOp == Gele {
 			return value >= lower && value <= upper, nil
@@ -87,9 +448,9 @@ func isPassingNumeric[T constraints.Ordered](
 		return value > lower && value < upper, nil
 
 	case Gt, Ge:
-		lower, ok := lowerLimit.(T)
-		if !ok {
-			return false, errors.New("lowerLimit type mismatch")
+		lower, err := operand("lower limit", tag.LowerLimit)
+		if err != nil {
+			return false, err
 		}
 		if compOp == Gt {
 			return value > lower, nil
@@ -97,9 +458,9 @@ func isPassingNumeric[T constraints.Ordered](
 		return value >= lower, nil
 
 	case Lt, Le:
-		upper, ok := upperLimit.(T)
-		if !ok {
-			return false, errors.New("upperLimit type mismatch")
+		upper, err := operand("upper limit", tag.UpperLimit)
+		if err != nil {
+			return false, err
 		}
 		if compOp == Lt {
 			return value < upper, nil
@@ -110,3 +471,41 @@ func isPassingNumeric[T constraints.Ordered](
 		return false, errors.Errorf("unknown comparison operator (%v)", compOp.String())
 	}
 }
+
+// isPassingApprox checks that the value is within the absolute or percent tolerance of the expected value.
+func isPassingApprox(value float64, tag *Tag) (bool, error) {
+	expected, err := numericLimit("expected", tag.ExpectedValue)
+	if err != nil {
+		return false, errors.Wrap(err, "expected value")
+	}
+
+	if tag.TolerancePercent != nil {
+		percent, err := numericLimit("tolerance percent", tag.TolerancePercent)
+		if err != nil {
+			return false, errors.Wrap(err, "tolerance percent")
+		}
+
+		return math.Abs(value-expected) <= math.Abs(expected)*percent/100, nil
+	}
+
+	tolerance, err := numericLimit("tolerance", tag.Tolerance)
+	if err != nil {
+		return false, errors.Wrap(err, "tolerance")
+	}
+
+	return math.Abs(value-expected) <= tolerance, nil
+}
+
+// numericLimit returns the limit as a float64, it returns an error if the limit is missing or not a number.
+func numericLimit(name string, limit any) (float64, error) {
+	if limit == nil {
+		return 0, errors.Errorf("%s limit is required", name)
+	}
+
+	l, err := coerceValue(TypeFloat, limit, "")
+	if err != nil {
+		return 0, errors.Errorf("%s limit (%v) must be a number", name, limit)
+	}
+
+	return l.(float64), nil
+}
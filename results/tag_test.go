@@ -2,6 +2,7 @@ package results
 
 import (
 	"testing"
+	"time"
 )
 
 func TestTag_IsPassing(t *testing.T) {
@@ -134,3 +135,142 @@ func TestTag_IsPassing(t *testing.T) {
 		})
 	}
 }
+
+func TestTag_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tag     Tag
+		wantErr bool
+	}{
+		{"log without limits", Tag{CompOp: Log}, false},
+		{"equality with expected value", Tag{CompOp: Eq, ExpectedValue: true}, false},
+		{"equality without expected value", Tag{CompOp: Eq}, true},
+		{"greater than with lower limit", Tag{CompOp: Gt, LowerLimit: 5}, false},
+		{"greater than without lower limit", Tag{CompOp: Gt, UpperLimit: 5}, true},
+		{"less than or equal with float upper limit", Tag{CompOp: Le, UpperLimit: 2.5}, false},
+		{"less than with string upper limit", Tag{CompOp: Lt, UpperLimit: "5"}, true},
+		{"inclusive range", Tag{CompOp: Gele, LowerLimit: 0, UpperLimit: 1000}, false},
+		{"inclusive range with equal limits", Tag{CompOp: Gele, LowerLimit: 5, UpperLimit: 5}, false},
+		{"inclusive range with inverted limits", Tag{CompOp: Gele, LowerLimit: 10, UpperLimit: 5}, true},
+		{"exclusive range with equal limits", Tag{CompOp: Gtlt, LowerLimit: 5.0, UpperLimit: 5}, true},
+		{"exclusive range missing upper limit", Tag{CompOp: Gtlt, LowerLimit: 5}, true},
+		{"not equal without expected value", Tag{CompOp: Ne}, true},
+		{"approx with tolerance", Tag{CompOp: Approx, ExpectedValue: 12.0, Tolerance: 0.5}, false},
+		{"approx with percent tolerance", Tag{CompOp: Approx, ExpectedValue: 200, TolerancePercent: 5}, false},
+		{"approx with both tolerances", Tag{CompOp: Approx, ExpectedValue: 1, Tolerance: 1, TolerancePercent: 1}, true},
+		{"approx without tolerance", Tag{CompOp: Approx, ExpectedValue: 1}, true},
+		{"approx with negative tolerance", Tag{CompOp: Approx, ExpectedValue: 1, Tolerance: -1}, true},
+		{"approx with string expected value", Tag{CompOp: Approx, ExpectedValue: "1", Tolerance: 1}, true},
+		{"match with pattern", Tag{CompOp: Match, Pattern: `v\d+`}, false},
+		{"match with invalid pattern", Tag{CompOp: Match, Pattern: "v("}, true},
+		{"match without pattern", Tag{CompOp: Match}, true},
+		{"in with values", Tag{CompOp: In, Values: []any{"Idle"}}, false},
+		{"in without values", Tag{CompOp: In}, true},
+		{"mask", Tag{CompOp: Mask, Mask: 0x0F, ExpectedValue: 0x05}, false},
+		{"mask with expected value outside mask", Tag{CompOp: Mask, Mask: 0x0F, ExpectedValue: 0x15}, true},
+		{"mask without mask", Tag{CompOp: Mask, ExpectedValue: 0x05}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tag.Validate()
+			if tc.wantErr && err == nil {
+				t.Errorf("Expected an error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+type testState uint8
+
+func (s testState) String() string {
+	return [...]string{"Idle", "Running"}[s]
+}
+
+func TestTag_IsPassingNormalizes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tag      Tag
+		value    any
+		expected bool
+	}{
+		{"uint8 against int limits", Tag{CompOp: Le, UpperLimit: 10, Type: TypeInt}, uint8(10), true},
+		{"int64 against float limit", Tag{CompOp: Gt, LowerLimit: 2.5}, int64(3), true},
+		{"float32 against int limit", Tag{CompOp: Lt, UpperLimit: 3}, float32(3.5), false},
+		{"integral float against int tag", Tag{CompOp: Eq, ExpectedValue: 5, Type: TypeInt}, 5.0, true},
+		{"duration in milliseconds", Tag{CompOp: Le, UpperLimit: 300, Type: TypeInt, Unit: "ms"}, 300 * time.Millisecond, true},
+		{"duration in seconds", Tag{CompOp: Le, UpperLimit: 0.2, Type: TypeFloat, Unit: "s"}, 250 * time.Millisecond, false},
+		{"stringer in set", Tag{CompOp: In, Values: []any{"Running"}, Type: TypeString}, testState(1), true},
+		{"int in float set", Tag{CompOp: In, Values: []any{1.0, 2.0}}, 2, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.tag.IsPassing(tc.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestTag_IsPassingTypeMismatch(t *testing.T) {
+	testCases := []struct {
+		name  string
+		tag   Tag
+		value any
+	}{
+		{"fractional float against int tag", Tag{CompOp: Le, UpperLimit: 10, Type: TypeInt}, 2.5},
+		{"string against numeric tag", Tag{CompOp: Le, UpperLimit: 10}, "2"},
+		{"duration without time unit", Tag{CompOp: Le, UpperLimit: 10, Unit: "V"}, time.Second},
+		{"int against bool tag", Tag{CompOp: Eq, ExpectedValue: true}, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.tag.IsPassing(tc.value)
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTag_Coerce(t *testing.T) {
+	tag := Tag{CompOp: Gele, LowerLimit: 0.0, UpperLimit: 1000.0, Type: TypeInt}
+	if err := tag.Coerce(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tag.LowerLimit != 0 || tag.UpperLimit != 1000 {
+		t.Errorf("Expected int limits, got (%T) and (%T)", tag.LowerLimit, tag.UpperLimit)
+	}
+
+	tag = Tag{CompOp: Approx, ExpectedValue: 12, Tolerance: 0.5, Type: TypeFloat}
+	if err := tag.Coerce(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tag.ExpectedValue != 12.0 {
+		t.Errorf("Expected a float expected value, got (%T)", tag.ExpectedValue)
+	}
+
+	invalid := []Tag{
+		{CompOp: Le, UpperLimit: 1.5, Type: TypeInt},
+		{CompOp: Eq, ExpectedValue: "true", Type: TypeBool},
+		{CompOp: Gt, LowerLimit: 1, Type: TypeBool},
+		{CompOp: Mask, Mask: 0x0F, ExpectedValue: 0x05, Type: TypeFloat},
+		{CompOp: Match, Pattern: "v1", Type: TypeInt},
+		{CompOp: In, Values: []any{"Idle", 1}, Type: TypeString},
+	}
+
+	for _, tag := range invalid {
+		if err := tag.Coerce(); err == nil {
+			t.Errorf("Expected an error for (%s) tag of type (%s)", tag.CompOp, tag.Type)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package results
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate enumer -type=TagType -trimprefix=Type tagtype.go
+type TagType int
+
+const (
+	// TypeAny is a tag without a declared type, its type is inferred from its comparison, see Tag.ValueType
+	TypeAny TagType = iota
+	// TypeBool is a boolean
+	TypeBool
+	// TypeInt is an integer, submitted integers of any size and integral floats are converted to int
+	TypeInt
+	// TypeFloat is a float64, submitted integers and floats of any size are converted to float64
+	TypeFloat
+	// TypeString is a string, submitted fmt.Stringer values such as enums are converted with String
+	TypeString
+)
+
+// _durationUnits are the tag units a time.Duration can be submitted in.
+var _durationUnits = map[string]time.Duration{
+	"ns":  time.Nanosecond,
+	"us":  time.Microsecond,
+	"µs":  time.Microsecond,
+	"ms":  time.Millisecond,
+	"s":   time.Second,
+	"min": time.Minute,
+	"h":   time.Hour,
+}
+
+// coerceValue converts the value to tagType. Durations are converted to the unit, which must be one of
+// _durationUnits, integer conversions truncate like time.Duration.Milliseconds. Values of TypeAny only have their
+// numbers normalized to int or float64, other values are returned unchanged.
+func coerceValue(tagType TagType, value any, unit string) (any, error) {
+	if value == nil {
+		return nil, errors.New("value is required")
+	}
+
+	if d, ok := value.(time.Duration); ok {
+		return coerceDuration(tagType, d, unit)
+	}
+
+	switch tagType {
+	case TypeAny:
+		if i, ok, err := toInt(value); ok {
+			return i, err
+		}
+
+		if f, ok := toFloat(value); ok {
+			return f, nil
+		}
+
+		return value, nil
+	case TypeBool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, typeMismatch(tagType, value)
+		}
+
+		return b, nil
+	case TypeString:
+		switch s := value.(type) {
+		case string:
+			return s, nil
+		case fmt.Stringer:
+			return s.String(), nil
+		default:
+			return nil, typeMismatch(tagType, value)
+		}
+	case TypeInt:
+		if i, ok, err := toInt(value); ok {
+			return i, err
+		}
+
+		f, ok := toFloat(value)
+		if !ok || f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+			return nil, typeMismatch(tagType, value)
+		}
+
+		return int(f), nil
+	case TypeFloat:
+		if f, ok := toFloat(value); ok {
+			return f, nil
+		}
+
+		if i, ok, err := toInt(value); ok {
+			return float64(i), err
+		}
+
+		return nil, typeMismatch(tagType, value)
+	default:
+		return nil, errors.Errorf("unknown tag type (%v)", tagType)
+	}
+}
+
+func coerceDuration(tagType TagType, d time.Duration, unit string) (any, error) {
+	per, ok := _durationUnits[unit]
+	if !ok {
+		if tagType == TypeAny {
+			return d, nil
+		}
+
+		return nil, errors.Errorf("duration (%s) requires a time unit, tag unit is (%s)", d, unit)
+	}
+
+	switch tagType {
+	case TypeInt:
+		return int(d / per), nil
+	case TypeFloat, TypeAny:
+		return float64(d) / float64(per), nil
+	default:
+		return nil, typeMismatch(tagType, d)
+	}
+}
+
+// toInt returns true if the value is an integer of any size, it returns an error if the value does not fit in an int.
+func toInt(value any) (int, bool, error) {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt {
+			return 0, true, errors.Errorf("value (%v) overflows int", value)
+		}
+
+		return int(v.Uint()), true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
+// toFloat returns true if the value is a float of any size.
+func toFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func typeMismatch(tagType TagType, value any) error {
+	return errors.Errorf("value (%v) of type (%T) is not a valid %s", value, value, tagType)
+}
@@ -0,0 +1,90 @@
+// Code generated by "enumer -type=TagType -trimprefix=Type tagtype.go"; DO NOT EDIT.
+
+package results
+
+import (
+	"fmt"
+	"strings"
+)
+
+const _TagTypeName = "AnyBoolIntFloatString"
+
+var _TagTypeIndex = [...]uint8{0, 3, 7, 10, 15, 21}
+
+const _TagTypeLowerName = "anyboolintfloatstring"
+
+func (i TagType) String() string {
+	if i < 0 || i >= TagType(len(_TagTypeIndex)-1) {
+		return fmt.Sprintf("TagType(%d)", i)
+	}
+	return _TagTypeName[_TagTypeIndex[i]:_TagTypeIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _TagTypeNoOp() {
+	var x [1]struct{}
+	_ = x[TypeAny-(0)]
+	_ = x[TypeBool-(1)]
+	_ = x[TypeInt-(2)]
+	_ = x[TypeFloat-(3)]
+	_ = x[TypeString-(4)]
+}
+
+var _TagTypeValues = []TagType{TypeAny, TypeBool, TypeInt, TypeFloat, TypeString}
+
+var _TagTypeNameToValueMap = map[string]TagType{
+	_TagTypeName[0:3]:        TypeAny,
+	_TagTypeLowerName[0:3]:   TypeAny,
+	_TagTypeName[3:7]:        TypeBool,
+	_TagTypeLowerName[3:7]:   TypeBool,
+	_TagTypeName[7:10]:       TypeInt,
+	_TagTypeLowerName[7:10]:  TypeInt,
+	_TagTypeName[10:15]:      TypeFloat,
+	_TagTypeLowerName[10:15]: TypeFloat,
+	_TagTypeName[15:21]:      TypeString,
+	_TagTypeLowerName[15:21]: TypeString,
+}
+
+var _TagTypeNames = []string{
+	_TagTypeName[0:3],
+	_TagTypeName[3:7],
+	_TagTypeName[7:10],
+	_TagTypeName[10:15],
+	_TagTypeName[15:21],
+}
+
+// TagTypeString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func TagTypeString(s string) (TagType, error) {
+	if val, ok := _TagTypeNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _TagTypeNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to TagType values", s)
+}
+
+// TagTypeValues returns all values of the enum
+func TagTypeValues() []TagType {
+	return _TagTypeValues
+}
+
+// TagTypeStrings returns a slice of all String values of the enum
+func TagTypeStrings() []string {
+	strs := make([]string, len(_TagTypeNames))
+	copy(strs, _TagTypeNames)
+	return strs
+}
+
+// IsATagType returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i TagType) IsATagType() bool {
+	for _, v := range _TagTypeValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
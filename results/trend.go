@@ -0,0 +1,260 @@
+package results
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	_defaultTrendWindow    = 20
+	_defaultDriftThreshold = 3.0
+	_defaultMinSamples     = 5
+)
+
+// TrendOption is a type for functions operating on TrendAnalyzer
+type TrendOption func(*TrendAnalyzer)
+
+// TrendAnalyzer compares the tags of a run against the same tags in previous runs of its sequence.
+type TrendAnalyzer struct {
+	window         int
+	driftThreshold float64
+	minSamples     int
+}
+
+// NewTrendAnalyzer returns a new TrendAnalyzer
+func NewTrendAnalyzer(opts ...TrendOption) *TrendAnalyzer {
+	analyzer := &TrendAnalyzer{
+		window:         _defaultTrendWindow,
+		driftThreshold: _defaultDriftThreshold,
+		minSamples:     _defaultMinSamples,
+	}
+
+	for _, o := range opts {
+		o(analyzer)
+	}
+
+	return analyzer
+}
+
+// WithTrendWindow sets how many of the most recent previous runs are used for the statistics.
+func WithTrendWindow(runs int) TrendOption {
+	return func(a *TrendAnalyzer) {
+		a.window = runs
+	}
+}
+
+// WithDriftThreshold sets how many standard deviations from the mean a value must be to be flagged as drift.
+func WithDriftThreshold(stdDevs float64) TrendOption {
+	return func(a *TrendAnalyzer) {
+		a.driftThreshold = stdDevs
+	}
+}
+
+// WithMinSamples sets how many previous values a tag needs before drift is flagged.
+func WithMinSamples(samples int) TrendOption {
+	return func(a *TrendAnalyzer) {
+		a.minSamples = samples
+	}
+}
+
+// TagTrend holds the statistics of a tag over the window of previous runs and how the current value compares.
+// The statistics are only set if Numeric is true.
+type TagTrend struct {
+	TagID     string
+	StatePath string
+	Params    string
+	Unit      string
+	// Runs is the number of previous runs in the window that submitted the tag.
+	Runs int
+	// PreviousFailures is the number of those runs in which the tag failed.
+	PreviousFailures int
+	// Numeric is true if the current value and at least one previous value are numbers.
+	Numeric bool
+	// Samples is the number of previous numeric values.
+	Samples int
+	Current float64
+	Mean    float64
+	StdDev  float64
+	Min     float64
+	Max     float64
+	P50     float64
+	P90     float64
+	P95     float64
+	// ZScore is the number of standard deviations the current value is from the mean. It is infinite if every
+	// previous value was the same and the current value differs.
+	ZScore float64
+	// PercentChange is the change of the current value relative to the mean, it is zero if the mean is zero.
+	PercentChange float64
+	// Drift is true if the current value is further than the drift threshold from the mean.
+	Drift bool
+	// NewFailure is true if the tag failed and passed in every previous run of the window.
+	NewFailure bool
+}
+
+// Flagged returns true if the tag drifted or newly failed.
+func (t TagTrend) Flagged() bool {
+	return t.Drift || t.NewFailure
+}
+
+type trendKey struct {
+	tagID     string
+	statePath string
+	params    string
+}
+
+type trendSamples struct {
+	runs     int
+	failures int
+	values   []float64
+}
+
+// Analyze returns a trend for every tag of current, in the order of current.Tags. Only the runs in history that
+// completed before current are used, history must be sorted oldest first, see History.Load.
+func (a *TrendAnalyzer) Analyze(history []Export, current Export) []TagTrend {
+	samples := make(map[trendKey]*trendSamples)
+
+	for _, export := range a.previousRuns(history, current) {
+		for _, tag := range export.Tags {
+			key := trendKey{tagID: tag.TagID, statePath: tag.StatePath, params: tag.Params}
+
+			s, ok := samples[key]
+			if !ok {
+				s = &trendSamples{}
+				samples[key] = s
+			}
+
+			s.runs++
+
+			if !tag.IsPassing {
+				s.failures++
+			}
+
+			if value, ok := trendValue(tag.Value); ok {
+				s.values = append(s.values, value)
+			}
+		}
+	}
+
+	trends := make([]TagTrend, 0, len(current.Tags))
+
+	for _, tag := range current.Tags {
+		s, ok := samples[trendKey{tagID: tag.TagID, statePath: tag.StatePath, params: tag.Params}]
+		if !ok {
+			s = &trendSamples{}
+		}
+
+		trends = append(trends, a.tagTrend(tag, s))
+	}
+
+	return trends
+}
+
+// previousRuns returns the last window runs of history that are not current and did not complete after it.
+func (a *TrendAnalyzer) previousRuns(history []Export, current Export) []Export {
+	previous := make([]Export, 0, len(history))
+
+	for _, export := range history {
+		if export.TestID == current.TestID {
+			continue
+		}
+
+		if !current.CompletedAt.IsZero() && export.CompletedAt.After(current.CompletedAt) {
+			continue
+		}
+
+		previous = append(previous, export)
+	}
+
+	if a.window > 0 && len(previous) > a.window {
+		previous = previous[len(previous)-a.window:]
+	}
+
+	return previous
+}
+
+func (a *TrendAnalyzer) tagTrend(tag ExportedTag, s *trendSamples) TagTrend {
+	trend := TagTrend{
+		TagID:            tag.TagID,
+		StatePath:        tag.StatePath,
+		Params:           tag.Params,
+		Unit:             tag.Unit,
+		Runs:             s.runs,
+		PreviousFailures: s.failures,
+		NewFailure:       !tag.IsPassing && s.runs > 0 && s.failures == 0,
+	}
+
+	current, ok := trendValue(tag.Value)
+	if !ok || len(s.values) == 0 {
+		return trend
+	}
+
+	sorted := append([]float64(nil), s.values...)
+	sort.Float64s(sorted)
+
+	trend.Numeric = true
+	trend.Samples = len(sorted)
+	trend.Current = current
+	trend.Mean, trend.StdDev = meanStdDev(sorted)
+	trend.Min = sorted[0]
+	trend.Max = sorted[len(sorted)-1]
+	trend.P50 = percentile(sorted, 50)
+	trend.P90 = percentile(sorted, 90)
+	trend.P95 = percentile(sorted, 95)
+
+	if trend.Mean != 0 {
+		trend.PercentChange = (current - trend.Mean) / math.Abs(trend.Mean) * 100
+	}
+
+	switch {
+	case trend.StdDev > 0:
+		trend.ZScore = (current - trend.Mean) / trend.StdDev
+	case current > trend.Mean:
+		trend.ZScore = math.Inf(1)
+	case current < trend.Mean:
+		trend.ZScore = math.Inf(-1)
+	}
+
+	trend.Drift = trend.Samples >= a.minSamples && math.Abs(trend.ZScore) > a.driftThreshold
+
+	return trend
+}
+
+// meanStdDev returns the mean and sample standard deviation of values.
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	mean := sum / float64(len(values))
+
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+// percentile linearly interpolates the p-th percentile of sorted.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// trendValue returns the value as a float64 if it is a number. Values read from a JSON export are always float64.
+func trendValue(value any) (float64, bool) {
+	v, err := coerceValue(TypeFloat, value, "")
+	if err != nil {
+		return 0, false
+	}
+
+	return v.(float64), true
+}
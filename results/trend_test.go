@@ -0,0 +1,102 @@
+package results
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trendTestExport(completedAt time.Time, tsalTime float64, flashed bool) Export {
+	return Export{
+		SchemaVersion: ExportSchemaVersion,
+		TestID:        uuid.New(),
+		SequenceName:  "TestSequence",
+		CompletedAt:   completedAt,
+		Tags: []ExportedTag{
+			{TagID: "tsal_time", Value: tsalTime, IsPassing: true, Unit: "ms"},
+			{TagID: "flashed", Value: flashed, IsPassing: flashed},
+		},
+	}
+}
+
+func TestTrendAnalyzerAnalyze(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var history []Export
+	for i, tsalTime := range []float64{100, 102, 98, 101, 99} {
+		history = append(history, trendTestExport(start.Add(time.Duration(i)*time.Hour), tsalTime, true))
+	}
+
+	// A 30% slowdown that is still within limits.
+	current := trendTestExport(start.Add(time.Duration(len(history))*time.Hour), 130, false)
+
+	trends := NewTrendAnalyzer().Analyze(append(history, current), current)
+	require.Len(t, trends, 2)
+
+	tsal := trends[0]
+	assert.Equal(t, "tsal_time", tsal.TagID)
+	assert.True(t, tsal.Numeric)
+	assert.Equal(t, 5, tsal.Samples)
+	assert.InDelta(t, 100, tsal.Mean, 1e-9)
+	assert.InDelta(t, math.Sqrt(2.5), tsal.StdDev, 1e-9)
+	assert.InDelta(t, 100, tsal.P50, 1e-9)
+	assert.InDelta(t, 101.8, tsal.P95, 1e-9)
+	assert.InDelta(t, 30, tsal.PercentChange, 1e-9)
+	assert.True(t, tsal.Drift)
+	assert.False(t, tsal.NewFailure)
+
+	flashed := trends[1]
+	assert.False(t, flashed.Numeric)
+	assert.Equal(t, 5, flashed.Runs)
+	assert.True(t, flashed.NewFailure)
+	assert.True(t, flashed.Flagged())
+}
+
+func TestTrendAnalyzerWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	history := []Export{
+		trendTestExport(start, 500, true),
+		trendTestExport(start.Add(time.Hour), 100, true),
+		trendTestExport(start.Add(2*time.Hour), 100, true),
+	}
+
+	// Runs after the current run are never part of its history.
+	later := trendTestExport(start.Add(4*time.Hour), 900, true)
+	current := trendTestExport(start.Add(3*time.Hour), 100, true)
+
+	trends := NewTrendAnalyzer(WithTrendWindow(2), WithMinSamples(2)).
+		Analyze(append(history, later), current)
+	require.Len(t, trends, 2)
+
+	assert.Equal(t, 2, trends[0].Samples)
+	assert.InDelta(t, 100, trends[0].Mean, 1e-9)
+	assert.False(t, trends[0].Drift)
+}
+
+func TestHistoryLoad(t *testing.T) {
+	dir := t.TempDir()
+	generator := NewJsonExportGenerator()
+
+	newer := exportTestReport()
+	older := exportTestReport()
+	older.CompletedAt = newer.CompletedAt.Add(-time.Hour)
+	other := exportTestReport()
+	other.SequenceName = "OtherSequence"
+
+	for _, report := range []Report{newer, older, other} {
+		_, err := generator.Generate(report, dir)
+		require.NoError(t, err)
+	}
+
+	exports, err := NewHistory(dir).Load("TestSequence")
+	require.NoError(t, err)
+
+	require.Len(t, exports, 2)
+	assert.Equal(t, older.TestID, exports[0].TestID)
+	assert.Equal(t, newer.TestID, exports[1].TestID)
+}
@@ -0,0 +1,248 @@
+package scheduler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	_cronFields = 5
+	// _maxSearchYears bounds the search for the next activation, a schedule such as Feb 30 never activates.
+	_maxSearchYears = 5
+)
+
+// _cronDescriptors are shorthands for common schedules.
+var _cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field is the range of values a cron field accepts.
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+var (
+	_minuteField     = field{name: "minute", min: 0, max: 59}
+	_hourField       = field{name: "hour", min: 0, max: 23}
+	_dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	_monthField      = field{name: "month", min: 1, max: 12}
+	// Sunday is both 0 and 7.
+	_dayOfWeekField = field{name: "day of week", min: 0, max: 7}
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr       string
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// anyDay is true if either day field is "*". Otherwise a day matches if it matches either day field.
+	anyDay bool
+}
+
+// ParseSchedule parses a standard five field cron expression: minute, hour, day of month, month and day of week.
+// Fields accept "*", single values, ranges such as "1-5", lists such as "1,15" and steps such as "*/15" or "0-30/10".
+// The descriptors @yearly, @monthly, @weekly, @daily, @midnight and @hourly are also accepted.
+func ParseSchedule(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+
+	if descriptor, ok := _cronDescriptors[spec]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != _cronFields {
+		return nil, errors.Errorf("cron expression (%s) must have %d fields, got %d", expr, _cronFields, len(fields))
+	}
+
+	var (
+		ret = &Schedule{expr: expr}
+		err error
+	)
+
+	ret.minute, err = parseField(fields[0], _minuteField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse cron expression (%s)", expr)
+	}
+
+	ret.hour, err = parseField(fields[1], _hourField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse cron expression (%s)", expr)
+	}
+
+	ret.dayOfMonth, err = parseField(fields[2], _dayOfMonthField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse cron expression (%s)", expr)
+	}
+
+	ret.month, err = parseField(fields[3], _monthField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse cron expression (%s)", expr)
+	}
+
+	ret.dayOfWeek, err = parseField(fields[4], _dayOfWeekField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse cron expression (%s)", expr)
+	}
+
+	// Fold Sunday as 7 into Sunday as 0.
+	if ret.dayOfWeek&(1<<7) != 0 {
+		ret.dayOfWeek |= 1
+	}
+
+	ret.anyDay = fields[2] == "*" || fields[4] == "*"
+
+	return ret, nil
+}
+
+// String returns the cron expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first activation after t, to the minute, in the location of t. It returns the zero time if the
+// schedule never activates. Times skipped when the clocks go forward never activate, and times repeated when the
+// clocks go back only activate once.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc))
+	limit := t.AddDate(_maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+		case !s.matchesDay(t):
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+		case !has(s.hour, t.Hour()):
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+		case !has(s.minute, t.Minute()):
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc))
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// forward returns next if it is after t. time.Date resolves a wall time that is skipped when the clocks go forward,
+// or repeated when they go back, to the earlier offset, so next can be at or before t. The search then continues
+// from the start of the next hour by elapsed time, which also skips the second pass of a repeated hour.
+func forward(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+
+	return t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second -
+		time.Duration(t.Nanosecond()))
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := has(s.dayOfMonth, t.Day())
+	dayOfWeek := has(s.dayOfWeek, int(t.Weekday()))
+
+	if s.anyDay {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
+
+// parseField returns a bit set of the values matched by a comma separated list of ranges.
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(expr, ",") {
+		rangeBits, err := parseRange(part, f)
+		if err != nil {
+			return 0, errors.Wrapf(err, "%s field", f.name)
+		}
+
+		bits |= rangeBits
+	}
+
+	return bits, nil
+}
+
+// parseRange parses "*", "n", "n-m" with an optional "/step".
+func parseRange(expr string, f field) (uint64, error) {
+	var (
+		low, high = f.min, f.max
+		step      = 1
+		err       error
+	)
+
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+
+	if hasStep {
+		step, err = strconv.Atoi(stepExpr)
+		if err != nil || step < 1 {
+			return 0, errors.Errorf("invalid step (%s)", stepExpr)
+		}
+	}
+
+	if rangeExpr != "*" {
+		lowExpr, highExpr, isRange := strings.Cut(rangeExpr, "-")
+
+		low, err = parseValue(lowExpr, f)
+		if err != nil {
+			return 0, err
+		}
+
+		high = low
+
+		// A step on a single value runs to the end of the field, as in "5/15".
+		if hasStep {
+			high = f.max
+		}
+
+		if isRange {
+			high, err = parseValue(highExpr, f)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if low > high {
+			return 0, errors.Errorf("invalid range (%s)", rangeExpr)
+		}
+	}
+
+	var bits uint64
+	for value := low; value <= high; value += step {
+		bits |= 1 << value
+	}
+
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, errors.Errorf("invalid value (%s)", expr)
+	}
+
+	if value < f.min || value > f.max {
+		return 0, errors.Errorf("value (%d) out of range (%d-%d)", value, f.min, f.max)
+	}
+
+	return value, nil
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<value) != 0
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "lists ranges and steps", expr: "0,30 8-18/2 1-15 */3 1-5"},
+		{name: "step from a value", expr: "5/15 * * * *"},
+		{name: "sunday as 7", expr: "0 0 * * 7"},
+		{name: "descriptor", expr: "@daily"},
+		{name: "surrounding spaces", expr: "  @hourly "},
+		{name: "too few fields", expr: "0 2 * *", wantErr: "must have 5 fields, got 4"},
+		{name: "too many fields", expr: "0 2 * * * *", wantErr: "must have 5 fields, got 6"},
+		{name: "unknown descriptor", expr: "@fortnightly", wantErr: "must have 5 fields, got 1"},
+		{name: "minute out of range", expr: "60 * * * *", wantErr: "minute field: value (60) out of range (0-59)"},
+		{name: "hour out of range", expr: "0 24 * * *", wantErr: "hour field: value (24) out of range (0-23)"},
+		{name: "day of month zero", expr: "0 0 0 * *", wantErr: "day of month field: value (0) out of range (1-31)"},
+		{name: "month out of range", expr: "0 0 1 13 *", wantErr: "month field: value (13) out of range (1-12)"},
+		{name: "day of week out of range", expr: "0 0 * * 8", wantErr: "day of week field: value (8) out of range (0-7)"},
+		{name: "not a number", expr: "a * * * *", wantErr: "minute field: invalid value (a)"},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: "minute field: invalid step (0)"},
+		{name: "reversed range", expr: "0 18-8 * * *", wantErr: "hour field: invalid range (18-8)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expr, schedule.String())
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "later the same day",
+			expr: "0 2 * * *",
+			from: time.Date(2024, 6, 1, 1, 15, 30, 0, time.UTC),
+			want: time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "strictly after from",
+			expr: "0 2 * * *",
+			from: time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 6, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "steps",
+			expr: "*/15 * * * *",
+			from: time.Date(2024, 6, 1, 10, 46, 0, 0, time.UTC),
+			want: time.Date(2024, 6, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "either day field matches",
+			expr: "0 0 13 * 5",
+			from: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 0 * * 7",
+			from: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			expr: "0 0 30 2 *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+		{
+			name: "half hour offset",
+			expr: "0 2 * * *",
+			from: time.Date(2024, 6, 1, 0, 45, 0, 0, kolkata),
+			want: time.Date(2024, 6, 1, 2, 0, 0, 0, kolkata),
+		},
+		{
+			name: "half hour offset hourly",
+			expr: "@hourly",
+			from: time.Date(2024, 6, 1, 9, 10, 0, 0, kolkata),
+			want: time.Date(2024, 6, 1, 10, 0, 0, 0, kolkata),
+		},
+		{
+			name: "hourly when the clocks go forward",
+			expr: "0 * * * *",
+			from: time.Date(2024, 3, 10, 1, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 10, 3, 0, 0, 0, newYork),
+		},
+		{
+			name: "skipped when the clocks go forward",
+			expr: "30 2 * * *",
+			from: time.Date(2024, 3, 10, 0, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 11, 2, 30, 0, 0, newYork),
+		},
+		{
+			name: "once when the clocks go back",
+			expr: "30 1 * * *",
+			from: time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(newYork), // 01:30 EDT
+			want: time.Date(2024, 11, 4, 1, 30, 0, 0, newYork),
+		},
+		{
+			name: "not again after the clocks go back",
+			expr: "30 1 * * *",
+			from: time.Date(2024, 11, 3, 6, 10, 0, 0, time.UTC).In(newYork), // 01:10 EST
+			want: time.Date(2024, 11, 4, 1, 30, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr)
+			require.NoError(t, err)
+
+			next := schedule.Next(tt.from)
+
+			assert.True(t, tt.want.Equal(next), "expected %s, got %s", tt.want, next)
+
+			if !next.IsZero() {
+				assert.True(t, next.After(tt.from))
+				assert.Equal(t, tt.from.Location(), next.Location())
+			}
+		})
+	}
+}
@@ -0,0 +1,540 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	_loggerName     = "scheduler"
+	_dispatcherName = "scheduler"
+	// _keepOutcomes is the number of outcomes kept for each entry.
+	_keepOutcomes = 10
+	// _queueTimeout is how long a started test can go without being seen in the queue before the orchestrator is
+	// assumed to have dropped it.
+	_queueTimeout = 30 * time.Second
+	// Metadata keys added to every scheduled test.
+	_scheduledByKey = "scheduledBy"
+	_scheduleKey    = "schedule"
+)
+
+// Entry runs a sequence on a cron schedule.
+type Entry struct {
+	// Cron is the schedule, see ParseSchedule.
+	Cron         string                `json:"cron"`
+	SequenceName string                `json:"sequenceName"`
+	Metadata     map[string]string     `json:"metadata,omitempty"`
+	Priority     orchestrator.Priority `json:"priority"`
+}
+
+// Outcome is what happened to a scheduled run.
+type Outcome struct {
+	// ScheduledAt is when the run was due.
+	ScheduledAt time.Time `json:"scheduledAt"`
+	// TestId is only set if the test was started.
+	TestId orchestrator.TestId `json:"testId"`
+	// Skipped is the reason the run was not started, if any.
+	Skipped string `json:"skipped,omitempty"`
+	// Completed is true once the results of the test have been received.
+	Completed bool `json:"completed"`
+	IsPassing bool `json:"isPassing"`
+	// BenchError is set if the test did not start because a bench health check failed.
+	BenchError string `json:"benchError,omitempty"`
+}
+
+// EntryStatus describes an entry of the schedule for display.
+type EntryStatus struct {
+	Entry
+	NextRun  time.Time `json:"nextRun"`
+	Outcomes []Outcome `json:"outcomes"`
+}
+
+// entry is an Entry with its parsed schedule and outcomes.
+type entry struct {
+	Entry
+	schedule *Schedule
+	seq      flow.Sequence
+	nextRun  time.Time
+	outcomes []Outcome
+}
+
+// startedRun is a test started by the scheduler whose results have not been received.
+type startedRun struct {
+	entry  *entry
+	sentAt time.Time
+	// seen is true once the test has been queued or running.
+	seen bool
+}
+
+// SchedulerDispatcher is an orchestrator.DispatcherIface that starts sequences on cron schedules. A run is skipped
+// if the bench is in fatal error, and coalesced into the earlier run if the sequence is already waiting in the queue.
+type SchedulerDispatcher struct {
+	l         *zap.Logger
+	entries   []Entry
+	sequences map[string]flow.Sequence
+
+	mtx       sync.Mutex
+	scheduled []*entry
+	// started maps the tests started by the scheduler to their run until their results are received, or until the
+	// orchestrator drops them.
+	started    map[orchestrator.TestId]*startedRun
+	lastStatus orchestrator.StatusSignal
+
+	closing chan struct{}
+
+	start            chan orchestrator.StartSignal
+	results          chan orchestrator.ResultsSignal
+	status           chan orchestrator.StatusSignal
+	measurements     chan orchestrator.MeasurementSignal
+	cancelTest       chan orchestrator.CancelTestSignal
+	moveTest         chan orchestrator.MoveTestSignal
+	recoverFromFatal chan orchestrator.RecoverFromFatalSignal
+	pause            chan orchestrator.PauseSignal
+	resume           chan orchestrator.ResumeSignal
+	step             chan orchestrator.StepSignal
+	shutdown         chan orchestrator.ShutdownSignal
+}
+
+// NewSchedulerDispatcher creates a scheduler dispatcher for the entries. The sequences of the entries are looked up
+// by name in sequences on Open.
+func NewSchedulerDispatcher(entries []Entry, sequences []flow.Sequence, l *zap.Logger) *SchedulerDispatcher {
+	ret := &SchedulerDispatcher{
+		l:                l.Named(_loggerName),
+		entries:          entries,
+		sequences:        make(map[string]flow.Sequence, len(sequences)),
+		started:          make(map[orchestrator.TestId]*startedRun),
+		closing:          make(chan struct{}),
+		start:            make(chan orchestrator.StartSignal),
+		results:          make(chan orchestrator.ResultsSignal),
+		status:           make(chan orchestrator.StatusSignal),
+		measurements:     make(chan orchestrator.MeasurementSignal),
+		cancelTest:       make(chan orchestrator.CancelTestSignal),
+		moveTest:         make(chan orchestrator.MoveTestSignal),
+		recoverFromFatal: make(chan orchestrator.RecoverFromFatalSignal),
+		pause:            make(chan orchestrator.PauseSignal),
+		resume:           make(chan orchestrator.ResumeSignal),
+		step:             make(chan orchestrator.StepSignal),
+		shutdown:         make(chan orchestrator.ShutdownSignal),
+	}
+
+	for _, seq := range sequences {
+		ret.sequences[seq.Name] = seq
+	}
+
+	return ret
+}
+
+// Name of the dispatcher.
+func (s *SchedulerDispatcher) Name() string {
+	return _dispatcherName
+}
+
+// Open parses the schedule of every entry and starts the scheduler.
+func (s *SchedulerDispatcher) Open(ctx context.Context) error {
+	now := time.Now()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for _, e := range s.entries {
+		schedule, err := ParseSchedule(e.Cron)
+		if err != nil {
+			return errors.Wrapf(err, "schedule for sequence (%s)", e.SequenceName)
+		}
+
+		seq, ok := s.sequences[e.SequenceName]
+		if !ok {
+			return errors.Errorf("unknown scheduled sequence (%s)", e.SequenceName)
+		}
+
+		if missing := seq.MissingMetadata(e.Metadata); len(missing) > 0 {
+			return errors.Errorf("schedule for sequence (%s) is missing required metadata (%s)",
+				e.SequenceName, strings.Join(missing, ", "))
+		}
+
+		next := schedule.Next(now)
+		if next.IsZero() {
+			return errors.Errorf("schedule (%s) for sequence (%s) never runs", e.Cron, e.SequenceName)
+		}
+
+		s.scheduled = append(s.scheduled, &entry{
+			Entry:    e,
+			schedule: schedule,
+			seq:      seq,
+			nextRun:  next,
+			outcomes: make([]Outcome, 0, _keepOutcomes),
+		})
+
+		s.l.Info("sequence scheduled",
+			zap.String("sequence", e.SequenceName),
+			zap.String("cron", e.Cron),
+			zap.Time("next run", next))
+	}
+
+	go s.monitorOrchestrator(ctx)
+	go s.run(ctx)
+
+	return nil
+}
+
+// Close stops the scheduler.
+func (s *SchedulerDispatcher) Close() error {
+	s.l.Info("closing scheduler dispatcher")
+
+	close(s.closing)
+
+	return nil
+}
+
+// Schedule returns every entry with its next run and latest outcomes, most recent first.
+func (s *SchedulerDispatcher) Schedule() []EntryStatus {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	ret := make([]EntryStatus, len(s.scheduled))
+
+	for i, e := range s.scheduled {
+		outcomes := make([]Outcome, len(e.outcomes))
+		for j, outcome := range e.outcomes {
+			outcomes[len(e.outcomes)-1-j] = outcome
+		}
+
+		ret[i] = EntryStatus{
+			Entry:    e.Entry,
+			NextRun:  e.nextRun,
+			Outcomes: outcomes,
+		}
+	}
+
+	return ret
+}
+
+// Start signal is sent on schedule to start a test sequence.
+func (s *SchedulerDispatcher) Start() <-chan orchestrator.StartSignal {
+	return s.start
+}
+
+// CancelTest is never sent by the scheduler.
+func (s *SchedulerDispatcher) CancelTest() <-chan orchestrator.CancelTestSignal {
+	return s.cancelTest
+}
+
+// MoveTest is never sent by the scheduler.
+func (s *SchedulerDispatcher) MoveTest() <-chan orchestrator.MoveTestSignal {
+	return s.moveTest
+}
+
+// Shutdown is never sent by the scheduler.
+func (s *SchedulerDispatcher) Shutdown() <-chan orchestrator.ShutdownSignal {
+	return s.shutdown
+}
+
+// RecoverFromFatal is never sent by the scheduler, a bench in fatal error needs a person.
+func (s *SchedulerDispatcher) RecoverFromFatal() <-chan orchestrator.RecoverFromFatalSignal {
+	return s.recoverFromFatal
+}
+
+// Pause is never sent by the scheduler.
+func (s *SchedulerDispatcher) Pause() <-chan orchestrator.PauseSignal {
+	return s.pause
+}
+
+// Resume is never sent by the scheduler.
+func (s *SchedulerDispatcher) Resume() <-chan orchestrator.ResumeSignal {
+	return s.resume
+}
+
+// Step is never sent by the scheduler.
+func (s *SchedulerDispatcher) Step() <-chan orchestrator.StepSignal {
+	return s.step
+}
+
+// Status signal is sent on updates from the orchestrator.
+func (s *SchedulerDispatcher) Status() chan<- orchestrator.StatusSignal {
+	return s.status
+}
+
+// Results signal is sent at the end of a test execution or on test cancel.
+func (s *SchedulerDispatcher) Results() chan<- orchestrator.ResultsSignal {
+	return s.results
+}
+
+// Measurements signal is sent for every live sample published by the running test, the scheduler ignores them.
+func (s *SchedulerDispatcher) Measurements() chan<- orchestrator.MeasurementSignal {
+	return s.measurements
+}
+
+// run starts the due entries until the dispatcher is closed.
+func (s *SchedulerDispatcher) run(ctx context.Context) {
+	for {
+		next, ok := s.nextRun()
+		if !ok {
+			s.l.Info("no scheduled runs left")
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-timer.C:
+			s.runDue(ctx, time.Now())
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.closing:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// nextRun returns the earliest next run of all entries.
+func (s *SchedulerDispatcher) nextRun() (time.Time, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var next time.Time
+
+	for _, e := range s.scheduled {
+		if !e.nextRun.IsZero() && (next.IsZero() || e.nextRun.Before(next)) {
+			next = e.nextRun
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// runDue starts every entry that is due at now, then schedules its next run. Runs missed while a start signal was
+// blocked are coalesced into a single run.
+func (s *SchedulerDispatcher) runDue(ctx context.Context, now time.Time) {
+	s.mtx.Lock()
+
+	due := make([]*entry, 0)
+	for _, e := range s.scheduled {
+		if !e.nextRun.IsZero() && !e.nextRun.After(now) {
+			due = append(due, e)
+		}
+	}
+
+	// Start entries in priority order when several are due at the same time.
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].Priority > due[j].Priority
+	})
+
+	s.mtx.Unlock()
+
+	for _, e := range due {
+		s.startEntry(ctx, e)
+
+		s.mtx.Lock()
+		e.nextRun = e.schedule.Next(now)
+		s.mtx.Unlock()
+	}
+}
+
+func (s *SchedulerDispatcher) startEntry(ctx context.Context, e *entry) {
+	s.mtx.Lock()
+
+	s.trackStarted(time.Now())
+
+	outcome := Outcome{ScheduledAt: e.nextRun}
+
+	switch {
+	case s.lastStatus.OrchestratorState == orchestrator.FatalError,
+		s.lastStatus.OrchestratorState == orchestrator.Recovering:
+		outcome.Skipped = "bench is in fatal error"
+	case s.isWaiting(e):
+		outcome.Skipped = "sequence is already queued"
+	default:
+		outcome.TestId = uuid.New()
+	}
+
+	if outcome.Skipped != "" {
+		s.l.Warn("skipping scheduled run",
+			zap.String("sequence", e.SequenceName),
+			zap.String("reason", outcome.Skipped))
+
+		addOutcome(e, outcome)
+		s.mtx.Unlock()
+
+		return
+	}
+
+	metadata := map[string]string{
+		_scheduledByKey: _dispatcherName,
+		_scheduleKey:    e.Cron,
+	}
+
+	for key, value := range e.Metadata {
+		metadata[key] = value
+	}
+
+	run := &startedRun{entry: e, sentAt: time.Now()}
+	s.started[outcome.TestId] = run
+	addOutcome(e, outcome)
+	s.mtx.Unlock()
+
+	s.l.Info("starting scheduled run",
+		zap.String("sequence", e.SequenceName),
+		zap.String("test id", outcome.TestId.String()))
+
+	select {
+	case s.start <- orchestrator.StartSignal{
+		TestId:   outcome.TestId,
+		Seq:      e.seq,
+		Metadata: metadata,
+		Priority: e.Priority,
+	}:
+		s.mtx.Lock()
+		run.sentAt = time.Now()
+		s.mtx.Unlock()
+	case <-ctx.Done():
+		s.mtx.Lock()
+		s.dropStarted(outcome.TestId, "scheduler closed")
+		s.mtx.Unlock()
+	case <-s.closing:
+		s.mtx.Lock()
+		s.dropStarted(outcome.TestId, "scheduler closed")
+		s.mtx.Unlock()
+	}
+}
+
+// isWaiting returns true if the sequence of the entry is in the queue, or was started by the scheduler and has not
+// been seen in the queue yet. It must be called with the lock held.
+func (s *SchedulerDispatcher) isWaiting(e *entry) bool {
+	for _, queued := range s.lastStatus.Queue {
+		if queued.SequenceName == e.SequenceName {
+			return true
+		}
+	}
+
+	for _, run := range s.started {
+		if run.entry.SequenceName == e.SequenceName && !run.seen {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trackStarted marks the started tests that are queued or running as seen. The orchestrator drops start signals
+// without results while it is in fatal error, so unseen tests are dropped on a fatal error status, or once they
+// have not been seen for _queueTimeout. It must be called with the lock held.
+func (s *SchedulerDispatcher) trackStarted(now time.Time) {
+	var (
+		state   = s.lastStatus.OrchestratorState
+		running = state == orchestrator.Running || state == orchestrator.Paused
+	)
+
+	for testId, run := range s.started {
+		switch {
+		case run.seen:
+		case (running && testId == s.lastStatus.TestId) || s.isQueued(testId):
+			run.seen = true
+		case state == orchestrator.FatalError || state == orchestrator.Recovering:
+			s.dropStarted(testId, "bench is in fatal error")
+		case now.Sub(run.sentAt) > _queueTimeout:
+			s.dropStarted(testId, "test was not queued")
+		}
+	}
+}
+
+// dropStarted forgets a started test that will not run and records why on its outcome. It must be called with the
+// lock held.
+func (s *SchedulerDispatcher) dropStarted(testId orchestrator.TestId, reason string) {
+	run, ok := s.started[testId]
+	if !ok {
+		return
+	}
+
+	delete(s.started, testId)
+
+	for i := range run.entry.outcomes {
+		if run.entry.outcomes[i].TestId == testId {
+			run.entry.outcomes[i].Skipped = reason
+		}
+	}
+
+	s.l.Warn("scheduled run was not queued",
+		zap.String("sequence", run.entry.SequenceName),
+		zap.String("test id", testId.String()),
+		zap.String("reason", reason))
+}
+
+func (s *SchedulerDispatcher) isQueued(testId orchestrator.TestId) bool {
+	for _, queued := range s.lastStatus.Queue {
+		if queued.TestId == testId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *SchedulerDispatcher) monitorOrchestrator(ctx context.Context) {
+	for {
+		select {
+		case status := <-s.status:
+			s.mtx.Lock()
+			s.lastStatus = status
+			s.trackStarted(time.Now())
+			s.mtx.Unlock()
+		case results := <-s.results:
+			s.recordResults(results)
+		case <-s.measurements:
+		case <-ctx.Done():
+			s.l.Info("context done signal received")
+
+			return
+		}
+	}
+}
+
+// recordResults completes the outcome of a test started by the scheduler.
+func (s *SchedulerDispatcher) recordResults(results orchestrator.ResultsSignal) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	run, ok := s.started[results.TestId]
+	if !ok {
+		return
+	}
+
+	delete(s.started, results.TestId)
+
+	e := run.entry
+
+	for i := range e.outcomes {
+		if e.outcomes[i].TestId == results.TestId {
+			e.outcomes[i].Completed = true
+			e.outcomes[i].IsPassing = results.IsPassing
+
+			if results.BenchError != nil {
+				e.outcomes[i].BenchError = results.BenchError.Error()
+			}
+		}
+	}
+
+	s.l.Info("scheduled run complete",
+		zap.String("sequence", e.SequenceName),
+		zap.String("test id", results.TestId.String()),
+		zap.Bool("is passing", results.IsPassing))
+}
+
+// addOutcome keeps the latest outcomes of the entry.
+func addOutcome(e *entry, outcome Outcome) {
+	if len(e.outcomes) == _keepOutcomes {
+		e.outcomes = e.outcomes[1:]
+	}
+
+	e.outcomes = append(e.outcomes, outcome)
+}
@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestScheduler(t *testing.T) (*SchedulerDispatcher, *entry) {
+	t.Helper()
+
+	schedule, err := ParseSchedule("@hourly")
+	require.NoError(t, err)
+
+	s := NewSchedulerDispatcher(nil, nil, zap.NewNop())
+	e := &entry{
+		Entry:    Entry{Cron: "@hourly", SequenceName: "soak"},
+		schedule: schedule,
+		seq:      flow.Sequence{Name: "soak"},
+		nextRun:  time.Now(),
+	}
+	s.scheduled = append(s.scheduled, e)
+
+	return s, e
+}
+
+// startAndReceive runs the entry and returns the start signal the orchestrator would receive.
+func startAndReceive(t *testing.T, s *SchedulerDispatcher, e *entry) orchestrator.StartSignal {
+	t.Helper()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		s.startEntry(context.Background(), e)
+	}()
+
+	select {
+	case sig := <-s.start:
+		<-done
+		return sig
+	case <-done:
+		t.Fatalf("scheduled run was not started: %s", e.outcomes[len(e.outcomes)-1].Skipped)
+	}
+
+	return orchestrator.StartSignal{}
+}
+
+func setStatus(s *SchedulerDispatcher, status orchestrator.StatusSignal) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.lastStatus = status
+	s.trackStarted(time.Now())
+}
+
+func TestSchedulerSkipsRunWhileQueued(t *testing.T) {
+	s, e := newTestScheduler(t)
+	sig := startAndReceive(t, s, e)
+
+	// Not seen in the queue yet, the orchestrator may still be queuing it.
+	s.startEntry(context.Background(), e)
+	assert.Equal(t, "sequence is already queued", e.outcomes[len(e.outcomes)-1].Skipped)
+
+	setStatus(s, orchestrator.StatusSignal{
+		OrchestratorState: orchestrator.Running,
+		Queue:             []orchestrator.QueuedTest{{TestId: sig.TestId, SequenceName: "soak"}},
+	})
+
+	s.startEntry(context.Background(), e)
+	assert.Equal(t, "sequence is already queued", e.outcomes[len(e.outcomes)-1].Skipped)
+
+	// Running is not waiting, the next run is queued behind it.
+	setStatus(s, orchestrator.StatusSignal{OrchestratorState: orchestrator.Running, TestId: sig.TestId})
+
+	startAndReceive(t, s, e)
+}
+
+func TestSchedulerForgetsRunDroppedInFatalError(t *testing.T) {
+	s, e := newTestScheduler(t)
+	sig := startAndReceive(t, s, e)
+
+	// The orchestrator drops start signals without results while in fatal error.
+	setStatus(s, orchestrator.StatusSignal{OrchestratorState: orchestrator.FatalError})
+
+	assert.NotContains(t, s.started, sig.TestId)
+	assert.Equal(t, sig.TestId, e.outcomes[0].TestId)
+	assert.Equal(t, "bench is in fatal error", e.outcomes[0].Skipped)
+
+	setStatus(s, orchestrator.StatusSignal{OrchestratorState: orchestrator.Idle})
+
+	startAndReceive(t, s, e)
+}
+
+func TestSchedulerForgetsRunNeverQueued(t *testing.T) {
+	s, e := newTestScheduler(t)
+	sig := startAndReceive(t, s, e)
+
+	s.mtx.Lock()
+	s.started[sig.TestId].sentAt = time.Now().Add(-2 * _queueTimeout)
+	s.mtx.Unlock()
+
+	startAndReceive(t, s, e)
+
+	assert.Equal(t, "test was not queued", e.outcomes[0].Skipped)
+	assert.NotContains(t, s.started, sig.TestId)
+}
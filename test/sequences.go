@@ -4,10 +4,12 @@ import (
 	"time"
 
 	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/macformula/config"
 )
 
 var (
-	Sequences = []flow.Sequence{DoNothingSequence, SleepSequence, PanicSequence, FatalErrorSequence, ErrorSequence}
+	Sequences = []flow.Sequence{DoNothingSequence, SleepSequence, PanicSequence, HangSequence, FatalErrorSequence,
+		ErrorSequence, ParallelSequence, BranchSequence, MatrixSequence}
 )
 
 var DoNothingSequence = flow.Sequence{
@@ -51,11 +53,14 @@ var FatalErrorSequence = flow.Sequence{
 		&SleepState{SleepTime: 2 * time.Second},
 		&SleepState{SleepTime: 3 * time.Second},
 	},
+	Teardown: []flow.State{
+		&DoNothingState{},
+	},
 }
 
 var PanicSequence = flow.Sequence{
 	Name: "Panic 😨",
-	Desc: "This will panic the hil app.",
+	Desc: "The panic is recovered and the bench goes to fatal error.",
 	States: []flow.State{
 		&SleepState{SleepTime: 1 * time.Second},
 		&SleepState{SleepTime: 1 * time.Second},
@@ -66,6 +71,19 @@ var PanicSequence = flow.Sequence{
 	},
 }
 
+var HangSequence = flow.Sequence{
+	Name: "Hang 🧊",
+	Desc: "A state ignores its timeout, it is abandoned and the bench goes to fatal error.",
+	States: []flow.State{
+		&SleepState{SleepTime: 1 * time.Second},
+		&HangState{},
+		&SleepState{SleepTime: 1 * time.Second},
+	},
+	Teardown: []flow.State{
+		&DoNothingState{},
+	},
+}
+
 var ErrorSequence = flow.Sequence{
 	Name: "Normal Error",
 	Desc: "ERROR ERROR ERROR",
@@ -77,3 +95,51 @@ var ErrorSequence = flow.Sequence{
 		&SleepState{SleepTime: 3 * time.Second},
 	},
 }
+
+var ParallelSequence = flow.Sequence{
+	Name: "Parallel 🔀",
+	Desc: "Runs sleeps side by side, then stops at the first error.",
+	States: []flow.State{
+		flow.NewParallel("parallel_sleep", flow.JoinAll, []flow.State{
+			&SleepState{SleepTime: 1 * time.Second},
+			&SleepState{SleepTime: 3 * time.Second},
+			&SleepState{SleepTime: 2 * time.Second},
+		}),
+		flow.NewParallel("parallel_first_fail", flow.JoinFirstFail, []flow.State{
+			&SleepState{SleepTime: 5 * time.Second},
+			&RunErrorState{},
+		}),
+	},
+}
+
+var BranchSequence = flow.Sequence{
+	Name: "Branch 🌿",
+	Desc: "Chooses the next state from the prior results.",
+	States: []flow.State{
+		&DoNothingState{},
+		flow.NewBranch("flashed_branch", func(priorResults map[flow.Tag]any) (string, error) {
+			flashed, _ := priorResults[config.FirmwareTags.FrontControllerFlashed].(bool)
+			if flashed {
+				return "flashed", nil
+			}
+
+			return "not_flashed", nil
+		}, map[string]flow.State{
+			"flashed":     &SleepState{SleepTime: 1 * time.Second},
+			"not_flashed": &RunErrorState{},
+		}),
+	},
+}
+
+var MatrixSequence = flow.Sequence{
+	Name: "Matrix 🧮",
+	Desc: "Runs the same states for every combination of params.",
+	States: []flow.State{
+		&DoNothingState{},
+		&SleepState{SleepTime: 1 * time.Second},
+	},
+	Matrix: map[string][]any{
+		"pedal_position": {0.0, 50.0, 100.0},
+		"revision":       {"sil", "ev5"},
+	},
+}
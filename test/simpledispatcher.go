@@ -17,9 +17,14 @@ type SimpleDispatcher struct {
 	startSig        chan orchestrator.StartSignal
 	shutdownSig     chan orchestrator.ShutdownSignal
 	cancelSig       chan orchestrator.CancelTestSignal
+	moveSig         chan orchestrator.MoveTestSignal
 	recoverFatalSig chan orchestrator.RecoverFromFatalSignal
+	pauseSig        chan orchestrator.PauseSignal
+	resumeSig       chan orchestrator.ResumeSignal
+	stepSig         chan orchestrator.StepSignal
 	status          chan orchestrator.StatusSignal
 	resultsSig      chan orchestrator.ResultsSignal
+	measurements    chan orchestrator.MeasurementSignal
 	durations       []time.Duration
 	l               *zap.Logger
 }
@@ -30,9 +35,14 @@ func NewSimpleDispatcher(l *zap.Logger, durations ...time.Duration) *SimpleDispa
 		startSig:        make(chan orchestrator.StartSignal),
 		shutdownSig:     make(chan orchestrator.ShutdownSignal),
 		cancelSig:       make(chan orchestrator.CancelTestSignal),
+		moveSig:         make(chan orchestrator.MoveTestSignal),
 		recoverFatalSig: make(chan orchestrator.RecoverFromFatalSignal),
+		pauseSig:        make(chan orchestrator.PauseSignal),
+		resumeSig:       make(chan orchestrator.ResumeSignal),
+		stepSig:         make(chan orchestrator.StepSignal),
 		status:          make(chan orchestrator.StatusSignal),
 		resultsSig:      make(chan orchestrator.ResultsSignal),
+		measurements:    make(chan orchestrator.MeasurementSignal),
 		durations:       durations,
 	}
 }
@@ -79,6 +89,10 @@ func (s *SimpleDispatcher) CancelTest() <-chan orchestrator.CancelTestSignal {
 	return s.cancelSig
 }
 
+func (s *SimpleDispatcher) MoveTest() <-chan orchestrator.MoveTestSignal {
+	return s.moveSig
+}
+
 func (s *SimpleDispatcher) Shutdown() <-chan orchestrator.ShutdownSignal {
 	return s.shutdownSig
 }
@@ -87,6 +101,18 @@ func (s *SimpleDispatcher) RecoverFromFatal() <-chan orchestrator.RecoverFromFat
 	return s.recoverFatalSig
 }
 
+func (s *SimpleDispatcher) Pause() <-chan orchestrator.PauseSignal {
+	return s.pauseSig
+}
+
+func (s *SimpleDispatcher) Resume() <-chan orchestrator.ResumeSignal {
+	return s.resumeSig
+}
+
+func (s *SimpleDispatcher) Step() <-chan orchestrator.StepSignal {
+	return s.stepSig
+}
+
 func (s *SimpleDispatcher) Status() chan<- orchestrator.StatusSignal {
 	return s.status
 }
@@ -95,6 +121,10 @@ func (s *SimpleDispatcher) Results() chan<- orchestrator.ResultsSignal {
 	return s.resultsSig
 }
 
+func (s *SimpleDispatcher) Measurements() chan<- orchestrator.MeasurementSignal {
+	return s.measurements
+}
+
 func (s *SimpleDispatcher) monitorOrchestrator(ctx context.Context) {
 	for {
 		select {
@@ -107,6 +137,12 @@ func (s *SimpleDispatcher) monitorOrchestrator(ctx context.Context) {
 			s.l.Info("results signal received",
 				zap.String("testid", results.TestId.String()))
 
+		case measurement := <-s.measurements:
+			s.l.Debug("measurement signal received",
+				zap.String("testid", measurement.TestId.String()),
+				zap.String("name", measurement.Sample.Name),
+				zap.Float64("value", measurement.Sample.Value))
+
 		case <-ctx.Done():
 			s.l.Info("context done signal received")
 
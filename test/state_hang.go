@@ -0,0 +1,47 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"github.com/macformula/hil/flow"
+)
+
+// HangState ignores its context in the Run function, like a state stuck on a blocking read. The Sequencer abandons it
+// once the hang grace period is over.
+type HangState struct{}
+
+// Timeout returns the state setup and run timeout.
+func (h *HangState) Timeout() time.Duration {
+	return time.Second
+}
+
+// Setup executes any necessary setup logic before run.
+func (h *HangState) Setup(_ context.Context) error {
+	return nil
+}
+
+// Name is the name of the state.
+func (h *HangState) Name() string {
+	return "hang_state"
+}
+
+// Run blocks forever without checking the context.
+func (h *HangState) Run(_ context.Context) error {
+	select {}
+}
+
+// ContinueOnFail returns false, a hung state is a fatal error anyway.
+func (h *HangState) ContinueOnFail() bool {
+	return false
+}
+
+// GetResults returns no results.
+func (h *HangState) GetResults() map[flow.Tag]any {
+	return map[flow.Tag]any{}
+}
+
+// FatalError indicates if any non-recoverable errors have occurred.
+func (h *HangState) FatalError() error {
+	return nil
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"github.com/macformula/hil/flow"
 	"github.com/macformula/hil/macformula/config"
+	"github.com/macformula/hil/utils"
 	"time"
 
 	"github.com/pkg/errors"
 )
 
+const _recoveryActionDuration = time.Second
+
 // RunFatalErrorState returns an error when Run is called and when FatalError is called.
 type RunFatalErrorState struct{}
 
@@ -43,7 +46,16 @@ func (r *RunFatalErrorState) Run(_ context.Context) error {
 	return errors.New("there has been a run error")
 }
 
-// FatalError indicates if any non-recoverable errors have occured.
+// FatalError indicates if any non-recoverable errors have occured. It has a recovery procedure with a checklist
+// and an action that waits a second.
 func (r *RunFatalErrorState) FatalError() error {
-	return errors.New("there has been a fatal run error")
+	return flow.WithRecovery(errors.New("there has been a fatal run error"), flow.RecoveryProcedure{
+		Checklist: []string{"Check the bench is powered", "Check the can interfaces are up"},
+		Actions: []flow.RecoveryAction{{
+			Name: "wait",
+			Do: func(ctx context.Context) error {
+				return utils.Sleep(ctx, _recoveryActionDuration)
+			},
+		}},
+	})
 }
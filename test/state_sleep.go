@@ -4,9 +4,14 @@ import (
 	"context"
 	"github.com/macformula/hil/flow"
 	"github.com/macformula/hil/macformula/config"
+	"math"
 	"time"
 )
 
+const (
+	_sleepSamplePeriod = 100 * time.Millisecond
+)
+
 // SleepState sleeps in the Run function for a specified amount of time.
 type SleepState struct {
 	SleepTime time.Duration
@@ -42,13 +47,26 @@ func (s *SleepState) Name() string {
 	return "sleep_state"
 }
 
-// Run is the logic that gets executed after setup.
+// Run is the logic that gets executed after setup. It publishes a sine wave while it sleeps.
 func (s *SleepState) Run(ctx context.Context) error {
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-time.After(s.SleepTime):
-		return nil
+	ticker := time.NewTicker(_sleepSamplePeriod)
+	defer ticker.Stop()
+
+	start := time.Now()
+	done := time.After(s.SleepTime)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+			return nil
+		case <-ticker.C:
+			elapsed := time.Since(start)
+
+			flow.PublishMeasurement(ctx, "sine", math.Sin(elapsed.Seconds()*math.Pi), "V")
+			flow.PublishBool(ctx, "square", int(elapsed.Seconds())%2 == 0)
+		}
 	}
 }
 
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"sync"
+)
+
+const (
+	_statusEvent  = "status"
+	_resultsEvent = "results"
+	// _measurementEvent is sent for every live sample published by the running test.
+	_measurementEvent = "measurement"
+	// _subscriberBuffer is the number of events buffered per client before the client's stream is ended.
+	_subscriberBuffer = 32
+)
+
+// event is a single server-sent event.
+type event struct {
+	name string
+	data []byte
+}
+
+// broker fans out events to every connected event stream client. The stream of a slow client is ended rather than
+// blocking the orchestrator, so it never silently misses results or status. Only measurements are dropped.
+type broker struct {
+	mtx         sync.Mutex
+	subscribers map[chan event]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{
+		subscribers: make(map[chan event]struct{}),
+	}
+}
+
+func (b *broker) subscribe() chan event {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	ch := make(chan event, _subscriberBuffer)
+	b.subscribers[ch] = struct{}{}
+
+	return ch
+}
+
+func (b *broker) unsubscribe(ch chan event) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	delete(b.subscribers, ch)
+}
+
+// publish returns the number of subscribers that were ended because their buffer was full. Their channel is closed.
+func (b *broker) publish(e event) int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	ended := 0
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+
+			ended++
+		}
+	}
+
+	return ended
+}
+
+// publishMeasurement drops the event quietly for slow subscribers since the next sample replaces it.
+func (b *broker) publishMeasurement(e event) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
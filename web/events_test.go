@@ -0,0 +1,42 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerEndsSlowSubscriber(t *testing.T) {
+	b := newBroker()
+
+	slow := b.subscribe()
+	defer b.unsubscribe(slow)
+
+	for i := 0; i < _subscriberBuffer; i++ {
+		assert.Zero(t, b.publish(event{name: _statusEvent}))
+	}
+
+	// Measurements are dropped for a full subscriber without ending its stream.
+	b.publishMeasurement(event{name: _measurementEvent})
+	assert.Contains(t, b.subscribers, slow)
+
+	fast := b.subscribe()
+	defer b.unsubscribe(fast)
+
+	results := event{name: _resultsEvent, data: []byte(`{"testId":"test"}`)}
+	assert.Equal(t, 1, b.publish(results))
+
+	// The slow subscriber gets every event it buffered, then its stream ends rather than missing the results.
+	for i := 0; i < _subscriberBuffer; i++ {
+		e, ok := <-slow
+		require.True(t, ok)
+		assert.Equal(t, _statusEvent, e.name)
+	}
+
+	_, ok := <-slow
+	assert.False(t, ok, "stream of slow subscriber must end")
+	assert.NotContains(t, b.subscribers, slow)
+
+	assert.Equal(t, results, <-fast)
+}
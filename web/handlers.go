@@ -0,0 +1,353 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func (h *HttpDispatcher) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /sequences", h.handleListSequences)
+	mux.HandleFunc("GET /status", h.handleStatus)
+	mux.HandleFunc("GET /events", h.handleEvents)
+	mux.HandleFunc("GET /runs", h.handleListRuns)
+	mux.HandleFunc("GET /schedule", h.handleSchedule)
+	mux.HandleFunc("POST /runs", h.handleStart)
+	mux.HandleFunc("DELETE /runs/{testId}", h.handleCancel)
+	mux.HandleFunc("POST /runs/{testId}/move", h.handleMove)
+	mux.HandleFunc("POST /pause", h.handlePause)
+	mux.HandleFunc("POST /resume", h.handleResume)
+	mux.HandleFunc("POST /step", h.handleStep)
+	mux.HandleFunc("POST /recover", h.handleRecover)
+	mux.HandleFunc("POST /shutdown", h.handleShutdown)
+
+	return mux
+}
+
+func (h *HttpDispatcher) handleListSequences(w http.ResponseWriter, _ *http.Request) {
+	ret := make([]sequenceMessage, 0, len(h.seqOrder))
+
+	for _, name := range h.seqOrder {
+		ret = append(ret, newSequenceMessage(h.sequences[name]))
+	}
+
+	h.writeJson(w, http.StatusOK, ret)
+}
+
+func (h *HttpDispatcher) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	h.writeJson(w, http.StatusOK, newStatusMessage(h.currentStatus()))
+}
+
+func (h *HttpDispatcher) handleListRuns(w http.ResponseWriter, r *http.Request) {
+	if h.runLister == nil {
+		h.writeError(w, http.StatusNotImplemented, errors.New("run history is not enabled"))
+		return
+	}
+
+	query, err := parseRunQuery(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	runs, err := h.runLister.ListRuns(query)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, errors.Wrap(err, "list runs"))
+		return
+	}
+
+	h.writeJson(w, http.StatusOK, runs)
+}
+
+func (h *HttpDispatcher) handleSchedule(w http.ResponseWriter, _ *http.Request) {
+	if h.scheduleLister == nil {
+		h.writeError(w, http.StatusNotImplemented, errors.New("scheduler is not enabled"))
+		return
+	}
+
+	h.writeJson(w, http.StatusOK, h.scheduleLister.Schedule())
+}
+
+func (h *HttpDispatcher) handleStart(w http.ResponseWriter, r *http.Request) {
+	var req startRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, errors.Wrap(err, "decode start request"))
+		return
+	}
+
+	seq, ok := h.sequences[req.Sequence]
+	if !ok {
+		h.writeError(w, http.StatusNotFound, errors.Errorf("unknown sequence (%s)", req.Sequence))
+		return
+	}
+
+	if missing := seq.MissingMetadata(req.Metadata); len(missing) > 0 {
+		h.writeError(w, http.StatusBadRequest, errors.Errorf("missing required metadata (%s)", strings.Join(missing, ", ")))
+		return
+	}
+
+	state := h.currentStatus().OrchestratorState
+	if state == orchestrator.FatalError || state == orchestrator.Recovering {
+		h.writeError(w, http.StatusConflict, errors.New("orchestrator is in fatal error state, must recover from fatal error"))
+		return
+	}
+
+	startSig := orchestrator.StartSignal{
+		TestId:      uuid.New(),
+		Seq:         seq,
+		Metadata:    req.Metadata,
+		Priority:    req.Priority,
+		ScheduledAt: req.ScheduledAt,
+		Breakpoints: req.Breakpoints,
+	}
+
+	if !send(h, w, r, h.start, startSig) {
+		return
+	}
+
+	h.l.Info("start signal sent",
+		zap.String("test id", startSig.TestId.String()),
+		zap.String("sequence", seq.Name),
+		zap.String("remote addr", r.RemoteAddr))
+
+	h.writeJson(w, http.StatusAccepted, startResponse{TestId: startSig.TestId})
+}
+
+func (h *HttpDispatcher) handleCancel(w http.ResponseWriter, r *http.Request) {
+	testId, err := uuid.Parse(r.PathValue("testId"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, errors.Wrap(err, "parse test id"))
+		return
+	}
+
+	if !send(h, w, r, h.cancelTest, orchestrator.CancelTestSignal{TestId: testId}) {
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *HttpDispatcher) handleMove(w http.ResponseWriter, r *http.Request) {
+	testId, err := uuid.Parse(r.PathValue("testId"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, errors.Wrap(err, "parse test id"))
+		return
+	}
+
+	var req moveRequest
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, errors.Wrap(err, "decode move request"))
+		return
+	}
+
+	moveSig := orchestrator.MoveTestSignal{TestId: testId, Position: req.Position}
+
+	if !send(h, w, r, h.moveTest, moveSig) {
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *HttpDispatcher) handlePause(w http.ResponseWriter, r *http.Request) {
+	if h.currentStatus().OrchestratorState != orchestrator.Running {
+		h.writeError(w, http.StatusConflict, errors.New("orchestrator is not running a test"))
+		return
+	}
+
+	if !send(h, w, r, h.pause, orchestrator.PauseSignal{}) {
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *HttpDispatcher) handleResume(w http.ResponseWriter, r *http.Request) {
+	state := h.currentStatus().OrchestratorState
+	if state != orchestrator.Running && state != orchestrator.Paused {
+		h.writeError(w, http.StatusConflict, errors.New("orchestrator is not running a test"))
+		return
+	}
+
+	if !send(h, w, r, h.resume, orchestrator.ResumeSignal{}) {
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *HttpDispatcher) handleStep(w http.ResponseWriter, r *http.Request) {
+	if h.currentStatus().OrchestratorState != orchestrator.Paused {
+		h.writeError(w, http.StatusConflict, errors.New("orchestrator is not paused"))
+		return
+	}
+
+	if !send(h, w, r, h.step, orchestrator.StepSignal{}) {
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *HttpDispatcher) handleRecover(w http.ResponseWriter, r *http.Request) {
+	if h.currentStatus().OrchestratorState != orchestrator.FatalError {
+		h.writeError(w, http.StatusConflict, errors.New("orchestrator is not in fatal error state"))
+		return
+	}
+
+	if !send(h, w, r, h.recoverFromFatal, orchestrator.RecoverFromFatalSignal{}) {
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *HttpDispatcher) handleShutdown(w http.ResponseWriter, r *http.Request) {
+	h.l.Info("shutdown requested", zap.String("remote addr", r.RemoteAddr))
+
+	if !send(h, w, r, h.shutdown, orchestrator.ShutdownSignal{}) {
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleEvents streams status, results and measurements to the client as server-sent events until the client disconnects.
+func (h *HttpDispatcher) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	events := h.events.subscribe()
+	defer h.events.unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// Send the current status right away so clients do not have to wait for the next update.
+	data, err := marshal(newStatusMessage(h.currentStatus()))
+	if err == nil {
+		writeEvent(w, event{name: _statusEvent, data: data})
+	}
+
+	flusher.Flush()
+
+	for {
+		select {
+		case e, ok := <-events:
+			// The client fell behind, it reconnects and gets the current status first.
+			if !ok {
+				return
+			}
+
+			writeEvent(w, e)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-h.closing:
+			return
+		}
+	}
+}
+
+// send hands the signal to the orchestrator unless the request is canceled or the dispatcher is closing first.
+// It returns false if the signal was not sent, in which case an error has already been written.
+func send[T any](h *HttpDispatcher, w http.ResponseWriter, r *http.Request, ch chan T, sig T) bool {
+	select {
+	case ch <- sig:
+		return true
+	case <-r.Context().Done():
+		h.writeError(w, http.StatusServiceUnavailable, errors.Wrap(r.Context().Err(), "request canceled"))
+	case <-h.closing:
+		h.writeError(w, http.StatusServiceUnavailable, errors.New("dispatcher is closing"))
+	}
+
+	return false
+}
+
+func (h *HttpDispatcher) writeJson(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		h.l.Error("failed to write response", zap.Error(err))
+	}
+}
+
+func (h *HttpDispatcher) writeError(w http.ResponseWriter, statusCode int, err error) {
+	h.l.Warn("request failed", zap.Int("status code", statusCode), zap.Error(err))
+
+	h.writeJson(w, statusCode, errorResponse{Error: err.Error()})
+}
+
+// parseRunQuery reads the sequence, status, since and limit query parameters.
+func parseRunQuery(r *http.Request) (orchestrator.RunQuery, error) {
+	values := r.URL.Query()
+
+	query := orchestrator.RunQuery{
+		SequenceName: values.Get("sequence"),
+	}
+
+	if statuses := values.Get("status"); statuses != "" {
+		for _, s := range strings.Split(statuses, ",") {
+			status, err := orchestrator.RunStatusString(s)
+			if err != nil {
+				return orchestrator.RunQuery{}, errors.Wrap(err, "parse status")
+			}
+
+			query.Statuses = append(query.Statuses, status)
+		}
+	}
+
+	if since := values.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return orchestrator.RunQuery{}, errors.Wrap(err, "parse since")
+		}
+
+		query.Since = t
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return orchestrator.RunQuery{}, errors.Wrap(err, "parse limit")
+		}
+
+		query.Limit = n
+	}
+
+	return query, nil
+}
+
+func marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "json marshal")
+	}
+
+	return data, nil
+}
+
+func writeEvent(w http.ResponseWriter, e event) {
+	_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.name, e.data)
+}
@@ -0,0 +1,259 @@
+package web
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/orchestrator"
+	"github.com/macformula/hil/scheduler"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	_loggerName      = "http_dispatcher"
+	_dispatcherName  = "http_dispatcher"
+	_shutdownTimeout = 5 * time.Second
+)
+
+// RunListerIface returns the history of test runs, it is implemented by orchestrator.Orchestrator.
+type RunListerIface interface {
+	ListRuns(query orchestrator.RunQuery) ([]orchestrator.RunRecord, error)
+}
+
+// ScheduleListerIface returns the recurring runs and their latest outcomes, it is implemented by
+// scheduler.SchedulerDispatcher.
+type ScheduleListerIface interface {
+	Schedule() []scheduler.EntryStatus
+}
+
+// HttpDispatcher is the HTTP implementation of the orchestrator.DispatcherIface. It exposes a REST API to start,
+// cancel and move tests and streams status and results to clients as server-sent events.
+type HttpDispatcher struct {
+	l              *zap.Logger
+	addr           string
+	sequences      map[string]flow.Sequence
+	seqOrder       []string
+	runLister      RunListerIface
+	scheduleLister ScheduleListerIface
+
+	server  *http.Server
+	events  *broker
+	closing chan struct{}
+
+	start            chan orchestrator.StartSignal
+	results          chan orchestrator.ResultsSignal
+	status           chan orchestrator.StatusSignal
+	measurements     chan orchestrator.MeasurementSignal
+	cancelTest       chan orchestrator.CancelTestSignal
+	moveTest         chan orchestrator.MoveTestSignal
+	recoverFromFatal chan orchestrator.RecoverFromFatalSignal
+	pause            chan orchestrator.PauseSignal
+	resume           chan orchestrator.ResumeSignal
+	step             chan orchestrator.StepSignal
+	shutdown         chan orchestrator.ShutdownSignal
+
+	statusMtx  sync.Mutex
+	lastStatus orchestrator.StatusSignal
+}
+
+// NewHttpDispatcher creates an http dispatcher that will listen on addr, for example ":8000".
+func NewHttpDispatcher(addr string, sequences []flow.Sequence, l *zap.Logger) *HttpDispatcher {
+	ret := &HttpDispatcher{
+		l:                l.Named(_loggerName),
+		addr:             addr,
+		sequences:        make(map[string]flow.Sequence, len(sequences)),
+		seqOrder:         make([]string, 0, len(sequences)),
+		events:           newBroker(),
+		closing:          make(chan struct{}),
+		start:            make(chan orchestrator.StartSignal),
+		results:          make(chan orchestrator.ResultsSignal),
+		status:           make(chan orchestrator.StatusSignal),
+		measurements:     make(chan orchestrator.MeasurementSignal),
+		cancelTest:       make(chan orchestrator.CancelTestSignal),
+		moveTest:         make(chan orchestrator.MoveTestSignal),
+		recoverFromFatal: make(chan orchestrator.RecoverFromFatalSignal),
+		pause:            make(chan orchestrator.PauseSignal),
+		resume:           make(chan orchestrator.ResumeSignal),
+		step:             make(chan orchestrator.StepSignal),
+		shutdown:         make(chan orchestrator.ShutdownSignal),
+	}
+
+	for _, seq := range sequences {
+		ret.sequences[seq.Name] = seq
+		ret.seqOrder = append(ret.seqOrder, seq.Name)
+	}
+
+	return ret
+}
+
+// SetScheduleLister enables the schedule endpoint. It must be called before Open.
+func (h *HttpDispatcher) SetScheduleLister(scheduleLister ScheduleListerIface) {
+	h.scheduleLister = scheduleLister
+}
+
+// SetRunLister enables the run history endpoint. It must be called before Open.
+func (h *HttpDispatcher) SetRunLister(runLister RunListerIface) {
+	h.runLister = runLister
+}
+
+// Name returns the dispatcher name.
+func (h *HttpDispatcher) Name() string {
+	return _dispatcherName
+}
+
+// Open starts the http server.
+func (h *HttpDispatcher) Open(ctx context.Context) error {
+	listener, err := net.Listen("tcp", h.addr)
+	if err != nil {
+		return errors.Wrapf(err, "listen (%s)", h.addr)
+	}
+
+	h.server = &http.Server{
+		Handler: h.routes(),
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		err := h.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			h.l.Error("http server stopped", zap.Error(err))
+		}
+	}()
+
+	go h.monitorOrchestrator(ctx)
+
+	h.l.Info("http dispatcher listening", zap.String("addr", listener.Addr().String()))
+
+	return nil
+}
+
+// Close stops the http server and disconnects event stream clients.
+func (h *HttpDispatcher) Close() error {
+	h.l.Info("closing http dispatcher")
+
+	close(h.closing)
+
+	if h.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _shutdownTimeout)
+	defer cancel()
+
+	err := h.server.Shutdown(ctx)
+	if err != nil {
+		return errors.Wrap(err, "http server shutdown")
+	}
+
+	return nil
+}
+
+// Start signal is sent by the dispatcher to the orchestrator to start a test sequence.
+func (h *HttpDispatcher) Start() <-chan orchestrator.StartSignal {
+	return h.start
+}
+
+// CancelTest will cancel execution of the test with the given ID.
+func (h *HttpDispatcher) CancelTest() <-chan orchestrator.CancelTestSignal {
+	return h.cancelTest
+}
+
+// MoveTest will move a queued test to a new position in the test queue.
+func (h *HttpDispatcher) MoveTest() <-chan orchestrator.MoveTestSignal {
+	return h.moveTest
+}
+
+// Shutdown will shut down the hil app.
+func (h *HttpDispatcher) Shutdown() <-chan orchestrator.ShutdownSignal {
+	return h.shutdown
+}
+
+// RecoverFromFatal will tell the orchestrator to leave the fatal error state and go back to idle.
+func (h *HttpDispatcher) RecoverFromFatal() <-chan orchestrator.RecoverFromFatalSignal {
+	return h.recoverFromFatal
+}
+
+// Pause will halt the running test before its next state.
+func (h *HttpDispatcher) Pause() <-chan orchestrator.PauseSignal {
+	return h.pause
+}
+
+// Resume will continue a paused test.
+func (h *HttpDispatcher) Resume() <-chan orchestrator.ResumeSignal {
+	return h.resume
+}
+
+// Step will run the next state of a paused test and pause again.
+func (h *HttpDispatcher) Step() <-chan orchestrator.StepSignal {
+	return h.step
+}
+
+// Status signal is sent on updates from the orchestrator.
+func (h *HttpDispatcher) Status() chan<- orchestrator.StatusSignal {
+	return h.status
+}
+
+// Results signal is sent at the end of a test execution or on test cancel.
+func (h *HttpDispatcher) Results() chan<- orchestrator.ResultsSignal {
+	return h.results
+}
+
+// Measurements signal is sent for every live sample published by the running test.
+func (h *HttpDispatcher) Measurements() chan<- orchestrator.MeasurementSignal {
+	return h.measurements
+}
+
+func (h *HttpDispatcher) monitorOrchestrator(ctx context.Context) {
+	for {
+		select {
+		case status := <-h.status:
+			h.statusMtx.Lock()
+			h.lastStatus = status
+			h.statusMtx.Unlock()
+
+			h.publish(_statusEvent, newStatusMessage(status))
+		case results := <-h.results:
+			h.l.Info("results signal received", zap.String("test id", results.TestId.String()))
+
+			h.publish(_resultsEvent, newResultsMessage(results))
+		case measurement := <-h.measurements:
+			h.publish(_measurementEvent, newMeasurementMessage(measurement))
+		case <-ctx.Done():
+			h.l.Info("context done signal received")
+
+			return
+		}
+	}
+}
+
+func (h *HttpDispatcher) publish(name string, msg any) {
+	data, err := marshal(msg)
+	if err != nil {
+		h.l.Error("failed to marshal event", zap.String("event", name), zap.Error(err))
+		return
+	}
+
+	if name == _measurementEvent {
+		h.events.publishMeasurement(event{name: name, data: data})
+		return
+	}
+
+	ended := h.events.publish(event{name: name, data: data})
+	if ended > 0 {
+		h.l.Warn("ended event streams of slow clients", zap.String("event", name), zap.Int("clients", ended))
+	}
+}
+
+func (h *HttpDispatcher) currentStatus() orchestrator.StatusSignal {
+	h.statusMtx.Lock()
+	defer h.statusMtx.Unlock()
+
+	return h.lastStatus
+}
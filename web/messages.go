@@ -0,0 +1,192 @@
+package web
+
+import (
+	"time"
+
+	"github.com/macformula/hil/flow"
+	"github.com/macformula/hil/orchestrator"
+)
+
+// sequenceMessage describes a sequence that can be started through the HttpDispatcher.
+type sequenceMessage struct {
+	Name     string           `json:"name"`
+	Desc     string           `json:"desc"`
+	States   []string         `json:"states"`
+	Teardown []string         `json:"teardown,omitempty"`
+	Matrix   map[string][]any `json:"matrix,omitempty"`
+	// RequiredMetadata are the metadata keys that must be supplied to start the sequence.
+	RequiredMetadata []string `json:"requiredMetadata,omitempty"`
+}
+
+// startRequest is the body of a request to queue a test.
+type startRequest struct {
+	Sequence    string                `json:"sequence"`
+	Metadata    map[string]string     `json:"metadata"`
+	Priority    orchestrator.Priority `json:"priority"`
+	ScheduledAt time.Time             `json:"scheduledAt"`
+	Breakpoints []string              `json:"breakpoints"`
+}
+
+// startResponse is returned once a test has been queued.
+type startResponse struct {
+	TestId orchestrator.TestId `json:"testId"`
+}
+
+// moveRequest is the body of a request to move a queued test.
+type moveRequest struct {
+	Position int `json:"position"`
+}
+
+// errorResponse is returned by every endpoint on failure.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// statusMessage is the JSON form of an orchestrator.StatusSignal.
+type statusMessage struct {
+	OrchestratorState string                    `json:"orchestratorState"`
+	TestId            orchestrator.TestId       `json:"testId"`
+	Sequence          string                    `json:"sequence,omitempty"`
+	CurrentState      string                    `json:"currentState,omitempty"`
+	CurrentPath       string                    `json:"currentPath,omitempty"`
+	StateIndex        int                       `json:"stateIndex"`
+	Iteration         int                       `json:"iteration"`
+	Iterations        int                       `json:"iterations"`
+	Params            string                    `json:"params,omitempty"`
+	StatePassed       []bool                    `json:"statePassed"`
+	StateDuration     []time.Duration           `json:"stateDuration"`
+	Children          []flow.ChildProgress      `json:"children,omitempty"`
+	Queue             []orchestrator.QueuedTest `json:"queue"`
+	FatalError        string                    `json:"fatalError,omitempty"`
+	RecoveryChecklist []string                  `json:"recoveryChecklist,omitempty"`
+	RecoveryActions   []string                  `json:"recoveryActions,omitempty"`
+	RecoveryError     string                    `json:"recoveryError,omitempty"`
+}
+
+// resultsMessage is the JSON form of an orchestrator.ResultsSignal.
+type resultsMessage struct {
+	TestId         orchestrator.TestId `json:"testId"`
+	IsPassing      bool                `json:"isPassing"`
+	FailedTags     []flow.Tag          `json:"failedTags"`
+	TestErrors     []string            `json:"testErrors"`
+	TeardownErrors []string            `json:"teardownErrors"`
+	ReportPaths    []string            `json:"reportPaths"`
+	FatalError     string              `json:"fatalError,omitempty"`
+	BenchError     string              `json:"benchError,omitempty"`
+}
+
+// measurementMessage is the JSON form of an orchestrator.MeasurementSignal.
+type measurementMessage struct {
+	TestId orchestrator.TestId `json:"testId"`
+	Name   string              `json:"name"`
+	Value  float64             `json:"value"`
+	Unit   string              `json:"unit,omitempty"`
+	IsBool bool                `json:"isBool,omitempty"`
+	State  string              `json:"state"`
+	Time   time.Time           `json:"time"`
+}
+
+func newSequenceMessage(seq flow.Sequence) sequenceMessage {
+	return sequenceMessage{
+		Name:             seq.Name,
+		Desc:             seq.Desc,
+		States:           stateNames(seq.States),
+		Teardown:         stateNames(seq.Teardown),
+		Matrix:           seq.Matrix,
+		RequiredMetadata: seq.RequiredMetadata,
+	}
+}
+
+func newStatusMessage(status orchestrator.StatusSignal) statusMessage {
+	progress := status.Progress
+
+	ret := statusMessage{
+		OrchestratorState: status.OrchestratorState.String(),
+		TestId:            status.TestId,
+		Sequence:          progress.Sequence.Name,
+		StateIndex:        progress.StateIndex,
+		Iteration:         progress.Iteration,
+		Iterations:        progress.Iterations,
+		Params:            progress.Params.String(),
+		StatePassed:       progress.StatePassed,
+		StateDuration:     progress.StateDuration,
+		Children:          progress.Children,
+		CurrentPath:       progress.CurrentPath(),
+		Queue:             status.Queue,
+	}
+
+	if progress.CurrentState != nil {
+		ret.CurrentState = progress.CurrentState.Name()
+	}
+
+	if status.FatalError != nil {
+		ret.FatalError = status.FatalError.Error()
+	}
+
+	if procedure, ok := flow.Recovery(status.FatalError); ok {
+		ret.RecoveryChecklist = procedure.Checklist
+		ret.RecoveryActions = procedure.ActionNames()
+	}
+
+	if status.RecoveryError != nil {
+		ret.RecoveryError = status.RecoveryError.Error()
+	}
+
+	return ret
+}
+
+func newResultsMessage(results orchestrator.ResultsSignal) resultsMessage {
+	ret := resultsMessage{
+		TestId:         results.TestId,
+		IsPassing:      results.IsPassing,
+		FailedTags:     results.FailedTags,
+		TestErrors:     errorStrings(results.TestErrors),
+		TeardownErrors: errorStrings(results.TeardownErrors),
+		ReportPaths:    results.ReportPaths,
+	}
+
+	if results.FatalError != nil {
+		ret.FatalError = results.FatalError.Error()
+	}
+
+	if results.BenchError != nil {
+		ret.BenchError = results.BenchError.Error()
+	}
+
+	return ret
+}
+
+func stateNames(states []flow.State) []string {
+	ret := make([]string, len(states))
+	for i, state := range states {
+		ret[i] = state.Name()
+	}
+
+	return ret
+}
+
+func errorStrings(errs []error) []string {
+	ret := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		if err != nil {
+			ret = append(ret, err.Error())
+		}
+	}
+
+	return ret
+}
+
+func newMeasurementMessage(measurement orchestrator.MeasurementSignal) measurementMessage {
+	sample := measurement.Sample
+
+	return measurementMessage{
+		TestId: measurement.TestId,
+		Name:   sample.Name,
+		Value:  sample.Value,
+		Unit:   sample.Unit,
+		IsBool: sample.IsBool,
+		State:  sample.StateName,
+		Time:   sample.Time,
+	}
+}